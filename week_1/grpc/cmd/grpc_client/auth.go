@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"google.golang.org/grpc"
+)
+
+// bearerToken добавляет токен UFO_TOKEN в метаданные каждого вызова
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity разрешает токен и без TLS, чтобы локальный стенд
+// работал без сертификатов. Защиту канала настраивают переменные UFO_TLS_*
+func (t bearerToken) RequireTransportSecurity() bool {
+	return false
+}
+
+// authOptions возвращает опции соединения с токеном, если он задан
+func authOptions() []grpc.DialOption {
+	token := os.Getenv("UFO_TOKEN")
+	if token == "" {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithPerRPCCredentials(bearerToken(token))}
+}
@@ -2,22 +2,36 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"os"
+	"sync"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/tracing"
 	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
 )
 
 const serverAddress = "localhost:50051"
 
-// createSighting создает новое наблюдение НЛО с рандомными данными
-func createSighting(ctx context.Context, client ufoV1.UFOServiceClient) (string, error) {
+// randomSightingInfo генерирует случайную информацию о наблюдении НЛО
+func randomSightingInfo() *ufoV1.SightingInfo {
 	// Генерируем случайные данные с помощью gofakeit
 	observedAt := gofakeit.DateRange(
 		time.Now().AddDate(-3, 0, 0), // за последние 3 года
@@ -43,18 +57,76 @@ func createSighting(ctx context.Context, client ufoV1.UFOServiceClient) (string,
 	}
 
 	if gofakeit.Bool() {
-		info.DurationSeconds = wrapperspb.Int32(gofakeit.Int32())
+		info.DurationSeconds = wrapperspb.Int32(int32(gofakeit.Number(1, 3600)))
+	}
+
+	if gofakeit.Bool() {
+		info.Coordinates = &ufoV1.GeoPoint{Latitude: gofakeit.Latitude(), Longitude: gofakeit.Longitude()}
+	}
+
+	return info
+}
+
+// createSighting создает новое наблюдение НЛО с рандомными данными
+func createSighting(ctx context.Context, client ufoV1.UFOServiceClient) (string, error) {
+	req := &ufoV1.CreateRequest{
+		Info:           randomSightingInfo(),
+		IdempotencyKey: gofakeit.UUID(),
 	}
 
 	// Вызываем gRPC метод Create
-	resp, err := client.Create(ctx, &ufoV1.CreateRequest{Info: info})
+	resp, err := client.Create(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	// Повторяем запрос с тем же ключом, как сделал бы клиент после таймаута:
+	// сервер не создает дубликат, а возвращает исходный ответ
+	retry, err := client.Create(ctx, req)
 	if err != nil {
 		return "", err
 	}
+	log.Printf("🔁 Повтор с ключом %s вернул UUID=%s (дубликат не создан: %t)\n",
+		req.IdempotencyKey, retry.Uuid, retry.Uuid == resp.Uuid)
+
+	// Тот же ключ с другими данными - ошибка клиента, а не повтор
+	req.Info = randomSightingInfo()
+	_, err = client.Create(ctx, req)
+	if status.Code(err) == codes.FailedPrecondition {
+		log.Printf("🚫 Ключ с другими данными отклонен: %v\n", status.Convert(err).Message())
+	} else if err != nil {
+		return "", err
+	}
 
 	return resp.Uuid, nil
 }
 
+// importSightings загружает пачку наблюдений одним клиентским стримом
+func importSightings(ctx context.Context, client ufoV1.UFOServiceClient, infos []*ufoV1.SightingInfo, atomic bool) (*ufoV1.ImportSightingsResponse, error) {
+	stream, err := client.ImportSightings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = stream.Send(&ufoV1.ImportSightingsRequest{
+		Payload: &ufoV1.ImportSightingsRequest_Options{Options: &ufoV1.ImportOptions{Atomic: atomic}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range infos {
+		err = stream.Send(&ufoV1.ImportSightingsRequest{
+			Payload: &ufoV1.ImportSightingsRequest_Info{Info: info},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
 // getSighting получает информацию о наблюдении по UUID
 func getSighting(ctx context.Context, client ufoV1.UFOServiceClient, uuid string) (*ufoV1.Sighting, error) {
 	resp, err := client.Get(ctx, &ufoV1.GetRequest{Uuid: uuid})
@@ -65,8 +137,18 @@ func getSighting(ctx context.Context, client ufoV1.UFOServiceClient, uuid string
 	return resp.Sighting, nil
 }
 
-// updateSighting обновляет наблюдение НЛО
-func updateSighting(ctx context.Context, client ufoV1.UFOServiceClient, uuid string) error {
+// getDeletedSighting получает информацию о наблюдении по UUID, включая удаленные
+func getDeletedSighting(ctx context.Context, client ufoV1.UFOServiceClient, uuid string) (*ufoV1.Sighting, error) {
+	resp, err := client.Get(ctx, &ufoV1.GetRequest{Uuid: uuid, IncludeDeleted: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Sighting, nil
+}
+
+// updateSighting обновляет наблюдение НЛО, если его версия все еще expectedVersion, и возвращает новую версию
+func updateSighting(ctx context.Context, client ufoV1.UFOServiceClient, uuid string, expectedVersion int64) (int64, error) {
 	// Генерируем рандомные данные для обновления
 	updateInfo := &ufoV1.SightingUpdateInfo{}
 
@@ -97,14 +179,34 @@ func updateSighting(ctx context.Context, client ufoV1.UFOServiceClient, uuid str
 	}
 
 	if gofakeit.Bool() {
-		updateInfo.DurationSeconds = wrapperspb.Int32(gofakeit.Int32())
+		updateInfo.DurationSeconds = wrapperspb.Int32(int32(gofakeit.Number(1, 3600)))
 	}
 
 	// Вызываем gRPC метод Update
+	resp, err := client.Update(ctx, &ufoV1.UpdateRequest{
+		Uuid:            uuid,
+		UpdateInfo:      updateInfo,
+		ExpectedVersion: wrapperspb.Int64(expectedVersion),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.GetVersion(), nil
+}
+
+// clearOptionalFields сбрасывает цвет и звук наблюдения: поля есть в маске, но не заданы в update_info
+func clearOptionalFields(ctx context.Context, client ufoV1.UFOServiceClient, uuid string) error {
 	_, err := client.Update(ctx, &ufoV1.UpdateRequest{
 		Uuid:       uuid,
-		UpdateInfo: updateInfo,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"color", "sound"}},
 	})
+	return err
+}
+
+// deleteSighting удаляет наблюдение НЛО
+func deleteSighting(ctx context.Context, client ufoV1.UFOServiceClient, uuid string) error {
+	_, err := client.Delete(ctx, &ufoV1.DeleteRequest{Uuid: uuid})
 	if err != nil {
 		return err
 	}
@@ -112,22 +214,251 @@ func updateSighting(ctx context.Context, client ufoV1.UFOServiceClient, uuid str
 	return nil
 }
 
-// deleteSighting удаляет наблюдение НЛО
-func deleteSighting(ctx context.Context, client ufoV1.UFOServiceClient, uuid string) error {
-	_, err := client.Delete(ctx, &ufoV1.DeleteRequest{Uuid: uuid})
+// listSightings получает все наблюдения, подходящие под фильтр, обходя страницы по next_page_token
+func listSightings(ctx context.Context, client ufoV1.UFOServiceClient, filter *ufoV1.ListFilter, pageSize int32) ([]*ufoV1.Sighting, error) {
+	var (
+		sightings []*ufoV1.Sighting
+		pageToken string
+	)
+
+	for {
+		resp, err := client.List(ctx, &ufoV1.ListRequest{
+			PageSize:  pageSize,
+			PageToken: pageToken,
+			Filter:    filter,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		sightings = append(sightings, resp.GetSightings()...)
+		if resp.GetNextPageToken() == "" {
+			return sightings, nil
+		}
+		pageToken = resp.GetNextPageToken()
+	}
+}
+
+// searchSightings ищет наблюдения по словам и логирует первую страницу результатов
+func searchSightings(ctx context.Context, client ufoV1.UFOServiceClient, query string) error {
+	resp, err := client.Search(ctx, &ufoV1.SearchRequest{Query: query, PageSize: 5})
 	if err != nil {
 		return err
 	}
 
+	log.Printf("По запросу %q найдено наблюдений: %d", query, resp.GetTotalSize())
+	for _, result := range resp.GetResults() {
+		log.Printf("UUID=%s, релевантность=%.3f, место=%s",
+			result.GetSighting().GetUuid(), result.GetScore(), result.GetSighting().GetInfo().GetLocation())
+	}
+	return nil
+}
+
+// findNearby ищет наблюдения в радиусе от точки и логирует первую страницу результатов
+func findNearby(ctx context.Context, client ufoV1.UFOServiceClient, center *ufoV1.GeoPoint, radiusKm float64) error {
+	resp, err := client.FindNearby(ctx, &ufoV1.FindNearbyRequest{
+		Area:     &ufoV1.FindNearbyRequest_Circle{Circle: &ufoV1.GeoCircle{Center: center, RadiusKm: radiusKm}},
+		PageSize: 5,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("В радиусе %.0f км от (%.4f, %.4f) найдено наблюдений: %d",
+		radiusKm, center.GetLatitude(), center.GetLongitude(), resp.GetTotalSize())
+	for _, nearby := range resp.GetSightings() {
+		log.Printf("UUID=%s, расстояние=%.1f км, место=%s",
+			nearby.GetSighting().GetUuid(), nearby.GetDistanceKm(), nearby.GetSighting().GetInfo().GetLocation())
+	}
+	return nil
+}
+
+// logStats логирует сводную статистику по всем неудаленным наблюдениям
+func logStats(ctx context.Context, client ufoV1.UFOServiceClient) error {
+	resp, err := client.GetStats(ctx, &ufoV1.GetStatsRequest{GroupLimit: 3})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Всего наблюдений: %d, месяцев с наблюдениями: %d", resp.GetTotal(), len(resp.GetByMonth()))
+	for _, group := range resp.GetByColor() {
+		log.Printf("Цвет %q: %d", group.GetValue(), group.GetCount())
+	}
+	if d := resp.GetDuration(); d != nil {
+		log.Printf("Продолжительность: медиана %d с, p90 %d с, максимум %d с", d.GetP50Seconds(), d.GetP90Seconds(), d.GetMaxSeconds())
+	}
+	return nil
+}
+
+// uploadAttachment загружает файл к наблюдению частями по chunkSize байт
+func uploadAttachment(ctx context.Context, client ufoV1.UFOServiceClient, uuid, fileName, contentType string, data []byte, chunkSize int) (*ufoV1.UploadAttachmentResponse, error) {
+	stream, err := client.UploadAttachment(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	err = stream.Send(&ufoV1.UploadAttachmentRequest{
+		Payload: &ufoV1.UploadAttachmentRequest_Header{Header: &ufoV1.UploadAttachmentHeader{
+			SightingUuid: uuid,
+			FileName:     fileName,
+			ContentType:  contentType,
+			SizeBytes:    int64(len(data)),
+			Sha256:       hex.EncodeToString(sum[:]),
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for offset := 0; offset < len(data); offset += chunkSize {
+		end := min(offset+chunkSize, len(data))
+		err = stream.Send(&ufoV1.UploadAttachmentRequest{
+			Payload: &ufoV1.UploadAttachmentRequest_Chunk{Chunk: data[offset:end]},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+// downloadAttachment скачивает вложение и сверяет его контрольную сумму с метаданными
+func downloadAttachment(ctx context.Context, client ufoV1.UFOServiceClient, uuid, attachmentID string) (*ufoV1.Attachment, []byte, error) {
+	stream, err := client.DownloadAttachment(ctx, &ufoV1.DownloadAttachmentRequest{SightingUuid: uuid, AttachmentId: attachmentID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		attachment *ufoV1.Attachment
+		data       []byte
+	)
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch payload := resp.GetPayload().(type) {
+		case *ufoV1.DownloadAttachmentResponse_Attachment:
+			attachment = payload.Attachment
+		case *ufoV1.DownloadAttachmentResponse_Chunk:
+			data = append(data, payload.Chunk...)
+		}
+	}
+
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != attachment.GetSha256() {
+		return nil, nil, fmt.Errorf("sha256 mismatch for attachment %s", attachmentID)
+	}
+	return attachment, data, nil
+}
+
+// logHistory логирует историю изменений наблюдения
+func logHistory(ctx context.Context, client ufoV1.UFOServiceClient, uuid string) error {
+	resp, err := client.GetHistory(ctx, &ufoV1.GetHistoryRequest{Uuid: uuid})
+	if err != nil {
+		return err
+	}
+
+	for _, revision := range resp.GetRevisions() {
+		fields := make([]string, 0, len(revision.GetChanges()))
+		for _, change := range revision.GetChanges() {
+			fields = append(fields, change.GetField())
+		}
+		log.Printf("Версия %d: %s, автор=%s, поля=%v", revision.GetVersion(), revision.GetType(), revision.GetActor(), fields)
+	}
+	return nil
+}
+
+// watchSightings подписывается на события изменения наблюдений и логирует их до отмены контекста.
+// Возвращает управление, когда подписка уже активна
+func watchSightings(ctx context.Context, client ufoV1.UFOServiceClient, wg *sync.WaitGroup) error {
+	stream, err := client.Watch(ctx, &ufoV1.WatchRequest{})
+	if err != nil {
+		return err
+	}
+
+	// Сервер отправляет заголовки после регистрации подписки
+	if _, err = stream.Header(); err != nil {
+		return err
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				if status.Code(err) != codes.Canceled {
+					log.Printf("Подписка на события завершилась: %v", err)
+				}
+				return
+			}
+
+			event := resp.GetEvent()
+			log.Printf("📡 Событие #%d: %s, UUID=%s", event.GetSequence(), event.GetType(), event.GetSighting().GetUuid())
+		}
+	}()
+
 	return nil
 }
 
+// logFieldViolations выводит нарушения из деталей google.rpc.BadRequest ошибки InvalidArgument
+func logFieldViolations(err error) {
+	st := status.Convert(err)
+	log.Printf("Ошибка: %s: %s", st.Code(), st.Message())
+
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, violation := range badRequest.GetFieldViolations() {
+			log.Printf("  %s: %s", violation.GetField(), violation.GetDescription())
+		}
+	}
+}
+
 func main() {
-	ctx := context.Background()
+	if len(os.Args) > 1 && os.Args[1] == "probe" {
+		os.Exit(runProbe(os.Args[2:]))
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), "ufo-client", os.Getenv("UFO_TRACE_EXPORTER"))
+	if err != nil {
+		log.Printf("failed to set up tracing: %v\n", err)
+		return
+	}
+	defer func() {
+		if serr := shutdownTracing(context.Background()); serr != nil {
+			log.Printf("failed to flush traces: %v\n", serr)
+		}
+	}()
+
+	// Все вызовы демонстрации попадают в один трейс с общим корневым спаном
+	ctx, span := otel.Tracer("ufo-client").Start(context.Background(), "ufo-client demo")
+	defer span.End()
+	log.Printf("🧭 trace_id=%s\n", span.SpanContext().TraceID())
+
+	creds, err := transportCredentials()
+	if err != nil {
+		log.Printf("failed to load TLS files: %v\n", err)
+		return
+	}
 
 	conn, err := grpc.NewClient(
 		serverAddress,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		append(authOptions(),
+			grpc.WithTransportCredentials(creds),
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		)...,
 	)
 	if err != nil {
 		log.Printf("failed to connect: %v\n", err)
@@ -145,6 +476,20 @@ func main() {
 	log.Println("=== Тестирование API для работы с наблюдениями НЛО ===")
 	log.Println()
 
+	// Подписываемся на события, чтобы видеть все изменения, сделанные ниже
+	watchCtx, cancelWatch := context.WithCancel(ctx)
+	var watchWG sync.WaitGroup
+	defer func() {
+		cancelWatch()
+		watchWG.Wait()
+	}()
+
+	err = watchSightings(watchCtx, client, &watchWG)
+	if err != nil {
+		log.Printf("Ошибка при подписке на события: %v\n", err)
+		return
+	}
+
 	// 1. Создаем несколько наблюдений
 	log.Println("🛸 Создание наблюдений НЛО")
 	log.Println("===========================")
@@ -170,15 +515,99 @@ func main() {
 	log.Printf("Получено наблюдение НЛО: UUID=%s", uuid)
 	log.Printf("%v\n", sighting)
 
+	// Некорректное наблюдение сервер отклоняет с описанием каждого нарушения
+	log.Println("🚫 Проверка валидации")
+	log.Println("=====================")
+	_, err = client.Create(ctx, &ufoV1.CreateRequest{Info: &ufoV1.SightingInfo{
+		ObservedAt:      timestamppb.New(time.Now().Add(time.Hour)),
+		DurationSeconds: wrapperspb.Int32(-1),
+	}})
+	logFieldViolations(err)
+
+	// Массово загружаем исторические наблюдения
+	log.Println("📦 Массовая загрузка наблюдений")
+	log.Println("==============================")
+	infos := make([]*ufoV1.SightingInfo, 0, 5)
+	for range 5 {
+		infos = append(infos, randomSightingInfo())
+	}
+
+	importResp, err := importSightings(ctx, client, infos, false)
+	if err != nil {
+		log.Printf("Ошибка при массовой загрузке наблюдений: %v\n", err)
+		return
+	}
+
+	log.Printf("Загружено %d из %d наблюдений", len(importResp.GetCreated()), importResp.GetReceived())
+	for _, itemErr := range importResp.GetErrors() {
+		log.Printf("Наблюдение #%d отклонено: %s", itemErr.GetIndex(), itemErr.GetMessage())
+	}
+
+	// Получаем список всех наблюдений постранично
+	log.Println("📋 Список наблюдений")
+	log.Println("===================")
+	sightings, err := listSightings(ctx, client, &ufoV1.ListFilter{}, 2)
+	if err != nil {
+		log.Printf("Ошибка при получении списка наблюдений: %v\n", err)
+		return
+	}
+
+	log.Printf("Всего наблюдений: %d", len(sightings))
+	for _, s := range sightings {
+		log.Printf("UUID=%s, место=%s", s.GetUuid(), s.GetInfo().GetLocation())
+	}
+
+	// Ищем наблюдения по месту только что созданного наблюдения
+	log.Println("🔎 Поиск наблюдений")
+	log.Println("==================")
+	err = searchSightings(ctx, client, sighting.GetInfo().GetLocation())
+	if err != nil {
+		log.Printf("Ошибка при поиске наблюдений: %v\n", err)
+		return
+	}
+
+	// Ищем наблюдения рядом с первым наблюдением, у которого есть координаты
+	log.Println("📍 Поиск наблюдений поблизости")
+	log.Println("=============================")
+	for _, s := range sightings {
+		if s.GetInfo().GetCoordinates() == nil {
+			continue
+		}
+		err = findNearby(ctx, client, s.GetInfo().GetCoordinates(), 5000)
+		if err != nil {
+			log.Printf("Ошибка при поиске наблюдений поблизости: %v\n", err)
+			return
+		}
+		break
+	}
+
+	// Сводная статистика для еженедельного отчета
+	log.Println("📊 Статистика наблюдений")
+	log.Println("=======================")
+	err = logStats(ctx, client)
+	if err != nil {
+		log.Printf("Ошибка при получении статистики: %v\n", err)
+		return
+	}
+
 	// 3. Обновляем наблюдение
 	log.Println("✏️ Обновление наблюдение")
 	log.Println("=======================")
 
-	err = updateSighting(ctx, client, uuid)
+	newVersion, err := updateSighting(ctx, client, uuid, sighting.GetVersion())
 	if err != nil {
 		log.Printf("Ошибка при обновлении наблюдения: %v", err)
 		return
 	}
+	log.Printf("Версия наблюдения: %d -> %d", sighting.GetVersion(), newVersion)
+
+	// Повторное обновление со старой версией имитирует параллельную правку другого аналитика
+	_, err = updateSighting(ctx, client, uuid, sighting.GetVersion())
+	if status.Code(err) != codes.Aborted {
+		log.Printf("Ожидалась ошибка Aborted при обновлении устаревшей версии, получено: %v", err)
+		return
+	}
+	log.Printf("Обновление устаревшей версии отклонено: %v", err)
 
 	// 4. Проверяем обновленное наблюдение
 	log.Println("🔍 Проверка обновленного наблюдения")
@@ -193,16 +622,145 @@ func main() {
 	log.Printf("Получено наблюдение НЛО: UUID=%s", uuid)
 	log.Printf("%v\n", updatedSighting)
 
+	// 5. Сбрасываем необязательные поля через update_mask
+	log.Println("🧽 Сброс цвета и звука")
+	log.Println("======================")
+	err = clearOptionalFields(ctx, client, uuid)
+	if err != nil {
+		log.Printf("Ошибка при сбросе полей наблюдения: %v", err)
+		return
+	}
+
+	clearedSighting, err := getSighting(ctx, client, uuid)
+	if err != nil {
+		log.Printf("Ошибка при получении наблюдения: %v", err)
+		return
+	}
+	log.Printf("Цвет задан: %t, звук задан: %t", clearedSighting.GetInfo().GetColor() != nil, clearedSighting.GetInfo().GetSound() != nil)
+
+	// Прикрепляем к наблюдению снимок и скачиваем его обратно
+	log.Println("📎 Вложения")
+	log.Println("==========")
+	photo := gofakeit.ImagePng(64, 64)
+	uploadResp, err := uploadAttachment(ctx, client, uuid, "photo.png", "image/png", photo, 1024)
+	if err != nil {
+		log.Printf("Ошибка при загрузке вложения: %v", err)
+		return
+	}
+	log.Printf("Загружено вложение %s (%d байт), версия наблюдения: %d",
+		uploadResp.GetAttachment().GetId(), uploadResp.GetAttachment().GetSizeBytes(), uploadResp.GetVersion())
+
+	attachment, data, err := downloadAttachment(ctx, client, uuid, uploadResp.GetAttachment().GetId())
+	if err != nil {
+		log.Printf("Ошибка при скачивании вложения: %v", err)
+		return
+	}
+	log.Printf("Скачано вложение %s: %d байт, контрольная сумма совпала", attachment.GetFileName(), len(data))
+
+	// Смотрим, кто и что менял, и возвращаем наблюдение к первой версии
+	log.Println("📜 История изменений")
+	log.Println("===================")
+	err = logHistory(ctx, client, uuid)
+	if err != nil {
+		log.Printf("Ошибка при получении истории наблюдения: %v", err)
+		return
+	}
+
+	revertResp, err := client.RevertSighting(ctx, &ufoV1.RevertSightingRequest{Uuid: uuid, Version: 1})
+	if err != nil {
+		log.Printf("Ошибка при возврате наблюдения к первой версии: %v", err)
+		return
+	}
+	log.Printf("Наблюдение возвращено к версии 1, новая версия: %d", revertResp.GetVersion())
+
+	// Второй очевидец сообщает о том же событии: сервер помечает повтор, аналитик объединяет
+	log.Println("🔗 Повторные сообщения")
+	log.Println("=====================")
+	repeatInfo := proto.Clone(sighting.GetInfo()).(*ufoV1.SightingInfo)
+	repeatInfo.ObservedAt = timestamppb.New(repeatInfo.GetObservedAt().AsTime().Add(10 * time.Minute))
+	repeatResp, err := client.Create(ctx, &ufoV1.CreateRequest{Info: repeatInfo})
+	if err != nil {
+		log.Printf("Ошибка при создании повторного сообщения: %v", err)
+		return
+	}
+	log.Printf("Создано повторное сообщение %s, вероятные повторы: %v", repeatResp.GetUuid(), repeatResp.GetPossibleDuplicates())
+
+	mergeResp, err := client.MergeSightings(ctx, &ufoV1.MergeSightingsRequest{
+		CanonicalUuid:  uuid,
+		DuplicateUuids: []string{repeatResp.GetUuid()},
+	})
+	if err != nil {
+		log.Printf("Ошибка при объединении наблюдений: %v", err)
+		return
+	}
+	log.Printf("Наблюдение %s объединено из %v", uuid, mergeResp.GetSighting().GetMergedFrom())
+
+	// Аналитик проверяет наблюдение и подтверждает его
+	log.Println("🛂 Модерация")
+	log.Println("============")
+	for _, next := range []ufoV1.SightingStatus{
+		ufoV1.SightingStatus_SIGHTING_STATUS_UNDER_REVIEW,
+		ufoV1.SightingStatus_SIGHTING_STATUS_VERIFIED,
+	} {
+		_, err = client.TransitionStatus(ctx, &ufoV1.TransitionStatusRequest{Uuid: uuid, Status: next, Note: "Сверено с показаниями радара"})
+		if err != nil {
+			log.Printf("Ошибка при смене статуса наблюдения на %s: %v", next, err)
+			return
+		}
+		log.Printf("Статус наблюдения: %s", next)
+	}
+
+	verified, err := client.ListByStatus(ctx, &ufoV1.ListByStatusRequest{Status: ufoV1.SightingStatus_SIGHTING_STATUS_VERIFIED})
+	if err != nil {
+		log.Printf("Ошибка при получении подтвержденных наблюдений: %v", err)
+		return
+	}
+	log.Printf("Подтвержденных наблюдений: %d", len(verified.GetSightings()))
+
+	// Записываем очевидца и находим его наблюдения по имени
+	log.Println("👀 Очевидцы")
+	log.Println("===========")
+	witnessName := gofakeit.Name()
+	witnessResp, err := client.AddWitness(ctx, &ufoV1.AddWitnessRequest{
+		SightingUuid: uuid,
+		Witness: &ufoV1.WitnessInfo{
+			Name:        witnessName,
+			Contact:     gofakeit.Email(),
+			Credibility: gofakeit.Float64Range(0.5, 1),
+		},
+	})
+	if err != nil {
+		log.Printf("Ошибка при добавлении очевидца: %v", err)
+		return
+	}
+	log.Printf("Добавлен очевидец %s, контакт скрыт: %t", witnessResp.GetWitness().GetId(), witnessResp.GetWitness().GetRedacted())
+
+	byWitness, err := client.FindSightingsByWitness(ctx, &ufoV1.FindSightingsByWitnessRequest{
+		Witness: &ufoV1.FindSightingsByWitnessRequest_Name{Name: witnessName},
+	})
+	if err != nil {
+		log.Printf("Ошибка при поиске наблюдений очевидца: %v", err)
+		return
+	}
+	log.Printf("Наблюдений очевидца %s: %d", witnessName, len(byWitness.GetSightings()))
+
 	// 6. Удаляем наблюдение
 	err = deleteSighting(ctx, client, uuid)
 	if err != nil {
 		log.Printf("Ошибка при удалении наблюдения: %v", err)
 	}
 
-	// 7. Проверяем удаленное наблюдение
+	// 7. Проверяем удаленное наблюдение: без include_deleted его больше не видно
 	log.Println("🔍 Проверка удаленного наблюдения")
 	log.Println("=================================")
-	deletedSighting, err := getSighting(ctx, client, uuid)
+	_, err = getSighting(ctx, client, uuid)
+	if status.Code(err) != codes.NotFound {
+		log.Printf("Ожидалась ошибка NotFound для удаленного наблюдения, получено: %v", err)
+		return
+	}
+	log.Printf("Удаленное наблюдение скрыто: %v", err)
+
+	deletedSighting, err := getDeletedSighting(ctx, client, uuid)
 	if err != nil {
 		log.Printf("Ошибка при получении удаленного наблюдения: %v", err)
 		return
@@ -212,5 +770,39 @@ func main() {
 	log.Printf("Получено удаленное наблюдение НЛО: UUID=%s", uuid)
 	log.Printf("%v\n", deletedSighting)
 
+	// 8. Восстанавливаем наблюдение
+	log.Println("♻️ Восстановление наблюдения")
+	log.Println("===========================")
+	_, err = client.Restore(ctx, &ufoV1.RestoreRequest{Uuid: uuid})
+	if err != nil {
+		log.Printf("Ошибка при восстановлении наблюдения: %v", err)
+		return
+	}
+
+	restoredSighting, err := getSighting(ctx, client, uuid)
+	if err != nil {
+		log.Printf("Ошибка при получении восстановленного наблюдения: %v", err)
+		return
+	}
+	log.Printf("Восстановлено наблюдение НЛО: %v\n", restoredSighting)
+
+	// 9. Удаляем наблюдение безвозвратно
+	log.Println("🔥 Безвозвратное удаление наблюдения")
+	log.Println("===================================")
+	err = deleteSighting(ctx, client, uuid)
+	if err != nil {
+		log.Printf("Ошибка при удалении наблюдения: %v", err)
+		return
+	}
+
+	_, err = client.Purge(ctx, &ufoV1.PurgeRequest{Uuid: uuid})
+	if err != nil {
+		log.Printf("Ошибка при безвозвратном удалении наблюдения: %v", err)
+		return
+	}
+
+	_, err = getDeletedSighting(ctx, client, uuid)
+	log.Printf("Наблюдение удалено безвозвратно: %v", err)
+
 	log.Println("Тестирование завершено!")
 }
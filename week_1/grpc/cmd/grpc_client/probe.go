@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Коды выхода probe, их читает оркестратор
+const (
+	probeServing    = 0
+	probeNotServing = 1
+	probeFailed     = 2
+)
+
+// runProbe проверяет статус сервера по протоколу grpc.health.v1 и возвращает код выхода:
+//
+//	grpc_client probe [-addr localhost:50051] [-service ufo.v1.UFOService] [-timeout 3s]
+func runProbe(args []string) int {
+	fs := flag.NewFlagSet("probe", flag.ContinueOnError)
+	addr := fs.String("addr", serverAddress, "адрес gRPC сервера")
+	service := fs.String("service", "", "имя сервиса, пустое - общий статус сервера")
+	timeout := fs.Duration("timeout", 3*time.Second, "таймаут проверки")
+	if err := fs.Parse(args); err != nil {
+		return probeFailed
+	}
+
+	creds, err := transportCredentials()
+	if err != nil {
+		log.Printf("failed to load TLS files: %v\n", err)
+		return probeFailed
+	}
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		log.Printf("failed to connect: %v\n", err)
+		return probeFailed
+	}
+	defer func() {
+		if cerr := conn.Close(); cerr != nil {
+			log.Printf("failed to close connect: %v", cerr)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: *service})
+	if err != nil {
+		log.Printf("❌ Проверка не удалась: %v\n", err)
+		return probeFailed
+	}
+
+	log.Printf("🩺 %s: %s\n", *addr, resp.GetStatus())
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return probeNotServing
+	}
+	return probeServing
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestProbeExitCodes(t *testing.T) {
+	// Соединение без TLS, как при пустых переменных окружения
+	for _, key := range []string{"UFO_TLS_CERT", "UFO_TLS_KEY", "UFO_TLS_CA"} {
+		t.Setenv(key, "")
+	}
+
+	hs := health.NewServer()
+	hs.SetServingStatus("ufo.v1.UFOService", healthpb.HealthCheckResponse_NOT_SERVING)
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, hs)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	addr := lis.Addr().String()
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "server serving", args: []string{"-addr", addr}, want: probeServing},
+		{name: "service not serving", args: []string{"-addr", addr, "-service", "ufo.v1.UFOService"}, want: probeNotServing},
+		{name: "unknown service", args: []string{"-addr", addr, "-service", "ufo.v1.Unknown"}, want: probeFailed},
+		{name: "bad flag", args: []string{"-bogus"}, want: probeFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runProbe(tt.args); got != tt.want {
+				t.Errorf("runProbe(%v) = %d, want %d", tt.args, got, tt.want)
+			}
+		})
+	}
+
+	// Недоступный сервер - сбой проверки, а не NOT_SERVING
+	server.Stop()
+	if got := runProbe([]string{"-addr", addr, "-timeout", "200ms"}); got != probeFailed {
+		t.Errorf("runProbe on a stopped server = %d, want %d", got, probeFailed)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"os"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/tlsconfig"
+)
+
+// transportCredentials настраивает защиту соединения по переменным окружения:
+// UFO_TLS_CA - CA для проверки сервера, UFO_TLS_CERT и UFO_TLS_KEY - сертификат клиента для mTLS,
+// UFO_TLS_SERVER_NAME - имя сервера в сертификате, если оно отличается от адреса.
+// Если ничего не задано, соединение остается без шифрования
+func transportCredentials() (credentials.TransportCredentials, error) {
+	files := tlsconfig.Files{
+		CertFile: os.Getenv("UFO_TLS_CERT"),
+		KeyFile:  os.Getenv("UFO_TLS_KEY"),
+		CAFile:   os.Getenv("UFO_TLS_CA"),
+	}
+	if files == (tlsconfig.Files{}) {
+		return insecure.NewCredentials(), nil
+	}
+
+	reloader, err := tlsconfig.NewReloader(files)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(reloader.ClientConfig(os.Getenv("UFO_TLS_SERVER_NAME"))), nil
+}
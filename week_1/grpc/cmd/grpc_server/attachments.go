@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/validator"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxUploadChunkSize наибольшая часть файла в одном сообщении загрузки
+	maxUploadChunkSize = 1 << 20
+	// downloadChunkSize размер части файла в одном сообщении выдачи
+	downloadChunkSize = 64 << 10
+	// maxAttachmentsPerSighting сколько вложений можно прикрепить к одному наблюдению
+	maxAttachmentsPerSighting = 20
+)
+
+// attachmentLimits ограничения на загружаемые вложения
+type attachmentLimits struct {
+	maxBytes int64
+	types    []string
+	// budget общий для всех загрузок объем памяти, nil - без ограничения
+	budget *uploadBudget
+}
+
+// uploadBudget сколько байт сервер готов держать в памяти под все идущие загрузки сразу
+type uploadBudget struct {
+	mu    sync.Mutex
+	limit int64
+	used  int64
+}
+
+func newUploadBudget(limit int64) *uploadBudget {
+	return &uploadBudget{limit: limit}
+}
+
+// reserve занимает n байт под загрузку, если они помещаются в бюджет
+func (b *uploadBudget) reserve(n int64) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.used+n > b.limit {
+		return status.Errorf(codes.ResourceExhausted, "uploads in progress already hold %d of %d bytes, retry later", b.used, b.limit)
+	}
+	b.used += n
+	return nil
+}
+
+// release возвращает n байт, занятых через reserve
+func (b *uploadBudget) release(n int64) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.used -= n
+}
+
+// checkHeader проверяет заголовок загрузки по правилам .proto и лимитам сервера
+func (l attachmentLimits) checkHeader(header *ufoV1.UploadAttachmentHeader) error {
+	if violations := validator.Validate(header); len(violations) > 0 {
+		return badRequest(violations)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header.GetContentType())
+	if err != nil || !slices.Contains(l.types, mediaType) {
+		return status.Errorf(codes.InvalidArgument, "content type %q is not allowed, allowed: %q", header.GetContentType(), l.types)
+	}
+	if header.GetSizeBytes() > l.maxBytes {
+		return status.Errorf(codes.InvalidArgument, "attachment is %d bytes, the limit is %d bytes", header.GetSizeBytes(), l.maxBytes)
+	}
+	return nil
+}
+
+// checkContent сверяет полученное содержимое с заголовком: размер, контрольную сумму
+// и сигнатуру файла, если по ней можно определить тип
+func checkContent(header *ufoV1.UploadAttachmentHeader, data, sum []byte) error {
+	if int64(len(data)) != header.GetSizeBytes() {
+		return status.Errorf(codes.InvalidArgument, "received %d bytes, header declared %d", len(data), header.GetSizeBytes())
+	}
+	if hex.EncodeToString(sum) != header.GetSha256() {
+		return status.Errorf(codes.InvalidArgument, "sha256 mismatch: received content has %x", sum)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(header.GetContentType())
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	if sniffed != "application/octet-stream" && sniffed != mediaType {
+		return status.Errorf(codes.InvalidArgument, "content looks like %s, not %s", sniffed, mediaType)
+	}
+	return nil
+}
+
+func (s *ufoService) UploadAttachment(stream grpc.ClientStreamingServer[ufoV1.UploadAttachmentRequest, ufoV1.UploadAttachmentResponse]) error {
+	first, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Errorf(codes.InvalidArgument, "stream is empty, header is required")
+		}
+		return err
+	}
+	header := first.GetHeader()
+	if header == nil {
+		return status.Errorf(codes.InvalidArgument, "header must be the first message of the stream")
+	}
+	if err = s.attachmentLimits.checkHeader(header); err != nil {
+		return err
+	}
+
+	// Проверяем наблюдение до приема содержимого, чтобы не гонять файл зря
+	sighting, err := s.activeSighting(stream.Context(), header.GetSightingUuid())
+	if err != nil {
+		return err
+	}
+	if len(sighting.GetAttachments()) >= maxAttachmentsPerSighting {
+		return status.Errorf(codes.FailedPrecondition, "sighting with UUID %s already has %d attachments", sighting.GetUuid(), maxAttachmentsPerSighting)
+	}
+
+	// Содержимое копится в памяти до сохранения, поэтому заявленный размер
+	// резервируется в общем бюджете загрузок до конца вызова. Больше заявленного
+	// клиент прислать не может. Буфер все равно растет по мере прихода частей,
+	// чтобы загрузки без данных не занимали память на самом деле
+	if err = s.attachmentLimits.budget.reserve(header.GetSizeBytes()); err != nil {
+		return err
+	}
+	defer s.attachmentLimits.budget.release(header.GetSizeBytes())
+
+	var data bytes.Buffer
+	hash := sha256.New()
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		chunk, ok := req.GetPayload().(*ufoV1.UploadAttachmentRequest_Chunk)
+		if !ok {
+			return status.Errorf(codes.InvalidArgument, "only chunks may follow the header")
+		}
+		if len(chunk.Chunk) > maxUploadChunkSize {
+			return status.Errorf(codes.InvalidArgument, "chunk is %d bytes, the limit is %d bytes", len(chunk.Chunk), maxUploadChunkSize)
+		}
+		if int64(data.Len()+len(chunk.Chunk)) > header.GetSizeBytes() {
+			return status.Errorf(codes.InvalidArgument, "received more than %d bytes declared in header", header.GetSizeBytes())
+		}
+
+		data.Write(chunk.Chunk)
+		hash.Write(chunk.Chunk)
+	}
+
+	if err = checkContent(header, data.Bytes(), hash.Sum(nil)); err != nil {
+		return err
+	}
+
+	attachment := &ufoV1.Attachment{
+		Id:          uuid.NewString(),
+		FileName:    header.GetFileName(),
+		ContentType: header.GetContentType(),
+		SizeBytes:   header.GetSizeBytes(),
+		Sha256:      header.GetSha256(),
+		UploadedAt:  timestamppb.New(time.Now()),
+	}
+	version, err := s.attach(stream.Context(), header.GetSightingUuid(), attachment, data.Bytes())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("📎 Attached %s (%s, %d bytes) to sighting %s", attachment.GetId(), attachment.GetContentType(), attachment.GetSizeBytes(), header.GetSightingUuid())
+	return stream.SendAndClose(&ufoV1.UploadAttachmentResponse{Attachment: attachment, Version: version})
+}
+
+// attach сохраняет содержимое вложения и добавляет его метаданные в наблюдение.
+// Если наблюдение сохранить не удалось, содержимое удаляется
+func (s *ufoService) attach(ctx context.Context, sightingUUID string, attachment *ufoV1.Attachment, data []byte) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Пока файл принимался, наблюдение могли удалить или дополнить
+	sighting, err := s.activeSightingLocked(ctx, sightingUUID)
+	if err != nil {
+		return 0, err
+	}
+	if len(sighting.GetAttachments()) >= maxAttachmentsPerSighting {
+		return 0, status.Errorf(codes.FailedPrecondition, "sighting with UUID %s already has %d attachments", sightingUUID, maxAttachmentsPerSighting)
+	}
+
+	if err = s.repo.PutAttachment(ctx, attachment.GetId(), data); err != nil {
+		return 0, repositoryError(err, sightingUUID)
+	}
+
+	sighting.Attachments = append(sighting.Attachments, attachment)
+	sighting.UpdatedAt = attachment.GetUploadedAt()
+	if err = s.saveLocked(ctx, sighting, ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_UPDATED); err != nil {
+		if derr := s.repo.DeleteAttachment(context.WithoutCancel(ctx), attachment.GetId()); derr != nil {
+			log.Printf("Failed to remove orphaned attachment %s: %v", attachment.GetId(), derr)
+		}
+		return 0, err
+	}
+
+	return sighting.GetVersion(), nil
+}
+
+// deleteAttachmentsLocked удаляет содержимое всех вложений наблюдения, вызывается под s.mu.
+// Ошибки только логируются: метаданные уже удалены вместе с наблюдением
+func (s *ufoService) deleteAttachmentsLocked(ctx context.Context, sighting *ufoV1.Sighting) {
+	for _, attachment := range sighting.GetAttachments() {
+		if err := s.repo.DeleteAttachment(ctx, attachment.GetId()); err != nil {
+			log.Printf("Failed to delete attachment %s of sighting %s: %v", attachment.GetId(), sighting.GetUuid(), err)
+		}
+	}
+}
+
+func (s *ufoService) DownloadAttachment(req *ufoV1.DownloadAttachmentRequest, stream grpc.ServerStreamingServer[ufoV1.DownloadAttachmentResponse]) error {
+	ctx := stream.Context()
+
+	sighting, err := s.activeSighting(ctx, req.GetSightingUuid())
+	if err != nil {
+		return err
+	}
+
+	idx := slices.IndexFunc(sighting.GetAttachments(), func(a *ufoV1.Attachment) bool {
+		return a.GetId() == req.GetAttachmentId()
+	})
+	if idx < 0 {
+		return status.Errorf(codes.NotFound, "attachment %s of sighting %s not found", req.GetAttachmentId(), req.GetSightingUuid())
+	}
+	attachment := sighting.GetAttachments()[idx]
+
+	data, err := s.repo.GetAttachment(ctx, attachment.GetId())
+	if err != nil {
+		return repositoryError(err, req.GetSightingUuid())
+	}
+
+	err = stream.Send(&ufoV1.DownloadAttachmentResponse{
+		Payload: &ufoV1.DownloadAttachmentResponse_Attachment{Attachment: attachment},
+	})
+	if err != nil {
+		return err
+	}
+
+	for offset := 0; offset < len(data); offset += downloadChunkSize {
+		end := min(offset+downloadChunkSize, len(data))
+		err = stream.Send(&ufoV1.DownloadAttachmentResponse{
+			Payload: &ufoV1.DownloadAttachmentResponse_Chunk{Chunk: data[offset:end]},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+)
+
+type uploadStream = fakeClientStream[ufoV1.UploadAttachmentRequest, ufoV1.UploadAttachmentResponse]
+
+// uploadRequests заголовок с размером declared и содержимое, разбитое на части по chunkSize
+func uploadRequests(sightingUUID string, content []byte, declared int64, chunkSize int) *uploadStream {
+	sum := sha256.Sum256(content)
+	stream := &uploadStream{ctx: context.Background()}
+	stream.reqs = append(stream.reqs, &ufoV1.UploadAttachmentRequest{
+		Payload: &ufoV1.UploadAttachmentRequest_Header{Header: &ufoV1.UploadAttachmentHeader{
+			SightingUuid: sightingUUID,
+			FileName:     "notes.txt",
+			ContentType:  "text/plain",
+			SizeBytes:    declared,
+			Sha256:       hex.EncodeToString(sum[:]),
+		}},
+	})
+	for offset := 0; offset < len(content); offset += chunkSize {
+		chunk := content[offset:min(offset+chunkSize, len(content))]
+		stream.reqs = append(stream.reqs, &ufoV1.UploadAttachmentRequest{
+			Payload: &ufoV1.UploadAttachmentRequest_Chunk{Chunk: chunk},
+		})
+	}
+	return stream
+}
+
+func TestAttachmentRoundTrip(t *testing.T) {
+	s := newTestService(t, nil)
+	id := mustCreate(t, s, testInfo("Roswell", "disc"))
+	content := bytes.Repeat([]byte("it was not a weather balloon\n"), 5000)
+
+	upload := uploadRequests(id, content, int64(len(content)), 1000)
+	if err := s.UploadAttachment(upload); err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+
+	download := &fakeServerStream[ufoV1.DownloadAttachmentResponse]{ctx: context.Background()}
+	req := &ufoV1.DownloadAttachmentRequest{SightingUuid: id, AttachmentId: upload.resp.GetAttachment().GetId()}
+	if err := s.DownloadAttachment(req, download); err != nil {
+		t.Fatalf("download: %v", err)
+	}
+
+	var got []byte
+	for _, msg := range download.sent[1:] {
+		got = append(got, msg.GetChunk()...)
+	}
+	if download.sent[0].GetAttachment().GetSizeBytes() != int64(len(content)) || !bytes.Equal(got, content) {
+		t.Errorf("downloaded %d bytes, want %d", len(got), len(content))
+	}
+}
+
+func TestUploadRejectsContentBeyondHeader(t *testing.T) {
+	s := newTestService(t, nil)
+	id := mustCreate(t, s, testInfo("Roswell", "disc"))
+	content := bytes.Repeat([]byte("x"), 100)
+
+	tests := map[string]*uploadStream{
+		"more than declared":      uploadRequests(id, content, 50, 10),
+		"less than declared":      uploadRequests(id, content, 200, 10),
+		"declared over the limit": uploadRequests(id, content, defaultAttachmentMaxBytes+1, 10),
+		"chunk over the limit":    uploadRequests(id, bytes.Repeat([]byte("x"), maxUploadChunkSize+1), maxUploadChunkSize+1, maxUploadChunkSize+1),
+	}
+	for name, stream := range tests {
+		t.Run(name, func(t *testing.T) {
+			wantCode(t, s.UploadAttachment(stream), codes.InvalidArgument)
+		})
+	}
+
+	if sighting := mustGet(t, s, id); len(sighting.GetAttachments()) != 0 {
+		t.Errorf("rejected uploads left %d attachments", len(sighting.GetAttachments()))
+	}
+}
+
+func TestUploadBudget(t *testing.T) {
+	s := newTestService(t, nil)
+	s.attachmentLimits.budget = newUploadBudget(150)
+	id := mustCreate(t, s, testInfo("Roswell", "disc"))
+	content := bytes.Repeat([]byte("x"), 100)
+
+	// Пока другая загрузка держит 100 байт, вторая в бюджет не помещается
+	if err := s.attachmentLimits.budget.reserve(100); err != nil {
+		t.Fatal(err)
+	}
+	wantCode(t, s.UploadAttachment(uploadRequests(id, content, 100, 10)), codes.ResourceExhausted)
+	s.attachmentLimits.budget.release(100)
+
+	if err := s.UploadAttachment(uploadRequests(id, content, 100, 10)); err != nil {
+		t.Fatalf("upload within budget: %v", err)
+	}
+	// Отклоненная загрузка тоже возвращает зарезервированное
+	wantCode(t, s.UploadAttachment(uploadRequests(id, content, 120, 10)), codes.InvalidArgument)
+	if used := s.attachmentLimits.budget.used; used != 0 {
+		t.Errorf("finished uploads still hold %d bytes", used)
+	}
+	if sighting := mustGet(t, s, id); len(sighting.GetAttachments()) != 1 {
+		t.Errorf("sighting has %d attachments, want 1", len(sighting.GetAttachments()))
+	}
+}
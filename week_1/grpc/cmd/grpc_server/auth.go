@@ -0,0 +1,74 @@
+package main
+
+import (
+	"log"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/auth"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// accessPolicy кто что может делать с наблюдениями. Чтение доступно любой роли,
+// изменения - только ролям, которым они нужны по работе. Метод без правила запрещен
+var accessPolicy = auth.Policy{
+	Public: []string{
+		// Оркестратор и probe проверяют готовность без токена
+		"/" + healthpb.Health_ServiceDesc.ServiceName + "/",
+		"/grpc.reflection.",
+	},
+	Authenticated: []string{
+		ufoV1.UFOService_Get_FullMethodName,
+		ufoV1.UFOService_List_FullMethodName,
+		ufoV1.UFOService_Search_FullMethodName,
+		ufoV1.UFOService_FindNearby_FullMethodName,
+		ufoV1.UFOService_FindSightingsByWitness_FullMethodName,
+		ufoV1.UFOService_ListByStatus_FullMethodName,
+		ufoV1.UFOService_GetStats_FullMethodName,
+		ufoV1.UFOService_Watch_FullMethodName,
+		ufoV1.UFOService_DownloadAttachment_FullMethodName,
+		ufoV1.UFOService_FindDuplicates_FullMethodName,
+		ufoV1.UFOService_GetHistory_FullMethodName,
+		ufoV1.UFOService_GetRevision_FullMethodName,
+	},
+	Roles: map[string][]string{
+		ufoV1.UFOService_Create_FullMethodName:           {auth.RoleReporter, auth.RoleAdmin},
+		ufoV1.UFOService_ImportSightings_FullMethodName:  {auth.RoleReporter, auth.RoleAdmin},
+		ufoV1.UFOService_UploadAttachment_FullMethodName: {auth.RoleReporter, auth.RoleAdmin},
+		ufoV1.UFOService_AddWitness_FullMethodName:       {auth.RoleReporter, auth.RoleAnalyst, auth.RoleAdmin},
+		ufoV1.UFOService_RemoveWitness_FullMethodName:    {auth.RoleAnalyst, auth.RoleAdmin},
+		ufoV1.UFOService_Update_FullMethodName:           {auth.RoleAnalyst, auth.RoleAdmin},
+		ufoV1.UFOService_RevertSighting_FullMethodName:   {auth.RoleAnalyst, auth.RoleAdmin},
+		ufoV1.UFOService_MergeSightings_FullMethodName:   {auth.RoleAnalyst, auth.RoleAdmin},
+		ufoV1.UFOService_TransitionStatus_FullMethodName: {auth.RoleAnalyst, auth.RoleAdmin},
+		ufoV1.UFOService_Delete_FullMethodName:           {auth.RoleAdmin},
+		ufoV1.UFOService_Restore_FullMethodName:          {auth.RoleAdmin},
+		ufoV1.UFOService_Purge_FullMethodName:            {auth.RoleAdmin},
+	},
+}
+
+// newAuthenticator собирает проверку токенов из настроек, nil если аутентификация не настроена
+func newAuthenticator(cfg config) (auth.Authenticator, error) {
+	if cfg.apiKeysFile == "" && cfg.jwksFile == "" {
+		log.Printf("⚠️ Authentication is disabled, set UFO_API_KEYS_FILE or UFO_JWKS_FILE to enable it")
+		return nil, nil
+	}
+
+	var chain auth.Chain
+	if cfg.apiKeysFile != "" {
+		keys, err := auth.LoadAPIKeys(cfg.apiKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		chain.APIKeys = keys
+	}
+	if cfg.jwksFile != "" {
+		jwt, err := auth.LoadJWT(cfg.jwksFile, auth.JWTOptions{Issuer: cfg.jwtIssuer, Audience: cfg.jwtAudience})
+		if err != nil {
+			return nil, err
+		}
+		chain.JWT = jwt
+	}
+
+	log.Printf("🔑 Authentication is enabled (api keys: %t, jwt: %t)", chain.APIKeys != nil, chain.JWT != nil)
+	return chain, nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/auth"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestAccessPolicyMatrix(t *testing.T) {
+	const (
+		r = 1 << iota // reporter
+		a             // analyst
+		d             // admin
+		// anyone любой аутентифицированный, в том числе без ролей
+		anyone = r | a | d | 1<<3
+	)
+
+	// Каждый метод UFOService обязан быть в таблице: новый метод без решения
+	// о доступе не пройдет тест
+	want := map[string]int{
+		"Get":                    anyone,
+		"List":                   anyone,
+		"Search":                 anyone,
+		"FindNearby":             anyone,
+		"FindSightingsByWitness": anyone,
+		"ListByStatus":           anyone,
+		"GetStats":               anyone,
+		"Watch":                  anyone,
+		"DownloadAttachment":     anyone,
+		"FindDuplicates":         anyone,
+		"GetHistory":             anyone,
+		"GetRevision":            anyone,
+		"Create":                 r | d,
+		"ImportSightings":        r | d,
+		"UploadAttachment":       r | d,
+		"AddWitness":             r | a | d,
+		"RemoveWitness":          a | d,
+		"Update":                 a | d,
+		"RevertSighting":         a | d,
+		"MergeSightings":         a | d,
+		"TransitionStatus":       a | d,
+		"Delete":                 d,
+		"Restore":                d,
+		"Purge":                  d,
+	}
+
+	roles := map[int]string{r: auth.RoleReporter, a: auth.RoleAnalyst, d: auth.RoleAdmin}
+
+	desc := ufoV1.UFOService_ServiceDesc
+	var methods []string
+	for _, m := range desc.Methods {
+		methods = append(methods, m.MethodName)
+	}
+	for _, s := range desc.Streams {
+		methods = append(methods, s.StreamName)
+	}
+	if len(methods) != len(want) {
+		t.Errorf("UFOService has %d methods, matrix covers %d", len(methods), len(want))
+	}
+
+	for _, method := range methods {
+		allowed, ok := want[method]
+		if !ok {
+			t.Errorf("%s is missing from the matrix", method)
+			continue
+		}
+		full := "/" + desc.ServiceName + "/" + method
+
+		if accessPolicy.IsPublic(full) {
+			t.Errorf("%s must not be public", method)
+		}
+		if accessPolicy.Allowed(nil, full) {
+			t.Errorf("%s is allowed without a principal", method)
+		}
+		if got := accessPolicy.Allowed(&auth.Principal{Subject: "nobody"}, full); got != (allowed == anyone) {
+			t.Errorf("%s without roles: allowed = %t, want %t", method, got, !got)
+		}
+		for bit, role := range roles {
+			p := &auth.Principal{Subject: role, Roles: []string{role}}
+			if got := accessPolicy.Allowed(p, full); got != (allowed&bit != 0) {
+				t.Errorf("%s as %s: allowed = %t, want %t", method, role, got, !got)
+			}
+		}
+	}
+}
+
+func TestAccessPolicyCoversEveryMethod(t *testing.T) {
+	desc := ufoV1.UFOService_ServiceDesc
+	var methods []string
+	for _, m := range desc.Methods {
+		methods = append(methods, m.MethodName)
+	}
+	for _, s := range desc.Streams {
+		methods = append(methods, s.StreamName)
+	}
+
+	for _, method := range methods {
+		full := "/" + desc.ServiceName + "/" + method
+		if !accessPolicy.Covers(full) {
+			t.Errorf("%s has no access rule and is denied to everyone", method)
+		}
+		_, byRole := accessPolicy.Roles[full]
+		if byRole && slices.Contains(accessPolicy.Authenticated, full) {
+			t.Errorf("%s is both role-restricted and open to any caller", method)
+		}
+	}
+}
+
+func TestAccessPolicyPublicMethods(t *testing.T) {
+	for _, method := range []string{
+		healthpb.Health_Check_FullMethodName,
+		healthpb.Health_Watch_FullMethodName,
+		"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+	} {
+		if !accessPolicy.IsPublic(method) {
+			t.Errorf("%s must be public", method)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/ratelimit"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/tlsconfig"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/tracing"
+)
+
+const (
+	storageMemory = "memory"
+	storageBolt   = "bolt"
+
+	defaultStorage  = storageMemory
+	defaultBoltPath = "ufo.db"
+
+	defaultDeletedRetention  = 30 * 24 * time.Hour
+	defaultRetentionInterval = time.Hour
+	defaultIdempotencyTTL    = 24 * time.Hour
+	defaultDuplicateWindow   = 2 * time.Hour
+
+	logFormatText = "text"
+	logFormatJSON = "json"
+
+	defaultLogFormat      = logFormatText
+	defaultRequestTimeout = 10 * time.Second
+	defaultMaxTimeout     = time.Minute
+	defaultStreamTimeout  = 10 * time.Minute
+	defaultHealthInterval = 5 * time.Second
+	defaultHTTPAddr       = ":8080"
+	defaultMetricsAddr    = ":9090"
+
+	defaultTLSReloadInterval = time.Minute
+
+	// Импорт тяжелее остальных вызовов: он держит мьютекс записи на всю пачку
+	defaultRateLimits       = "*=50:100,Create=10:20,ImportSightings=1:3"
+	defaultCreateDailyQuota = 10000
+
+	defaultAttachmentMaxBytes = 20 << 20
+	defaultUploadBudgetBytes  = 5 * defaultAttachmentMaxBytes
+	defaultAttachmentTypes    = "image/jpeg,image/png,image/gif,image/webp,video/mp4,video/quicktime,video/webm"
+)
+
+// config настройки сервера, читаются из переменных окружения
+type config struct {
+	// storage тип хранилища: memory или bolt
+	storage string
+	// boltPath путь к файлу базы для хранилища bolt
+	boltPath string
+
+	// deletedRetention сколько хранить удаленные наблюдения до безвозвратного удаления
+	deletedRetention time.Duration
+	// retentionInterval как часто запускать очистку удаленных наблюдений
+	retentionInterval time.Duration
+	// idempotencyTTL сколько помнить ключи идемпотентности Create
+	idempotencyTTL time.Duration
+
+	// duplicateWindow насколько могут расходиться observed_at повторных сообщений об одном событии
+	duplicateWindow time.Duration
+
+	// logFormat формат логов: text или json
+	logFormat string
+	// logLevel минимальный уровень логов
+	logLevel slog.Level
+	// requestTimeout таймаут унарных запросов, пришедших без дедлайна
+	requestTimeout time.Duration
+	// maxTimeout верхняя граница дедлайна унарных запросов
+	maxTimeout time.Duration
+	// streamTimeout дедлайн ImportSightings, UploadAttachment и DownloadAttachment:
+	// и по умолчанию, и верхняя граница. Watch дедлайном не ограничивается
+	streamTimeout time.Duration
+	// healthInterval как часто проверять доступность хранилища для health-статуса
+	healthInterval time.Duration
+
+	// httpAddr адрес REST-шлюза
+	httpAddr string
+	// metricsAddr адрес HTTP-сервера с метриками Prometheus
+	metricsAddr string
+
+	// tlsFiles сертификат, ключ и CA сервера; без сертификата сервер работает без TLS
+	tlsFiles tlsconfig.Files
+	// requireClientCert требовать от клиентов сертификат, подписанный CA (mTLS)
+	requireClientCert bool
+	// gatewayTLSFiles клиентский сертификат и ключ, которые REST-шлюз предъявляет gRPC-серверу
+	// при обязательном клиентском сертификате. Нужен clientAuth в ExtKeyUsage и подпись UFO_TLS_CA
+	gatewayTLSFiles tlsconfig.Files
+	// tlsReloadInterval как часто проверять, не обновились ли файлы сертификатов
+	tlsReloadInterval time.Duration
+
+	// apiKeysFile JSON-файл со статическими API-ключами
+	apiKeysFile string
+	// jwksFile набор публичных ключей (JWKS) для проверки JWT
+	jwksFile string
+	// jwtIssuer ожидаемый iss токенов, пустой - не проверяется
+	jwtIssuer string
+	// jwtAudience ожидаемый aud токенов, пустой - не проверяется
+	jwtAudience string
+
+	// rateLimits лимиты частоты вызовов на клиента по коротким именам методов
+	rateLimits map[string]ratelimit.Limit
+	// createDailyQuota сколько наблюдений клиент может создать за сутки через Create и ImportSightings, 0 - без ограничения
+	createDailyQuota int
+
+	// attachmentMaxBytes максимальный размер одного вложения
+	attachmentMaxBytes int
+	// attachmentTypes допустимые MIME-типы вложений
+	attachmentTypes []string
+	// uploadBudgetBytes сколько байт могут занимать все идущие загрузки вложений вместе.
+	// Вложение хранится одним значением, поэтому загрузка держит файл в памяти, пока
+	// не сохранит его. Под каждую загрузку резервируется заявленный в заголовке размер,
+	// загрузка сверх бюджета сразу получает ResourceExhausted
+	uploadBudgetBytes int
+
+	// traceExporter куда отправлять трейсы: none, stdout или otlp
+	traceExporter string
+}
+
+func loadConfig() (config, error) {
+	cfg := config{
+		storage:           stringEnv("UFO_STORAGE", defaultStorage),
+		boltPath:          stringEnv("UFO_BOLT_PATH", defaultBoltPath),
+		deletedRetention:  defaultDeletedRetention,
+		retentionInterval: defaultRetentionInterval,
+		idempotencyTTL:    defaultIdempotencyTTL,
+		duplicateWindow:   defaultDuplicateWindow,
+		logFormat:         stringEnv("UFO_LOG_FORMAT", defaultLogFormat),
+		logLevel:          slog.LevelInfo,
+		requestTimeout:    defaultRequestTimeout,
+		maxTimeout:        defaultMaxTimeout,
+		streamTimeout:     defaultStreamTimeout,
+		healthInterval:    defaultHealthInterval,
+		httpAddr:          stringEnv("UFO_HTTP_ADDR", defaultHTTPAddr),
+		metricsAddr:       stringEnv("UFO_METRICS_ADDR", defaultMetricsAddr),
+		tlsFiles: tlsconfig.Files{
+			CertFile: os.Getenv("UFO_TLS_CERT"),
+			KeyFile:  os.Getenv("UFO_TLS_KEY"),
+			CAFile:   os.Getenv("UFO_TLS_CA"),
+		},
+		gatewayTLSFiles: tlsconfig.Files{
+			CertFile: os.Getenv("UFO_TLS_GATEWAY_CERT"),
+			KeyFile:  os.Getenv("UFO_TLS_GATEWAY_KEY"),
+		},
+		tlsReloadInterval:  defaultTLSReloadInterval,
+		apiKeysFile:        os.Getenv("UFO_API_KEYS_FILE"),
+		jwksFile:           os.Getenv("UFO_JWKS_FILE"),
+		jwtIssuer:          os.Getenv("UFO_JWT_ISSUER"),
+		jwtAudience:        os.Getenv("UFO_JWT_AUDIENCE"),
+		createDailyQuota:   defaultCreateDailyQuota,
+		attachmentMaxBytes: defaultAttachmentMaxBytes,
+		attachmentTypes:    splitList(stringEnv("UFO_ATTACHMENT_TYPES", defaultAttachmentTypes)),
+		uploadBudgetBytes:  defaultUploadBudgetBytes,
+		traceExporter:      stringEnv("UFO_TRACE_EXPORTER", tracing.ExporterNone),
+	}
+
+	if cfg.storage != storageMemory && cfg.storage != storageBolt {
+		return config{}, fmt.Errorf("UFO_STORAGE must be %q or %q, got %q", storageMemory, storageBolt, cfg.storage)
+	}
+
+	if cfg.logFormat != logFormatText && cfg.logFormat != logFormatJSON {
+		return config{}, fmt.Errorf("UFO_LOG_FORMAT must be %q or %q, got %q", logFormatText, logFormatJSON, cfg.logFormat)
+	}
+	if err := cfg.logLevel.UnmarshalText([]byte(stringEnv("UFO_LOG_LEVEL", cfg.logLevel.String()))); err != nil {
+		return config{}, fmt.Errorf("parse UFO_LOG_LEVEL: %w", err)
+	}
+
+	var err error
+	if cfg.deletedRetention, err = durationEnv("UFO_DELETED_RETENTION", cfg.deletedRetention); err != nil {
+		return config{}, err
+	}
+	if cfg.retentionInterval, err = durationEnv("UFO_RETENTION_INTERVAL", cfg.retentionInterval); err != nil {
+		return config{}, err
+	}
+	if cfg.idempotencyTTL, err = durationEnv("UFO_IDEMPOTENCY_TTL", cfg.idempotencyTTL); err != nil {
+		return config{}, err
+	}
+	if cfg.duplicateWindow, err = durationEnv("UFO_DUPLICATE_WINDOW", cfg.duplicateWindow); err != nil {
+		return config{}, err
+	}
+	if cfg.requestTimeout, err = durationEnv("UFO_REQUEST_TIMEOUT", cfg.requestTimeout); err != nil {
+		return config{}, err
+	}
+	if cfg.maxTimeout, err = durationEnv("UFO_MAX_TIMEOUT", cfg.maxTimeout); err != nil {
+		return config{}, err
+	}
+	if cfg.streamTimeout, err = durationEnv("UFO_STREAM_TIMEOUT", cfg.streamTimeout); err != nil {
+		return config{}, err
+	}
+	if cfg.healthInterval, err = durationEnv("UFO_HEALTH_INTERVAL", cfg.healthInterval); err != nil {
+		return config{}, err
+	}
+	if cfg.tlsReloadInterval, err = durationEnv("UFO_TLS_RELOAD_INTERVAL", cfg.tlsReloadInterval); err != nil {
+		return config{}, err
+	}
+	if cfg.requireClientCert, err = boolEnv("UFO_TLS_REQUIRE_CLIENT_CERT", false); err != nil {
+		return config{}, err
+	}
+	if cfg.requireClientCert && !cfg.tlsFiles.HasKeyPair() {
+		return config{}, fmt.Errorf("UFO_TLS_REQUIRE_CLIENT_CERT needs UFO_TLS_CERT and UFO_TLS_KEY")
+	}
+	if cfg.requireClientCert && !cfg.gatewayTLSFiles.HasKeyPair() {
+		return config{}, fmt.Errorf("UFO_TLS_REQUIRE_CLIENT_CERT needs UFO_TLS_GATEWAY_CERT and UFO_TLS_GATEWAY_KEY for the REST gateway")
+	}
+	if cfg.rateLimits, err = parseRateLimits(stringEnv("UFO_RATE_LIMITS", defaultRateLimits)); err != nil {
+		return config{}, fmt.Errorf("parse UFO_RATE_LIMITS: %w", err)
+	}
+	if cfg.createDailyQuota, err = intEnv("UFO_CREATE_DAILY_QUOTA", cfg.createDailyQuota); err != nil {
+		return config{}, err
+	}
+	if cfg.attachmentMaxBytes, err = intEnv("UFO_ATTACHMENT_MAX_BYTES", cfg.attachmentMaxBytes); err != nil {
+		return config{}, err
+	}
+	if cfg.attachmentMaxBytes == 0 {
+		return config{}, fmt.Errorf("UFO_ATTACHMENT_MAX_BYTES must be positive")
+	}
+	if cfg.uploadBudgetBytes, err = intEnv("UFO_UPLOAD_BUDGET_BYTES", cfg.uploadBudgetBytes); err != nil {
+		return config{}, err
+	}
+	if cfg.uploadBudgetBytes < cfg.attachmentMaxBytes {
+		return config{}, fmt.Errorf("UFO_UPLOAD_BUDGET_BYTES (%d) must not be less than UFO_ATTACHMENT_MAX_BYTES (%d)", cfg.uploadBudgetBytes, cfg.attachmentMaxBytes)
+	}
+	if cfg.requestTimeout > cfg.maxTimeout {
+		return config{}, fmt.Errorf("UFO_REQUEST_TIMEOUT (%s) must not exceed UFO_MAX_TIMEOUT (%s)", cfg.requestTimeout, cfg.maxTimeout)
+	}
+
+	return cfg, nil
+}
+
+func stringEnv(key, def string) string {
+	if raw, ok := os.LookupEnv(key); ok && raw != "" {
+		return raw
+	}
+	return def
+}
+
+// splitList разбирает список через запятую, пропуская пустые элементы
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// intEnv читает неотрицательное целое число
+func intEnv(key string, def int) (int, error) {
+	raw, ok := os.LookupEnv(key)
+	if !ok || raw == "" {
+		return def, nil
+	}
+
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", key, err)
+	}
+	if v < 0 {
+		return 0, fmt.Errorf("%s must not be negative, got %s", key, raw)
+	}
+	return v, nil
+}
+
+// boolEnv читает флаг в формате strconv.ParseBool: true, false, 1, 0
+func boolEnv(key string, def bool) (bool, error) {
+	raw, ok := os.LookupEnv(key)
+	if !ok || raw == "" {
+		return def, nil
+	}
+
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("parse %s: %w", key, err)
+	}
+	return v, nil
+}
+
+// durationEnv читает положительную длительность в формате time.ParseDuration, например "72h"
+func durationEnv(key string, def time.Duration) (time.Duration, error) {
+	raw, ok := os.LookupEnv(key)
+	if !ok || raw == "" {
+		return def, nil
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", key, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s must be positive, got %s", key, raw)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/geo"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/search"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// duplicateThreshold сходство, начиная с которого наблюдение считается вероятным повтором
+	duplicateThreshold = 0.6
+	// duplicateRadiusKm на каком расстоянии точки с координатами перестают считаться одним местом
+	duplicateRadiusKm = 50
+	// maxDuplicateCandidates сколько ближайших по тексту и по месту наблюдений сравнивать
+	maxDuplicateCandidates = 100
+	// maxFlaggedDuplicates сколько повторов запоминать в possible_duplicates при создании
+	maxFlaggedDuplicates = 10
+
+	// Веса времени, места и описания в итоговом сходстве, в сумме 1
+	duplicateTimeWeight  = 0.4
+	duplicatePlaceWeight = 0.35
+	duplicateTextWeight  = 0.25
+)
+
+// duplicateScore сходство двух сообщений о наблюдении от 0 до 1. Наблюдения, разнесенные
+// по времени дальше window, не похожи независимо от места и описания
+func duplicateScore(a, b *ufoV1.SightingInfo, window time.Duration) float64 {
+	gap := a.GetObservedAt().AsTime().Sub(b.GetObservedAt().AsTime()).Abs()
+	if gap > window {
+		return 0
+	}
+	timeScore := 1 - float64(gap)/float64(window)
+
+	// Координаты точнее названия места, поэтому если они есть у обоих, сравниваем их
+	var placeScore float64
+	if a.GetCoordinates() != nil && b.GetCoordinates() != nil {
+		distance := geo.DistanceKm(pointOf(a.GetCoordinates()), pointOf(b.GetCoordinates()))
+		placeScore = max(0, 1-distance/duplicateRadiusKm)
+	} else {
+		placeScore = termSimilarity(a.GetLocation(), b.GetLocation())
+	}
+
+	textScore := termSimilarity(a.GetDescription(), b.GetDescription())
+	return duplicateTimeWeight*timeScore + duplicatePlaceWeight*placeScore + duplicateTextWeight*textScore
+}
+
+// termSimilarity коэффициент Жаккара по нормализованным словам двух текстов
+func termSimilarity(a, b string) float64 {
+	termsA, termsB := termSet(a), termSet(b)
+
+	common := 0
+	for term := range termsA {
+		if _, ok := termsB[term]; ok {
+			common++
+		}
+	}
+
+	union := len(termsA) + len(termsB) - common
+	if union == 0 {
+		return 0
+	}
+	return float64(common) / float64(union)
+}
+
+func termSet(text string) map[string]struct{} {
+	terms := make(map[string]struct{})
+	for _, term := range search.Analyze(text) {
+		terms[term] = struct{}{}
+	}
+	return terms
+}
+
+// findDuplicates ищет среди неудаленных наблюдений похожие на sighting со сходством
+// не меньше minScore. Сравниваются только кандидаты из текстового и гео-индексов
+func (s *ufoService) findDuplicates(ctx context.Context, sighting *ufoV1.Sighting, minScore float64) ([]*ufoV1.DuplicateMatch, error) {
+	info := sighting.GetInfo()
+
+	candidates := make(map[string]struct{})
+	hits := s.textIndex.Search(info.GetLocation() + " " + info.GetDescription())
+	for _, hit := range hits[:min(len(hits), maxDuplicateCandidates)] {
+		candidates[hit.ID] = struct{}{}
+	}
+	if info.GetCoordinates() != nil {
+		nearby := s.geoIndex.WithinRadius(pointOf(info.GetCoordinates()), duplicateRadiusKm)
+		for _, hit := range nearby[:min(len(nearby), maxDuplicateCandidates)] {
+			candidates[hit.ID] = struct{}{}
+		}
+	}
+	delete(candidates, sighting.GetUuid())
+
+	var matches []*ufoV1.DuplicateMatch
+	for id := range candidates {
+		candidate, err := s.repo.Get(ctx, id)
+		if errors.Is(err, repository.ErrNotFound) || (err == nil && candidate.GetDeletedAt() != nil) {
+			continue
+		}
+		if err != nil {
+			return nil, repositoryError(err, id)
+		}
+
+		if score := duplicateScore(info, candidate.GetInfo(), s.duplicateWindow); score >= minScore {
+			matches = append(matches, &ufoV1.DuplicateMatch{Sighting: candidate, Score: score})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].GetScore() != matches[j].GetScore() {
+			return matches[i].GetScore() > matches[j].GetScore()
+		}
+		return matches[i].GetSighting().GetUuid() < matches[j].GetSighting().GetUuid()
+	})
+	return matches, nil
+}
+
+// flagDuplicatesLocked заполняет possible_duplicates нового наблюдения, вызывается под s.mu
+func (s *ufoService) flagDuplicatesLocked(ctx context.Context, sighting *ufoV1.Sighting) error {
+	matches, err := s.findDuplicates(ctx, sighting, duplicateThreshold)
+	if err != nil {
+		return err
+	}
+
+	for _, match := range matches[:min(len(matches), maxFlaggedDuplicates)] {
+		sighting.PossibleDuplicates = append(sighting.PossibleDuplicates, match.GetSighting().GetUuid())
+	}
+	return nil
+}
+
+func (s *ufoService) FindDuplicates(ctx context.Context, req *ufoV1.FindDuplicatesRequest) (*ufoV1.FindDuplicatesResponse, error) {
+	sighting, err := s.activeSighting(ctx, req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+
+	minScore := req.GetMinScore()
+	if minScore == 0 {
+		minScore = duplicateThreshold
+	}
+
+	matches, err := s.findDuplicates(ctx, sighting, minScore)
+	if err != nil {
+		return nil, err
+	}
+	return &ufoV1.FindDuplicatesResponse{Matches: matches}, nil
+}
+
+// mergeInfo дополняет основное наблюдение необязательными полями повтора, которых в нем нет.
+// Заданные поля основного наблюдения не меняются
+func mergeInfo(canonical, duplicate *ufoV1.SightingInfo) {
+	if canonical.GetColor() == nil {
+		canonical.Color = duplicate.GetColor()
+	}
+	if canonical.GetSound() == nil {
+		canonical.Sound = duplicate.GetSound()
+	}
+	if canonical.GetDurationSeconds() == nil {
+		canonical.DurationSeconds = duplicate.GetDurationSeconds()
+	}
+	if canonical.GetCoordinates() == nil {
+		canonical.Coordinates = duplicate.GetCoordinates()
+	}
+}
+
+func (s *ufoService) MergeSightings(ctx context.Context, req *ufoV1.MergeSightingsRequest) (*ufoV1.MergeSightingsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	canonical, err := s.activeSightingLocked(ctx, req.GetCanonicalUuid())
+	if err != nil {
+		return nil, err
+	}
+	if err = checkVersion(canonical, req.GetExpectedVersion()); err != nil {
+		return nil, err
+	}
+
+	// originals состояние до объединения для отката, если сохранить удастся не все
+	originals := []*ufoV1.Sighting{proto.Clone(canonical).(*ufoV1.Sighting)}
+	duplicates := make([]*ufoV1.Sighting, 0, len(req.GetDuplicateUuids()))
+	attachments := len(canonical.GetAttachments())
+	for _, id := range req.GetDuplicateUuids() {
+		if id == canonical.GetUuid() {
+			return nil, status.Errorf(codes.InvalidArgument, "sighting with UUID %s cannot be merged into itself", id)
+		}
+		duplicate, err := s.activeSightingLocked(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		duplicates = append(duplicates, duplicate)
+		originals = append(originals, proto.Clone(duplicate).(*ufoV1.Sighting))
+		attachments += len(duplicate.GetAttachments())
+	}
+	if attachments > maxAttachmentsPerSighting {
+		return nil, status.Errorf(codes.FailedPrecondition, "merged sighting would have %d attachments, the limit is %d", attachments, maxAttachmentsPerSighting)
+	}
+
+	// Объединенные повторы больше не вернутся, поэтому ссылки на них
+	// убираются из possible_duplicates всех наблюдений, а не только основного
+	merged := func(id string) bool { return slices.Contains(req.GetDuplicateUuids(), id) }
+	referrers, err := s.duplicateReferrersLocked(ctx, canonical.GetUuid(), merged)
+	if err != nil {
+		return nil, err
+	}
+	for _, referrer := range referrers {
+		originals = append(originals, proto.Clone(referrer).(*ufoV1.Sighting))
+	}
+
+	// Все проверки пройдены до первой записи, дальше ошибкой может закончиться только запись.
+	// Вложения и очевидцы переезжают в основное наблюдение, а у повторов остаются только ссылки на него
+	now := timestamppb.New(time.Now())
+	for _, duplicate := range duplicates {
+		mergeInfo(canonical.GetInfo(), duplicate.GetInfo())
+		canonical.Attachments = append(canonical.Attachments, duplicate.GetAttachments()...)
+		canonical.Witnesses = append(canonical.Witnesses, duplicate.GetWitnesses()...)
+		canonical.MergedFrom = append(canonical.MergedFrom, duplicate.GetUuid())
+	}
+	canonical.PossibleDuplicates = slices.DeleteFunc(canonical.PossibleDuplicates, merged)
+	canonical.UpdatedAt = now
+	if err = s.saveLocked(ctx, canonical, ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_UPDATED); err != nil {
+		return nil, err
+	}
+
+	saved := []*ufoV1.Sighting{canonical}
+	for _, duplicate := range duplicates {
+		duplicate.MergedInto = canonical.GetUuid()
+		duplicate.Attachments = nil
+		duplicate.Witnesses = nil
+		duplicate.DeletedAt = now
+		if err = s.saveLocked(ctx, duplicate, ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_DELETED); err != nil {
+			s.rollbackMergeLocked(ctx, originals, saved)
+			return nil, err
+		}
+		saved = append(saved, duplicate)
+	}
+	for _, referrer := range referrers {
+		referrer.PossibleDuplicates = slices.DeleteFunc(referrer.PossibleDuplicates, merged)
+		referrer.UpdatedAt = now
+		if err = s.saveLocked(ctx, referrer, ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_UPDATED); err != nil {
+			s.rollbackMergeLocked(ctx, originals, saved)
+			return nil, err
+		}
+		saved = append(saved, referrer)
+	}
+
+	log.Printf("🔗 Merged %d sightings into %s", len(duplicates), canonical.GetUuid())
+	return &ufoV1.MergeSightingsResponse{Sighting: canonical}, nil
+}
+
+// duplicateReferrersLocked возвращает неудаленные наблюдения, кроме canonical и самих
+// объединяемых, у которых в possible_duplicates есть merged, вызывается под s.mu
+func (s *ufoService) duplicateReferrersLocked(ctx context.Context, canonical string, merged func(id string) bool) ([]*ufoV1.Sighting, error) {
+	var referrers []*ufoV1.Sighting
+	err := s.repo.Scan(ctx, func(sighting *ufoV1.Sighting) error {
+		if sighting.GetDeletedAt() == nil && sighting.GetUuid() != canonical && !merged(sighting.GetUuid()) &&
+			slices.ContainsFunc(sighting.GetPossibleDuplicates(), merged) {
+			referrers = append(referrers, sighting)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, repositoryError(err, canonical)
+	}
+	return referrers, nil
+}
+
+// rollbackMergeLocked возвращает сохраненным наблюдениям состояние из originals, вызывается под s.mu.
+// Записи уже попали в историю, поэтому откат - это новые ревизии поверх них, а не возврат
+// старых версий: иначе следующее изменение столкнулось бы с занятым номером версии
+func (s *ufoService) rollbackMergeLocked(ctx context.Context, originals, saved []*ufoV1.Sighting) {
+	ctx = context.WithoutCancel(ctx)
+	now := timestamppb.New(time.Now())
+
+	for i := len(saved) - 1; i >= 0; i-- {
+		current := saved[i]
+		idx := slices.IndexFunc(originals, func(o *ufoV1.Sighting) bool { return o.GetUuid() == current.GetUuid() })
+		restored := proto.Clone(originals[idx]).(*ufoV1.Sighting)
+		restored.Version = current.GetVersion()
+		restored.UpdatedAt = now
+
+		eventType := ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_UPDATED
+		if current.GetDeletedAt() != nil && restored.GetDeletedAt() == nil {
+			eventType = ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_RESTORED
+		}
+		if err := s.saveLocked(ctx, restored, eventType); err != nil {
+			log.Printf("Failed to roll back merge of sighting %s: %v", current.GetUuid(), err)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository/memory"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+)
+
+func addTestWitness(t *testing.T, s *ufoService, id, name string) {
+	t.Helper()
+
+	_, err := s.AddWitness(context.Background(), &ufoV1.AddWitnessRequest{
+		SightingUuid: id,
+		Witness:      &ufoV1.WitnessInfo{Name: name, Contact: name + "@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("add witness: %v", err)
+	}
+}
+
+func TestMergeSightings(t *testing.T) {
+	s := newTestService(t, nil)
+	ctx := context.Background()
+
+	canonical := mustCreate(t, s, testInfo("Roswell", "silver disc"))
+	duplicate := mustCreate(t, s, testInfo("Roswell", "silver disc again"))
+	addTestWitness(t, s, duplicate, "mac")
+
+	resp, err := s.MergeSightings(ctx, &ufoV1.MergeSightingsRequest{CanonicalUuid: canonical, DuplicateUuids: []string{duplicate}})
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if got := resp.GetSighting().GetMergedFrom(); len(got) != 1 || got[0] != duplicate {
+		t.Errorf("merged_from = %v, want [%s]", got, duplicate)
+	}
+	if len(resp.GetSighting().GetWitnesses()) != 1 {
+		t.Errorf("canonical has %d witnesses, want 1", len(resp.GetSighting().GetWitnesses()))
+	}
+
+	merged := mustGet(t, s, duplicate)
+	if merged.GetMergedInto() != canonical || merged.GetDeletedAt() == nil || len(merged.GetWitnesses()) != 0 {
+		t.Errorf("duplicate after merge = %v, want deleted, merged into %s, without witnesses", merged, canonical)
+	}
+
+	_, err = s.MergeSightings(ctx, &ufoV1.MergeSightingsRequest{CanonicalUuid: canonical, DuplicateUuids: []string{canonical}})
+	wantCode(t, err, codes.InvalidArgument)
+}
+
+func TestMergeSightingsRollsBackOnFailure(t *testing.T) {
+	repo := &faultyRepo{SightingRepository: memory.NewRepository()}
+	s := newTestService(t, repo)
+	ctx := context.Background()
+
+	canonical := mustCreate(t, s, testInfo("Roswell", "silver disc"))
+	first := mustCreate(t, s, testInfo("Roswell", "silver disc over the ranch"))
+	second := mustCreate(t, s, testInfo("Roswell", "silver disc near the ranch"))
+	addTestWitness(t, s, first, "mac")
+	addTestWitness(t, s, second, "jesse")
+
+	before := map[string]*ufoV1.Sighting{}
+	for _, id := range []string{canonical, first, second} {
+		before[id] = mustGet(t, s, id)
+	}
+
+	// Первый повтор сохраняется, второй - нет
+	repo.failUpdate = func(sighting *ufoV1.Sighting) bool {
+		return sighting.GetUuid() == second && sighting.GetMergedInto() != ""
+	}
+	_, err := s.MergeSightings(ctx, &ufoV1.MergeSightingsRequest{CanonicalUuid: canonical, DuplicateUuids: []string{first, second}})
+	wantCode(t, err, codes.Internal)
+	repo.failUpdate = nil
+
+	for id, was := range before {
+		got := mustGet(t, s, id)
+		if got.GetDeletedAt() != nil || got.GetMergedInto() != "" || len(got.GetMergedFrom()) != 0 {
+			t.Errorf("sighting %s still merged after rollback: %v", id, got)
+		}
+		if len(got.GetWitnesses()) != len(was.GetWitnesses()) {
+			t.Errorf("sighting %s has %d witnesses after rollback, want %d", id, len(got.GetWitnesses()), len(was.GetWitnesses()))
+		}
+	}
+
+	// Откат записан новыми ревизиями, поэтому история согласована и изменения продолжаются
+	history, err := s.GetHistory(ctx, &ufoV1.GetHistoryRequest{Uuid: first})
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	revisions := history.GetRevisions()
+	if last := revisions[len(revisions)-1]; last.GetType() != ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_RESTORED {
+		t.Errorf("last revision of rolled back duplicate is %s, want RESTORED", last.GetType())
+	}
+	if len(findDuplicateIDs(t, s, canonical)) != 2 {
+		t.Error("rolled back duplicates are not found as duplicates again")
+	}
+	if _, err = s.MergeSightings(ctx, &ufoV1.MergeSightingsRequest{CanonicalUuid: canonical, DuplicateUuids: []string{first, second}}); err != nil {
+		t.Fatalf("merge after rollback: %v", err)
+	}
+}
+
+// findDuplicateIDs возвращает UUID неудаленных наблюдений, похожих на id
+func findDuplicateIDs(t *testing.T, s *ufoService, id string) []string {
+	t.Helper()
+
+	resp, err := s.FindDuplicates(context.Background(), &ufoV1.FindDuplicatesRequest{Uuid: id, MinScore: 0.01})
+	if err != nil {
+		t.Fatalf("find duplicates: %v", err)
+	}
+	ids := make([]string, 0, len(resp.GetMatches()))
+	for _, match := range resp.GetMatches() {
+		ids = append(ids, match.GetSighting().GetUuid())
+	}
+	return ids
+}
+
+func TestMergeDropsMergedFromOtherSightings(t *testing.T) {
+	s := newTestService(t, nil)
+	ctx := context.Background()
+
+	canonical := mustCreate(t, s, testInfo("Roswell", "silver disc"))
+	duplicate := mustCreate(t, s, testInfo("Roswell", "silver disc again"))
+	referrer := mustCreate(t, s, testInfo("Roswell", "silver disc once more"))
+	if got := mustGet(t, s, referrer).GetPossibleDuplicates(); !slices.Contains(got, duplicate) || !slices.Contains(got, canonical) {
+		t.Fatalf("possible_duplicates = %v, want both earlier sightings", got)
+	}
+
+	if _, err := s.MergeSightings(ctx, &ufoV1.MergeSightingsRequest{CanonicalUuid: canonical, DuplicateUuids: []string{duplicate}}); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+
+	got := mustGet(t, s, referrer)
+	if got.GetPossibleDuplicates()[0] != canonical || len(got.GetPossibleDuplicates()) != 1 {
+		t.Errorf("possible_duplicates after merge = %v, want only %s", got.GetPossibleDuplicates(), canonical)
+	}
+	if got.GetVersion() != 2 {
+		t.Errorf("referrer version = %d, want 2", got.GetVersion())
+	}
+}
+
+func TestMergeRollsBackReferrers(t *testing.T) {
+	repo := &faultyRepo{SightingRepository: memory.NewRepository()}
+	s := newTestService(t, repo)
+	ctx := context.Background()
+
+	canonical := mustCreate(t, s, testInfo("Roswell", "silver disc"))
+	duplicate := mustCreate(t, s, testInfo("Roswell", "silver disc again"))
+	referrer := mustCreate(t, s, testInfo("Roswell", "silver disc once more"))
+	flagged := mustGet(t, s, referrer).GetPossibleDuplicates()
+
+	repo.failUpdate = func(sighting *ufoV1.Sighting) bool { return sighting.GetUuid() == referrer }
+	_, err := s.MergeSightings(ctx, &ufoV1.MergeSightingsRequest{CanonicalUuid: canonical, DuplicateUuids: []string{duplicate}})
+	wantCode(t, err, codes.Internal)
+	repo.failUpdate = nil
+
+	if got := mustGet(t, s, duplicate); got.GetDeletedAt() != nil || got.GetMergedInto() != "" {
+		t.Errorf("duplicate still merged after rollback: %v", got)
+	}
+	if got := mustGet(t, s, referrer).GetPossibleDuplicates(); !slices.Equal(got, flagged) {
+		t.Errorf("referrer possible_duplicates = %v, want unchanged %v", got, flagged)
+	}
+}
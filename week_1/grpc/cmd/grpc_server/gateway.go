@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/textproto"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/interceptor"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// gatewayReadHeaderTimeout защищает шлюз от клиентов, которые слишком медленно шлют заголовки
+const gatewayReadHeaderTimeout = 10 * time.Second
+
+// gateway REST/JSON шлюз для браузерных клиентов. Работает в том же процессе,
+// но ходит в UFOService через обычное gRPC-соединение, поэтому запросы проходят
+// те же интерцепторы, что и у gRPC-клиентов
+type gateway struct {
+	conn   *grpc.ClientConn
+	server *http.Server
+}
+
+// newGateway создает шлюз на addr, который проксирует запросы в gRPC-сервер на grpcAddr.
+// С serverTLS шлюз отвечает по HTTPS, creds задают защиту соединения с gRPC-сервером
+func newGateway(ctx context.Context, addr, grpcAddr string, creds credentials.TransportCredentials, serverTLS *tls.Config) (*gateway, error) {
+	conn, err := grpc.NewClient(grpcAddr,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("connect to grpc server: %w", err)
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(gatewayIncomingHeader),
+		runtime.WithOutgoingHeaderMatcher(gatewayOutgoingHeader),
+		// GET /healthz отвечает по тому же grpc.health.v1, что и probe
+		runtime.WithHealthzEndpoint(healthpb.NewHealthClient(conn)),
+	)
+	if err = ufoV1.RegisterUFOServiceHandler(ctx, mux, conn); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("register gateway handlers: %w", err)
+	}
+
+	return &gateway{
+		conn: conn,
+		server: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: gatewayReadHeaderTimeout,
+			TLSConfig:         serverTLS,
+		},
+	}, nil
+}
+
+func (g *gateway) serve() {
+	log.Printf("🌐 Starting REST gateway on %s (tls=%t)\n", g.server.Addr, g.server.TLSConfig != nil)
+
+	var err error
+	if g.server.TLSConfig != nil {
+		// Сертификат берется из TLSConfig, поэтому пути к файлам не нужны
+		err = g.server.ListenAndServeTLS("", "")
+	} else {
+		err = g.server.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("Failed to serve gateway: %v\n", err)
+	}
+}
+
+// shutdown дожидается текущих HTTP-запросов и закрывает соединение с gRPC-сервером
+func (g *gateway) shutdown(ctx context.Context) {
+	if err := g.server.Shutdown(ctx); err != nil {
+		log.Printf("Failed to shut down gateway: %v\n", err)
+	}
+	if err := g.conn.Close(); err != nil {
+		log.Printf("Failed to close gateway connection: %v\n", err)
+	}
+}
+
+// gatewayIncomingHeader дополнительно к стандартным заголовкам пропускает X-Request-Id,
+// чтобы идентификатор запроса браузерного клиента дошел до логов
+func gatewayIncomingHeader(key string) (string, bool) {
+	if textproto.CanonicalMIMEHeaderKey(key) == textproto.CanonicalMIMEHeaderKey(interceptor.RequestIDKey) {
+		return interceptor.RequestIDKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// gatewayOutgoingHeader возвращает идентификатор запроса в привычном X-Request-Id,
+// остальные метаданные ответа идут с префиксом Grpc-Metadata-
+func gatewayOutgoingHeader(key string) (string, bool) {
+	if key == interceptor.RequestIDKey {
+		return textproto.CanonicalMIMEHeaderKey(key), true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
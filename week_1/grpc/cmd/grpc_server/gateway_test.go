@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGatewayRouting(t *testing.T) {
+	s := newTestService(t, nil)
+	addr := redactionServer(t, s)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	gw, err := newGateway(ctx, "", addr, insecure.NewCredentials(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = gw.conn.Close() })
+	server := httptest.NewServer(gw.server.Handler)
+	t.Cleanup(server.Close)
+
+	// do выполняет запрос и раскладывает тело ответа в out
+	do := func(t *testing.T, method, path, token, body string, wantStatus int, out proto.Message) {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, method, server.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != wantStatus {
+			t.Fatalf("%s %s: %s %s, want %d", method, path, resp.Status, data, wantStatus)
+		}
+		if err = protojson.Unmarshal(data, out); err != nil {
+			t.Fatalf("%s %s: decode %s: %v", method, path, data, err)
+		}
+	}
+
+	var created ufoV1.CreateResponse
+	do(t, http.MethodPost, "/api/v1/sightings", "reporter",
+		`{"info": {"location": "Roswell", "description": "Silver disc"}}`, http.StatusOK, &created)
+	if created.GetUuid() == "" {
+		t.Fatal("POST /api/v1/sightings returned no uuid")
+	}
+
+	var got ufoV1.GetResponse
+	do(t, http.MethodGet, "/api/v1/sightings/"+created.GetUuid(), "reporter", "", http.StatusOK, &got)
+	if got.GetSighting().GetInfo().GetLocation() != "Roswell" {
+		t.Errorf("GET returned %v, want the created sighting", got.GetSighting())
+	}
+
+	// Ошибки gRPC приходят с HTTP-статусом по коду и телом google.rpc.Status
+	for _, tt := range []struct {
+		name, method, path, token string
+		wantStatus                int
+		wantCode                  codes.Code
+	}{
+		{"unknown sighting", http.MethodGet, "/api/v1/sightings/missing", "reporter", http.StatusNotFound, codes.NotFound},
+		{"no token", http.MethodGet, "/api/v1/sightings/" + created.GetUuid(), "", http.StatusUnauthorized, codes.Unauthenticated},
+		{"role without access", http.MethodDelete, "/api/v1/sightings/" + created.GetUuid(), "reporter", http.StatusForbidden, codes.PermissionDenied},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var st status.Status
+			do(t, tt.method, tt.path, tt.token, "", tt.wantStatus, &st)
+			if codes.Code(st.GetCode()) != tt.wantCode || st.GetMessage() == "" {
+				t.Errorf("%s %s: body %v, want code %s with a message", tt.method, tt.path, &st, tt.wantCode)
+			}
+		})
+	}
+}
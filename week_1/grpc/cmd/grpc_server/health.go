@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthPingTimeout сколько ждать ответа хранилища при проверке готовности
+const healthPingTimeout = 2 * time.Second
+
+// runHealthCheck периодически проверяет хранилище и выставляет по его доступности
+// статус UFOService и общий статус сервера (пустое имя сервиса)
+func runHealthCheck(ctx context.Context, hs *health.Server, s *ufoService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last healthpb.HealthCheckResponse_ServingStatus
+	for {
+		current := s.storageStatus(ctx)
+		if current != last {
+			log.Printf("🩺 Storage health changed: %s", current)
+			hs.SetServingStatus("", current)
+			hs.SetServingStatus(ufoV1.UFOService_ServiceDesc.ServiceName, current)
+			last = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ufoService) storageStatus(ctx context.Context) healthpb.HealthCheckResponse_ServingStatus {
+	ctx, cancel := context.WithTimeout(ctx, healthPingTimeout)
+	defer cancel()
+
+	if err := s.repo.Ping(ctx); err != nil {
+		log.Printf("Storage ping failed: %v", err)
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository/memory"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// pingRepo хранилище, доступность которого переключает тест
+type pingRepo struct {
+	repository.SightingRepository
+	down atomic.Bool
+}
+
+func (r *pingRepo) Ping(ctx context.Context) error {
+	if r.down.Load() {
+		return errInjected
+	}
+	return r.SightingRepository.Ping(ctx)
+}
+
+// bufconnClient поднимает server в памяти и возвращает подключенного к нему клиента
+func bufconnClient(t *testing.T, server *grpc.Server) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestHealthFollowsStorage(t *testing.T) {
+	repo := &pingRepo{SightingRepository: memory.NewRepository()}
+	s := newTestService(t, repo)
+
+	// Как в main: до первой проверки хранилища сервер не готов
+	hs := health.NewServer()
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	hs.SetServingStatus(ufoV1.UFOService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, hs)
+	client := healthpb.NewHealthClient(bufconnClient(t, server))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	go runHealthCheck(ctx, hs, s, time.Millisecond)
+
+	waitStatus := func(step string, want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		for _, service := range []string{"", ufoV1.UFOService_ServiceDesc.ServiceName} {
+			waitFor(t, func() bool {
+				resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+				if err != nil {
+					t.Fatalf("%s: check %q: %v", step, service, err)
+				}
+				return resp.GetStatus() == want
+			})
+		}
+	}
+
+	waitStatus("storage up", healthpb.HealthCheckResponse_SERVING)
+
+	repo.down.Store(true)
+	waitStatus("ping fails", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	repo.down.Store(false)
+	waitStatus("storage back", healthpb.HealthCheckResponse_SERVING)
+
+	// При остановке сервер не готов, даже если хранилище доступно
+	hs.Shutdown()
+	waitStatus("shutdown", healthpb.HealthCheckResponse_NOT_SERVING)
+	// Следующие проверки хранилища статус уже не возвращают
+	repo.down.Store(true)
+	waitStatus("ping fails after shutdown", healthpb.HealthCheckResponse_NOT_SERVING)
+	repo.down.Store(false)
+	time.Sleep(10 * time.Millisecond)
+	waitStatus("after shutdown", healthpb.HealthCheckResponse_NOT_SERVING)
+}
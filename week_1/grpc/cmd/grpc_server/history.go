@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/auth"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// anonymousActor автор изменений, когда аутентификация выключена
+const anonymousActor = "anonymous"
+
+// trackedSightingFields поля наблюдения вне info, изменения которых попадают в историю.
+// Служебные поля (версия, время создания и обновления) в истории не нужны: они есть в самой ревизии
+var trackedSightingFields = []protoreflect.Name{"deleted_at", "attachments", "possible_duplicates", "merged_into", "merged_from", "status", "witnesses"}
+
+// actorOf возвращает автора изменения из контекста запроса
+func actorOf(ctx context.Context) string {
+	if p := auth.FromContext(ctx); p != nil && p.Subject != "" {
+		return p.Subject
+	}
+	return anonymousActor
+}
+
+// recordRevisionLocked дописывает в историю ревизию перехода previous -> current,
+// previous nil при создании. Тип и признак возврата берутся из revision, вызывается под s.mu
+func (s *ufoService) recordRevisionLocked(ctx context.Context, previous, current *ufoV1.Sighting, revision *ufoV1.Revision) error {
+	changes, err := diffSightings(previous, current)
+	if err != nil {
+		return status.Errorf(codes.Internal, "diff sighting %s: %v", current.GetUuid(), err)
+	}
+
+	revision.SightingUuid = current.GetUuid()
+	revision.Version = current.GetVersion()
+	revision.Actor = actorOf(ctx)
+	revision.CreatedAt = timestamppb.New(time.Now())
+	revision.Changes = changes
+	revision.Info = proto.Clone(current.GetInfo()).(*ufoV1.SightingInfo)
+
+	if err = s.repo.AppendRevision(ctx, revision); err != nil {
+		return repositoryError(err, current.GetUuid())
+	}
+	return nil
+}
+
+// diffSightings перечисляет измененные поля info и отслеживаемые поля наблюдения
+func diffSightings(previous, current *ufoV1.Sighting) ([]*ufoV1.FieldChange, error) {
+	var changes []*ufoV1.FieldChange
+
+	infoFields := (&ufoV1.SightingInfo{}).ProtoReflect().Descriptor().Fields()
+	for i := range infoFields.Len() {
+		change, err := diffField(infoFields.Get(i), previous.GetInfo(), current.GetInfo())
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			changes = append(changes, change)
+		}
+	}
+
+	previous, current = withoutContacts(previous), withoutContacts(current)
+	sightingFields := (&ufoV1.Sighting{}).ProtoReflect().Descriptor().Fields()
+	for _, name := range trackedSightingFields {
+		change, err := diffField(sightingFields.ByName(name), previous, current)
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
+
+// diffField сравнивает поле fd двух сообщений, nil если значение не изменилось
+func diffField(fd protoreflect.FieldDescriptor, previous, current proto.Message) (*ufoV1.FieldChange, error) {
+	oldValue, err := fieldValue(fd, previous)
+	if err != nil {
+		return nil, err
+	}
+	newValue, err := fieldValue(fd, current)
+	if err != nil {
+		return nil, err
+	}
+	if proto.Equal(oldValue, newValue) {
+		return nil, nil
+	}
+
+	return &ufoV1.FieldChange{Field: string(fd.Name()), OldValue: oldValue, NewValue: newValue}, nil
+}
+
+// fieldValue возвращает значение поля в том виде, в каком его отдает REST,
+// и null для незаданного поля или отсутствующего сообщения
+func fieldValue(fd protoreflect.FieldDescriptor, m proto.Message) (*structpb.Value, error) {
+	msg := m.ProtoReflect()
+	if !msg.IsValid() || !msg.Has(fd) {
+		return structpb.NewNullValue(), nil
+	}
+
+	// Сериализуем сообщение с одним этим полем, чтобы получить его JSON-представление
+	only := msg.Type().New()
+	only.Set(fd, msg.Get(fd))
+	raw, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(only.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("marshal field %s: %w", fd.Name(), err)
+	}
+
+	var fields structpb.Struct
+	if err = protojson.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("unmarshal field %s: %w", fd.Name(), err)
+	}
+	if value, ok := fields.GetFields()[string(fd.Name())]; ok {
+		return value, nil
+	}
+	return structpb.NewNullValue(), nil
+}
+
+// findRevision ищет ревизию с версией version в истории наблюдения
+func (s *ufoService) findRevision(ctx context.Context, uuid string, version int64) (*ufoV1.Revision, error) {
+	revisions, err := s.repo.ListRevisions(ctx, uuid)
+	if err != nil {
+		return nil, repositoryError(err, uuid)
+	}
+
+	idx := slices.IndexFunc(revisions, func(r *ufoV1.Revision) bool { return r.GetVersion() == version })
+	if idx < 0 {
+		return nil, status.Errorf(codes.NotFound, "revision %d of sighting %s not found", version, uuid)
+	}
+	return revisions[idx], nil
+}
+
+func (s *ufoService) GetHistory(ctx context.Context, req *ufoV1.GetHistoryRequest) (*ufoV1.GetHistoryResponse, error) {
+	pageSize, err := normalizePageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := decodeOffsetToken(req.GetPageToken(), fingerprint([]byte(req.GetUuid())))
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.repo.ListRevisions(ctx, req.GetUuid())
+	if err != nil {
+		return nil, repositoryError(err, req.GetUuid())
+	}
+	// Пустая история бывает и у наблюдений, созданных до ее появления, и у несуществующих
+	if len(revisions) == 0 {
+		if _, err = s.repo.Get(ctx, req.GetUuid()); err != nil {
+			return nil, repositoryError(err, req.GetUuid())
+		}
+	}
+
+	// История только дописывается в конец, поэтому смещение остается верным между страницами
+	start, end, next := cursor.page(len(revisions), pageSize)
+	return &ufoV1.GetHistoryResponse{Revisions: revisions[start:end], NextPageToken: next}, nil
+}
+
+func (s *ufoService) GetRevision(ctx context.Context, req *ufoV1.GetRevisionRequest) (*ufoV1.Revision, error) {
+	return s.findRevision(ctx, req.GetUuid(), req.GetVersion())
+}
+
+func (s *ufoService) RevertSighting(ctx context.Context, req *ufoV1.RevertSightingRequest) (*ufoV1.RevertSightingResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sighting, err := s.activeSightingLocked(ctx, req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if err = checkVersion(sighting, req.GetExpectedVersion()); err != nil {
+		return nil, err
+	}
+
+	revision, err := s.findRevision(ctx, req.GetUuid(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	if proto.Equal(sighting.GetInfo(), revision.GetInfo()) {
+		return nil, status.Errorf(codes.FailedPrecondition, "sighting with UUID %s already matches revision %d", req.GetUuid(), req.GetVersion())
+	}
+
+	sighting.Info = proto.Clone(revision.GetInfo()).(*ufoV1.SightingInfo)
+	sighting.UpdatedAt = timestamppb.New(time.Now())
+	err = s.commitLocked(ctx, sighting, &ufoV1.Revision{
+		Type:                ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_UPDATED,
+		RevertedFromVersion: req.GetVersion(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("⏪ Reverted sighting %s to revision %d, new version %d", req.GetUuid(), req.GetVersion(), sighting.GetVersion())
+	return &ufoV1.RevertSightingResponse{Version: sighting.GetVersion()}, nil
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/auth"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository/memory"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// changesByField раскладывает изменения ревизии по именам полей
+func changesByField(changes []*ufoV1.FieldChange) map[string]*ufoV1.FieldChange {
+	byField := make(map[string]*ufoV1.FieldChange, len(changes))
+	for _, c := range changes {
+		byField[c.GetField()] = c
+	}
+	return byField
+}
+
+func TestDiffSightings(t *testing.T) {
+	previous := &ufoV1.Sighting{
+		Uuid:   "s",
+		Info:   testInfo("Roswell", "Silver disc"),
+		Status: ufoV1.SightingStatus_SIGHTING_STATUS_SUBMITTED,
+		Witnesses: []*ufoV1.Witness{{
+			Id:   "w",
+			Info: &ufoV1.WitnessInfo{Name: "Fox", Contact: "fox@fbi.gov"},
+		}},
+	}
+	previous.Info.DurationSeconds = wrapperspb.Int32(30)
+
+	current := proto.Clone(previous).(*ufoV1.Sighting)
+	current.Version = 7 // служебные поля в историю не попадают
+	current.Info.Location = "Area 51"
+	current.Info.Color = wrapperspb.String("green")
+	current.Info.DurationSeconds = nil
+	current.Status = ufoV1.SightingStatus_SIGHTING_STATUS_UNDER_REVIEW
+	current.Witnesses[0].Info.Contact = "fox@x-files.org"
+
+	changes, err := diffSightings(previous, current)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	byField := changesByField(changes)
+
+	want := map[string][2]*structpb.Value{
+		"location":         {structpb.NewStringValue("Roswell"), structpb.NewStringValue("Area 51")},
+		"color":            {structpb.NewNullValue(), structpb.NewStringValue("green")},
+		"duration_seconds": {structpb.NewNumberValue(30), structpb.NewNullValue()},
+		"status":           {structpb.NewStringValue("SIGHTING_STATUS_SUBMITTED"), structpb.NewStringValue("SIGHTING_STATUS_UNDER_REVIEW")},
+	}
+	if len(byField) != len(want) {
+		t.Errorf("changed fields = %v, want %d fields", changes, len(want))
+	}
+	for field, values := range want {
+		c, ok := byField[field]
+		if !ok {
+			t.Errorf("no change of %s", field)
+			continue
+		}
+		if !proto.Equal(c.GetOldValue(), values[0]) || !proto.Equal(c.GetNewValue(), values[1]) {
+			t.Errorf("%s: %v -> %v, want %v -> %v", field, c.GetOldValue(), c.GetNewValue(), values[0], values[1])
+		}
+	}
+
+	// Контакт свидетеля не хранится в истории, поэтому и его смена не видна
+	if _, ok := byField["witnesses"]; ok {
+		t.Error("contact change of a witness got into history")
+	}
+}
+
+func TestDiffSightingsOnCreate(t *testing.T) {
+	current := &ufoV1.Sighting{Uuid: "s", Info: testInfo("Roswell", "Silver disc")}
+
+	changes, err := diffSightings(nil, current)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	byField := changesByField(changes)
+	for _, field := range []string{"observed_at", "location", "description"} {
+		c, ok := byField[field]
+		if !ok || c.GetOldValue().GetNullValue() != structpb.NullValue_NULL_VALUE {
+			t.Errorf("%s: change %v, want from null", field, c)
+		}
+	}
+	if len(byField) != 3 {
+		t.Errorf("changes = %v, want only the fields that are set", changes)
+	}
+}
+
+func TestHistoryRecordsEachChange(t *testing.T) {
+	s := newTestService(t, nil)
+	ctx := auth.NewContext(context.Background(), &auth.Principal{Subject: "scully", Roles: []string{auth.RoleAdmin}})
+
+	id := mustCreate(t, s, testInfo("Roswell", "Silver disc"))
+	if _, err := s.Update(ctx, &ufoV1.UpdateRequest{
+		Uuid:       id,
+		UpdateInfo: &ufoV1.SightingUpdateInfo{Location: wrapperspb.String("Area 51")},
+	}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if _, err := s.Delete(ctx, &ufoV1.DeleteRequest{Uuid: id}); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	resp, err := s.GetHistory(ctx, &ufoV1.GetHistoryRequest{Uuid: id})
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+
+	wantTypes := []ufoV1.SightingEventType{
+		ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_CREATED,
+		ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_UPDATED,
+		ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_DELETED,
+	}
+	wantActors := []string{anonymousActor, "scully", "scully"}
+	revisions := resp.GetRevisions()
+	if len(revisions) != len(wantTypes) {
+		t.Fatalf("history has %d revisions, want %d", len(revisions), len(wantTypes))
+	}
+	for i, r := range revisions {
+		if r.GetVersion() != int64(i+1) || r.GetType() != wantTypes[i] || r.GetActor() != wantActors[i] {
+			t.Errorf("revision %d = v%d %s by %s", i, r.GetVersion(), r.GetType(), r.GetActor())
+		}
+	}
+	if location := revisions[1].GetInfo().GetLocation(); location != "Area 51" {
+		t.Errorf("revision 2 info location = %q", location)
+	}
+	if _, ok := changesByField(revisions[2].GetChanges())["deleted_at"]; !ok {
+		t.Errorf("delete revision changes = %v, want deleted_at", revisions[2].GetChanges())
+	}
+}
+
+func TestGetHistoryPaging(t *testing.T) {
+	s := newTestService(t, nil)
+	id := mustCreate(t, s, testInfo("Roswell", "Silver disc"))
+	other := mustCreate(t, s, testInfo("Phoenix", "Lights"))
+	for _, location := range []string{"A", "B", "C"} {
+		if _, err := s.Update(context.Background(), &ufoV1.UpdateRequest{
+			Uuid:       id,
+			UpdateInfo: &ufoV1.SightingUpdateInfo{Location: wrapperspb.String(location)},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	first, err := s.GetHistory(context.Background(), &ufoV1.GetHistoryRequest{Uuid: id, PageSize: 3})
+	if err != nil || len(first.GetRevisions()) != 3 || first.GetNextPageToken() == "" {
+		t.Fatalf("first page = %v, %v", first, err)
+	}
+	second, err := s.GetHistory(context.Background(), &ufoV1.GetHistoryRequest{Uuid: id, PageSize: 3, PageToken: first.GetNextPageToken()})
+	if err != nil || len(second.GetRevisions()) != 1 || second.GetRevisions()[0].GetVersion() != 4 || second.GetNextPageToken() != "" {
+		t.Fatalf("second page = %v, %v", second, err)
+	}
+
+	_, err = s.GetHistory(context.Background(), &ufoV1.GetHistoryRequest{Uuid: other, PageToken: first.GetNextPageToken()})
+	wantCode(t, err, codes.InvalidArgument)
+
+	_, err = s.GetHistory(context.Background(), &ufoV1.GetHistoryRequest{Uuid: "6f1c2b8e-3a4d-4f5e-9b6a-7c8d9e0f1a2b"})
+	wantCode(t, err, codes.NotFound)
+}
+
+func TestRevertCreatesNewRevision(t *testing.T) {
+	s := newTestService(t, nil)
+	id := mustCreate(t, s, testInfo("Roswell", "Silver disc"))
+	if _, err := s.Update(context.Background(), &ufoV1.UpdateRequest{
+		Uuid:       id,
+		UpdateInfo: &ufoV1.SightingUpdateInfo{Location: wrapperspb.String("Area 51")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := s.RevertSighting(context.Background(), &ufoV1.RevertSightingRequest{Uuid: id, Version: 1})
+	if err != nil {
+		t.Fatalf("revert: %v", err)
+	}
+	if resp.GetVersion() != 3 {
+		t.Errorf("version after revert = %d, want 3", resp.GetVersion())
+	}
+
+	sighting := mustGet(t, s, id)
+	if sighting.GetInfo().GetLocation() != "Roswell" || sighting.GetVersion() != 3 {
+		t.Errorf("sighting after revert = %v", sighting)
+	}
+
+	// Возврат не переписывает историю, а дописывает ревизию со ссылкой на исходную
+	revision, err := s.GetRevision(context.Background(), &ufoV1.GetRevisionRequest{Uuid: id, Version: 3})
+	if err != nil {
+		t.Fatalf("get revision: %v", err)
+	}
+	if revision.GetRevertedFromVersion() != 1 || revision.GetType() != ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_UPDATED {
+		t.Errorf("revert revision = %v", revision)
+	}
+	if c := changesByField(revision.GetChanges())["location"]; c.GetNewValue().GetStringValue() != "Roswell" {
+		t.Errorf("revert revision changes = %v", revision.GetChanges())
+	}
+	if r2, err := s.GetRevision(context.Background(), &ufoV1.GetRevisionRequest{Uuid: id, Version: 2}); err != nil || r2.GetInfo().GetLocation() != "Area 51" {
+		t.Errorf("revision 2 = %v, %v", r2, err)
+	}
+
+	_, err = s.RevertSighting(context.Background(), &ufoV1.RevertSightingRequest{Uuid: id, Version: 1})
+	wantCode(t, err, codes.FailedPrecondition)
+	_, err = s.RevertSighting(context.Background(), &ufoV1.RevertSightingRequest{Uuid: id, Version: 9})
+	wantCode(t, err, codes.NotFound)
+	_, err = s.RevertSighting(context.Background(), &ufoV1.RevertSightingRequest{Uuid: id, Version: 2, ExpectedVersion: wrapperspb.Int64(2)})
+	wantCode(t, err, codes.Aborted)
+}
+
+func TestFailedRevisionRollsBackUpdate(t *testing.T) {
+	repo := &faultyRepo{SightingRepository: memory.NewRepository()}
+	s := newTestService(t, repo)
+	id := mustCreate(t, s, testInfo("Roswell", "Silver disc"))
+	sequence := s.events.sequence
+
+	repo.failAppendRevision = func(*ufoV1.Revision) bool { return true }
+	_, err := s.Update(context.Background(), &ufoV1.UpdateRequest{
+		Uuid:       id,
+		UpdateInfo: &ufoV1.SightingUpdateInfo{Location: wrapperspb.String("Area 51")},
+	})
+	wantCode(t, err, codes.Internal)
+
+	sighting := mustGet(t, s, id)
+	if sighting.GetVersion() != 1 || sighting.GetInfo().GetLocation() != "Roswell" {
+		t.Errorf("sighting after failed update = %v, want version 1 unchanged", sighting)
+	}
+	if hits := s.textIndex.Search("Area 51"); len(hits) != 0 {
+		t.Errorf("search index picked up the failed update: %v", hits)
+	}
+	if s.events.sequence != sequence {
+		t.Error("event published for a failed update")
+	}
+
+	// После сбоя история продолжается без пропусков
+	repo.failAppendRevision = nil
+	if _, err = s.Update(context.Background(), &ufoV1.UpdateRequest{
+		Uuid:       id,
+		UpdateInfo: &ufoV1.SightingUpdateInfo{Location: wrapperspb.String("Area 51")},
+	}); err != nil {
+		t.Fatalf("update after failure: %v", err)
+	}
+	if r, err := s.GetRevision(context.Background(), &ufoV1.GetRevisionRequest{Uuid: id, Version: 2}); err != nil || r.GetInfo().GetLocation() != "Area 51" {
+		t.Errorf("revision 2 = %v, %v", r, err)
+	}
+}
+
+func TestFailedRevisionRollsBackCreate(t *testing.T) {
+	repo := &faultyRepo{
+		SightingRepository: memory.NewRepository(),
+		failAppendRevision: func(*ufoV1.Revision) bool { return true },
+	}
+	s := newTestService(t, repo)
+
+	_, err := s.Create(context.Background(), &ufoV1.CreateRequest{Info: testInfo("Roswell", "Silver disc")})
+	wantCode(t, err, codes.Internal)
+
+	if sightings, _ := repo.List(context.Background()); len(sightings) != 0 {
+		t.Errorf("repository keeps %d sightings after a failed create", len(sightings))
+	}
+	if s.textIndex.Len() != 0 {
+		t.Error("search index keeps a sighting after a failed create")
+	}
+}
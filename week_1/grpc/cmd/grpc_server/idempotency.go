@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/auth"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// idempotencyEntry результат запроса, выполненного с ключом идемпотентности
+type idempotencyEntry struct {
+	fingerprint [sha256.Size]byte
+	resp        *ufoV1.CreateResponse
+	expiresAt   time.Time
+}
+
+// idempotencyKey ключ идемпотентности в пространстве имен вызывающего.
+// Клиенты генерируют ключи сами, поэтому одинаковый ключ у разных клиентов
+// не должен ни отдавать чужой ответ, ни отклонять запрос
+type idempotencyKey struct {
+	subject string
+	key     string
+}
+
+// idempotencyKeyOf ключ запроса для вызывающего из контекста.
+// Без аутентификации все вызывающие делят одно пространство имен
+func idempotencyKeyOf(ctx context.Context, key string) idempotencyKey {
+	var subject string
+	if p := auth.FromContext(ctx); p != nil {
+		subject = p.Subject
+	}
+	return idempotencyKey{subject: subject, key: key}
+}
+
+// idempotencyStore помнит ответы Create по ключам идемпотентности в течение ttl.
+// Хранится в памяти процесса: после перезапуска повтор создаст новое наблюдение
+type idempotencyStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[idempotencyKey]idempotencyEntry
+}
+
+func newIdempotencyStore(ttl time.Duration) *idempotencyStore {
+	return &idempotencyStore{
+		ttl:     ttl,
+		entries: make(map[idempotencyKey]idempotencyEntry),
+	}
+}
+
+// fingerprintOf хэш данных запроса, по которому повтор отличается от другого запроса с тем же ключом
+func fingerprintOf(info *ufoV1.SightingInfo) ([sha256.Size]byte, error) {
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(info)
+	if err != nil {
+		return [sha256.Size]byte{}, fmt.Errorf("marshal sighting info: %w", err)
+	}
+	return sha256.Sum256(raw), nil
+}
+
+// lookup возвращает сохраненный ответ для повтора или nil, если ключ еще не использовался
+func (s *idempotencyStore) lookup(key idempotencyKey, fingerprint [sha256.Size]byte, now time.Time) (*ufoV1.CreateResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || now.After(entry.expiresAt) {
+		return nil, nil
+	}
+	if entry.fingerprint != fingerprint {
+		return nil, status.Errorf(codes.FailedPrecondition, "idempotency key %q was already used with a different request", key.key)
+	}
+
+	return proto.Clone(entry.resp).(*ufoV1.CreateResponse), nil
+}
+
+func (s *idempotencyStore) remember(key idempotencyKey, fingerprint [sha256.Size]byte, resp *ufoV1.CreateResponse, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = idempotencyEntry{
+		fingerprint: fingerprint,
+		resp:        proto.Clone(resp).(*ufoV1.CreateResponse),
+		expiresAt:   now.Add(s.ttl),
+	}
+}
+
+// run периодически удаляет ключи с истекшим сроком, чтобы память не росла бесконечно
+func (s *idempotencyStore) run(ctx context.Context) {
+	ticker := time.NewTicker(s.ttl)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.mu.Lock()
+			for key, entry := range s.entries {
+				if now.After(entry.expiresAt) {
+					delete(s.entries, key)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/auth"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+)
+
+func asSubject(subject string) context.Context {
+	return auth.NewContext(context.Background(), &auth.Principal{Subject: subject, Roles: []string{auth.RoleReporter}})
+}
+
+func TestIdempotencyKeysAreScopedBySubject(t *testing.T) {
+	s := newTestService(t, nil)
+	const key = "retry-me"
+
+	mulder, err := s.Create(asSubject("mulder"), &ufoV1.CreateRequest{Info: testInfo("Roswell", "disc"), IdempotencyKey: key})
+	if err != nil {
+		t.Fatalf("create as mulder: %v", err)
+	}
+
+	// Тот же ключ у другого вызывающего - это другой запрос, а не повтор и не конфликт
+	scully, err := s.Create(asSubject("scully"), &ufoV1.CreateRequest{Info: testInfo("Phoenix", "lights"), IdempotencyKey: key})
+	if err != nil {
+		t.Fatalf("create as scully with the same key: %v", err)
+	}
+	if scully.GetUuid() == mulder.GetUuid() {
+		t.Fatalf("scully got mulder's sighting %s", mulder.GetUuid())
+	}
+	sameData, err := s.Create(asSubject("skinner"), &ufoV1.CreateRequest{Info: testInfo("Roswell", "disc"), IdempotencyKey: key})
+	if err != nil {
+		t.Fatalf("create as skinner with the same key and data: %v", err)
+	}
+	if sameData.GetUuid() == mulder.GetUuid() {
+		t.Fatalf("skinner got mulder's sighting %s", mulder.GetUuid())
+	}
+
+	// Внутри пространства имен вызывающего ключ работает как раньше
+	replay, err := s.Create(asSubject("mulder"), &ufoV1.CreateRequest{Info: testInfo("Roswell", "disc"), IdempotencyKey: key})
+	if err != nil {
+		t.Fatalf("replay as mulder: %v", err)
+	}
+	if replay.GetUuid() != mulder.GetUuid() {
+		t.Errorf("replay returned %s, want %s", replay.GetUuid(), mulder.GetUuid())
+	}
+	_, err = s.Create(asSubject("scully"), &ufoV1.CreateRequest{Info: testInfo("Roswell", "disc"), IdempotencyKey: key})
+	wantCode(t, err, codes.FailedPrecondition)
+}
+
+func TestIdempotencyWithoutAuthentication(t *testing.T) {
+	s := newTestService(t, nil)
+	req := &ufoV1.CreateRequest{Info: testInfo("Roswell", "disc"), IdempotencyKey: "retry-me"}
+
+	first, err := s.Create(context.Background(), req)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	replay, err := s.Create(context.Background(), req)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if replay.GetUuid() != first.GetUuid() {
+		t.Errorf("replay returned %s, want %s", replay.GetUuid(), first.GetUuid())
+	}
+
+	// Аутентифицированный вызывающий не видит ключи анонимных запросов
+	other, err := s.Create(asSubject("mulder"), req)
+	if err != nil {
+		t.Fatalf("create as mulder: %v", err)
+	}
+	if other.GetUuid() == first.GetUuid() {
+		t.Errorf("mulder got the anonymous sighting %s", first.GetUuid())
+	}
+}
+
+func TestIdempotencyKeyExpires(t *testing.T) {
+	store := newIdempotencyStore(time.Hour)
+	key := idempotencyKey{subject: "mulder", key: "retry-me"}
+	fingerprint, err := fingerprintOf(testInfo("Roswell", "disc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	store.remember(key, fingerprint, &ufoV1.CreateResponse{Uuid: "u1"}, now)
+
+	if resp, err := store.lookup(key, fingerprint, now.Add(time.Hour)); err != nil || resp.GetUuid() != "u1" {
+		t.Errorf("lookup before expiry = %v, %v, want u1", resp, err)
+	}
+	if resp, err := store.lookup(key, fingerprint, now.Add(time.Hour+time.Second)); err != nil || resp != nil {
+		t.Errorf("lookup after expiry = %v, %v, want nothing", resp, err)
+	}
+}
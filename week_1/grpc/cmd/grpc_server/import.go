@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/validator"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxAtomicImportSize ограничивает число наблюдений, которые атомарная загрузка держит в памяти до коммита
+const maxAtomicImportSize = 10000
+
+// validateSightingInfo проверяет элемент загрузки по тем же правилам из .proto, что и Create
+func validateSightingInfo(info *ufoV1.SightingInfo) error {
+	if info == nil {
+		return errors.New("info is required")
+	}
+
+	violations := validator.Validate(info)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(violations))
+	for _, v := range violations {
+		messages = append(messages, v.GetField()+": "+v.GetDescription())
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
+
+func (s *ufoService) ImportSightings(stream grpc.ClientStreamingServer[ufoV1.ImportSightingsRequest, ufoV1.ImportSightingsResponse]) error {
+	var (
+		resp    = &ufoV1.ImportSightingsResponse{}
+		atomic  bool
+		pending []*ufoV1.SightingInfo
+	)
+
+	for first := true; ; first = false {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch payload := req.GetPayload().(type) {
+		case *ufoV1.ImportSightingsRequest_Options:
+			if !first {
+				return status.Errorf(codes.InvalidArgument, "options must be the first message of the stream")
+			}
+			atomic = payload.Options.GetAtomic()
+			continue
+		case *ufoV1.ImportSightingsRequest_Info:
+		default:
+			return status.Errorf(codes.InvalidArgument, "message %d has no payload", resp.Received)
+		}
+
+		index := resp.Received
+		resp.Received++
+
+		if err = validateSightingInfo(req.GetInfo()); err != nil {
+			resp.Errors = append(resp.Errors, &ufoV1.ImportItemError{Index: index, Message: err.Error()})
+			continue
+		}
+
+		if !atomic {
+			// Ошибка элемента не прерывает загрузку: иначе клиент потерял бы итог
+			// вместе с UUID уже созданных наблюдений
+			sighting, err := s.importOne(stream.Context(), req.GetInfo())
+			if err != nil {
+				resp.Errors = append(resp.Errors, &ufoV1.ImportItemError{Index: index, Message: importErrorMessage(err)})
+				continue
+			}
+
+			resp.Created = append(resp.Created, &ufoV1.ImportedSighting{Index: index, Uuid: sighting.GetUuid()})
+			continue
+		}
+
+		// После первой ошибки атомарная загрузка уже не будет применена,
+		// поэтому оставшиеся элементы только проверяем. Пока ошибок нет,
+		// индекс элемента совпадает с его позицией в pending
+		if len(resp.Errors) > 0 {
+			continue
+		}
+		if len(pending) >= maxAtomicImportSize {
+			return status.Errorf(codes.ResourceExhausted, "atomic import is limited to %d sightings", maxAtomicImportSize)
+		}
+		pending = append(pending, req.GetInfo())
+	}
+
+	if atomic && len(resp.Errors) == 0 && len(pending) > 0 {
+		// Квота списывается за всю загрузку сразу: атомарная загрузка не создает ее часть
+		client, err := s.createQuota.take(stream.Context(), len(pending))
+		if err != nil {
+			return err
+		}
+		if err = s.createAll(stream.Context(), pending, resp); err != nil {
+			s.createQuota.refund(client, len(pending))
+			return err
+		}
+	}
+
+	log.Printf("Imported %d of %d sightings (atomic=%t)", len(resp.Created), resp.Received, atomic)
+	return stream.SendAndClose(resp)
+}
+
+// importErrorMessage описание ошибки создания для ImportItemError, с кодом gRPC,
+// чтобы клиент отличал исчерпанную квоту от отказа хранилища
+func importErrorMessage(err error) string {
+	st := status.Convert(err)
+	return st.Code().String() + ": " + st.Message()
+}
+
+// importOne создает одно наблюдение неатомарной загрузки, списывая его из квоты
+func (s *ufoService) importOne(ctx context.Context, info *ufoV1.SightingInfo) (*ufoV1.Sighting, error) {
+	client, err := s.createQuota.take(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sighting, err := s.createLocked(ctx, info)
+	if err != nil {
+		s.createQuota.refund(client, 1)
+		return nil, err
+	}
+	return sighting, nil
+}
+
+// createAll атомарно создает наблюдения: события публикуются только после того,
+// как сохранены все записи, а при отказе хранилища уже сохраненные удаляются
+func (s *ufoService) createAll(ctx context.Context, infos []*ufoV1.SightingInfo, resp *ufoV1.ImportSightingsResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := make([]*ufoV1.Sighting, 0, len(infos))
+	for _, info := range infos {
+		sighting, err := s.storeLocked(ctx, info)
+		if err != nil {
+			for _, created := range stored {
+				if rerr := s.repo.Delete(context.WithoutCancel(ctx), created.GetUuid()); rerr != nil {
+					log.Printf("Failed to roll back imported sighting %s: %v", created.GetUuid(), rerr)
+				}
+				if rerr := s.repo.DeleteRevisions(context.WithoutCancel(ctx), created.GetUuid()); rerr != nil {
+					log.Printf("Failed to roll back history of imported sighting %s: %v", created.GetUuid(), rerr)
+				}
+				s.unindexSighting(created.GetUuid())
+				s.counts.move(created, nil)
+			}
+			return err
+		}
+		stored = append(stored, sighting)
+	}
+
+	for i, sighting := range stored {
+		s.events.publish(ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_CREATED, sighting)
+		resp.Created = append(resp.Created, &ufoV1.ImportedSighting{Index: int32(i), Uuid: sighting.GetUuid()})
+	}
+
+	return nil
+}
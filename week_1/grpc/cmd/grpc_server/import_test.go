@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository/memory"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+)
+
+func importStream(atomic bool, infos ...*ufoV1.SightingInfo) *fakeClientStream[ufoV1.ImportSightingsRequest, ufoV1.ImportSightingsResponse] {
+	stream := &fakeClientStream[ufoV1.ImportSightingsRequest, ufoV1.ImportSightingsResponse]{ctx: context.Background()}
+	stream.reqs = append(stream.reqs, &ufoV1.ImportSightingsRequest{
+		Payload: &ufoV1.ImportSightingsRequest_Options{Options: &ufoV1.ImportOptions{Atomic: atomic}},
+	})
+	for _, info := range infos {
+		stream.reqs = append(stream.reqs, &ufoV1.ImportSightingsRequest{Payload: &ufoV1.ImportSightingsRequest_Info{Info: info}})
+	}
+	return stream
+}
+
+func countSightings(t *testing.T, s *ufoService) int {
+	t.Helper()
+
+	sightings, err := s.repo.List(context.Background())
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	return len(sightings)
+}
+
+func TestImportChargesCreateQuota(t *testing.T) {
+	s := newTestService(t, nil)
+	s.createQuota = newCreateQuota(3)
+
+	stream := importStream(false,
+		testInfo("Roswell", "first"), testInfo("Phoenix", "second"),
+		testInfo("Kecksburg", "third"), testInfo("Rendlesham", "fourth"),
+	)
+	if err := s.ImportSightings(stream); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if n := countSightings(t, s); n != 3 {
+		t.Errorf("created %d sightings, want 3 within the quota", n)
+	}
+	if errs := stream.resp.GetErrors(); len(errs) != 1 || errs[0].GetIndex() != 3 ||
+		!strings.HasPrefix(errs[0].GetMessage(), codes.ResourceExhausted.String()) {
+		t.Errorf("errors = %v, want quota error for item 3", errs)
+	}
+
+	_, err := s.Create(context.Background(), &ufoV1.CreateRequest{Info: testInfo("Area 51", "after import")})
+	wantCode(t, err, codes.ResourceExhausted)
+}
+
+func TestImportContinuesAfterFailedItem(t *testing.T) {
+	repo := &faultyRepo{SightingRepository: memory.NewRepository()}
+	s := newTestService(t, repo)
+
+	// Хранилище отказывает на втором созданном наблюдении
+	var revisions int
+	repo.failAppendRevision = func(*ufoV1.Revision) bool {
+		revisions++
+		return revisions == 2
+	}
+	invalid := testInfo("", "no location")
+	stream := importStream(false,
+		testInfo("Roswell", "first"), testInfo("Phoenix", "second"),
+		invalid, testInfo("Kecksburg", "fourth"),
+	)
+	if err := s.ImportSightings(stream); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	resp := stream.resp
+	if resp.GetReceived() != 4 {
+		t.Errorf("received = %d, want 4", resp.GetReceived())
+	}
+	created := resp.GetCreated()
+	if len(created) != 2 || created[0].GetIndex() != 0 || created[1].GetIndex() != 3 {
+		t.Fatalf("created = %v, want items 0 and 3", created)
+	}
+	for _, c := range created {
+		mustGet(t, s, c.GetUuid())
+	}
+	errs := resp.GetErrors()
+	if len(errs) != 2 || errs[0].GetIndex() != 1 || errs[1].GetIndex() != 2 {
+		t.Fatalf("errors = %v, want items 1 and 2", errs)
+	}
+	if !strings.HasPrefix(errs[0].GetMessage(), codes.Internal.String()) {
+		t.Errorf("storage error = %q, want code %s", errs[0].GetMessage(), codes.Internal)
+	}
+	if n := countSightings(t, s); n != 2 {
+		t.Errorf("stored %d sightings, want 2", n)
+	}
+}
+
+func TestAtomicImportChargesWholeBatch(t *testing.T) {
+	s := newTestService(t, nil)
+	s.createQuota = newCreateQuota(2)
+
+	err := s.ImportSightings(importStream(true,
+		testInfo("Roswell", "first"), testInfo("Phoenix", "second"), testInfo("Kecksburg", "third"),
+	))
+	wantCode(t, err, codes.ResourceExhausted)
+	if n := countSightings(t, s); n != 0 {
+		t.Fatalf("atomic import over the quota created %d sightings", n)
+	}
+
+	// Отклоненная загрузка квоту не израсходовала
+	stream := importStream(true, testInfo("Roswell", "first"), testInfo("Phoenix", "second"))
+	if err = s.ImportSightings(stream); err != nil {
+		t.Fatalf("atomic import within the quota: %v", err)
+	}
+	if len(stream.resp.GetCreated()) != 2 {
+		t.Errorf("created %d sightings, want 2", len(stream.resp.GetCreated()))
+	}
+}
+
+func TestCreateReplayIsNotCharged(t *testing.T) {
+	s := newTestService(t, nil)
+	s.createQuota = newCreateQuota(1)
+	ctx := context.Background()
+	req := &ufoV1.CreateRequest{Info: testInfo("Roswell", "disc"), IdempotencyKey: "retry-me"}
+
+	first, err := s.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	for range 3 {
+		replay, err := s.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("replay: %v", err)
+		}
+		if replay.GetUuid() != first.GetUuid() {
+			t.Fatalf("replay returned %s, want %s", replay.GetUuid(), first.GetUuid())
+		}
+	}
+
+	_, err = s.Create(ctx, &ufoV1.CreateRequest{Info: testInfo("Phoenix", "lights")})
+	wantCode(t, err, codes.ResourceExhausted)
+}
+
+func TestFailedCreateIsRefunded(t *testing.T) {
+	repo := &faultyRepo{SightingRepository: memory.NewRepository()}
+	s := newTestService(t, repo)
+	s.createQuota = newCreateQuota(1)
+
+	repo.failAppendRevision = func(*ufoV1.Revision) bool { return true }
+	_, err := s.Create(context.Background(), &ufoV1.CreateRequest{Info: testInfo("Roswell", "disc")})
+	wantCode(t, err, codes.Internal)
+
+	repo.failAppendRevision = nil
+	if _, err = s.Create(context.Background(), &ufoV1.CreateRequest{Info: testInfo("Roswell", "disc")}); err != nil {
+		t.Fatalf("create after failed create: %v", err)
+	}
+}
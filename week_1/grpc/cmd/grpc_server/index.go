@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/geo"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/search"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+)
+
+// indexSighting обновляет наблюдение в поисковом и пространственном индексах:
+// удаленные наблюдения не ищутся
+func (s *ufoService) indexSighting(sighting *ufoV1.Sighting) {
+	if sighting.GetDeletedAt() != nil {
+		s.unindexSighting(sighting.GetUuid())
+		return
+	}
+
+	if c := sighting.GetInfo().GetCoordinates(); c != nil {
+		s.geoIndex.Put(sighting.GetUuid(), geo.Point{Lat: c.GetLatitude(), Lon: c.GetLongitude()})
+	} else {
+		s.geoIndex.Remove(sighting.GetUuid())
+	}
+	s.textIndex.Put(sighting.GetUuid(), map[search.Field]string{
+		search.FieldDescription: sighting.GetInfo().GetDescription(),
+		search.FieldLocation:    sighting.GetInfo().GetLocation(),
+	})
+}
+
+// unindexSighting убирает наблюдение из всех индексов
+func (s *ufoService) unindexSighting(id string) {
+	s.textIndex.Remove(id)
+	s.geoIndex.Remove(id)
+}
+
+// buildIndex индексирует и подсчитывает все сохраненные наблюдения при старте сервера,
+// дальше индекс и счетчики обновляются вместе с каждым изменением
+func (s *ufoService) buildIndex(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.repo.Scan(ctx, func(sighting *ufoV1.Sighting) error {
+		s.indexSighting(sighting)
+		s.counts.move(nil, sighting)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("🔎 Indexed %d sightings for search, %d with coordinates", s.textIndex.Len(), s.geoIndex.Len())
+	return nil
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 1000
+)
+
+// normalizePageSize проверяет page_size и подставляет значение по умолчанию
+func normalizePageSize(size int32) (int, error) {
+	switch {
+	case size < 0:
+		return 0, status.Errorf(codes.InvalidArgument, "page_size must not be negative")
+	case size == 0:
+		return defaultPageSize, nil
+	case size > maxPageSize:
+		return maxPageSize, nil
+	}
+	return int(size), nil
+}
+
+// pageCursor позиция в упорядоченном списке наблюдений: последняя отданная запись.
+// Отпечаток фильтра не дает продолжить выдачу с другим фильтром
+type pageCursor struct {
+	createdAtNanos int64
+	uuid           string
+	fingerprint    uint32
+}
+
+// encodePageToken превращает курсор в непрозрачный для клиента токен
+func encodePageToken(c pageCursor) string {
+	raw := strconv.FormatInt(c.createdAtNanos, 10) + "/" + c.uuid + "/" + strconv.FormatUint(uint64(c.fingerprint), 16)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodePageToken(token string) (pageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return pageCursor{}, fmt.Errorf("decode page token: %w", err)
+	}
+
+	parts := strings.Split(string(raw), "/")
+	if len(parts) != 3 || parts[1] == "" {
+		return pageCursor{}, fmt.Errorf("malformed page token")
+	}
+
+	createdAtNanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return pageCursor{}, fmt.Errorf("parse page token: %w", err)
+	}
+	fp, err := strconv.ParseUint(parts[2], 16, 32)
+	if err != nil {
+		return pageCursor{}, fmt.Errorf("parse page token: %w", err)
+	}
+
+	return pageCursor{createdAtNanos: createdAtNanos, uuid: parts[1], fingerprint: uint32(fp)}, nil
+}
+
+func cursorOf(s *ufoV1.Sighting) pageCursor {
+	return pageCursor{
+		createdAtNanos: s.GetCreatedAt().AsTime().UnixNano(),
+		uuid:           s.GetUuid(),
+	}
+}
+
+// less задает стабильный порядок выдачи: по времени создания, при равенстве - по UUID
+func (c pageCursor) less(other pageCursor) bool {
+	if c.createdAtNanos != other.createdAtNanos {
+		return c.createdAtNanos < other.createdAtNanos
+	}
+	return c.uuid < other.uuid
+}
+
+// offsetCursor позиция в ранжированной выдаче (поиск, ближайшие наблюдения) или в истории.
+// Порядок такой выдачи зависит от всего индекса, поэтому позиция - это смещение,
+// а отпечаток запроса не дает продолжить выдачу по другому запросу
+type offsetCursor struct {
+	offset      int
+	fingerprint uint32
+}
+
+// fingerprint короткий отпечаток параметров запроса для токена страницы
+func fingerprint(data []byte) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write(data)
+	return h.Sum32()
+}
+
+// messageFingerprint отпечаток параметров запроса, заданных сообщением
+func messageFingerprint(m proto.Message) (uint32, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "fingerprint request: %v", err)
+	}
+	return fingerprint(data), nil
+}
+
+func encodeOffsetToken(c offsetCursor) string {
+	raw := strconv.Itoa(c.offset) + "/" + strconv.FormatUint(uint64(c.fingerprint), 16)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeOffsetToken разбирает токен страницы ранжированной выдачи и сверяет его
+// с отпечатком запроса. Пустой токен означает первую страницу
+func decodeOffsetToken(token string, want uint32) (offsetCursor, error) {
+	if token == "" {
+		return offsetCursor{fingerprint: want}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return offsetCursor{}, status.Errorf(codes.InvalidArgument, "invalid page_token: %v", err)
+	}
+
+	rawOffset, rawFingerprint, ok := strings.Cut(string(raw), "/")
+	offset, offsetErr := strconv.Atoi(rawOffset)
+	got, fingerprintErr := strconv.ParseUint(rawFingerprint, 16, 32)
+	if !ok || offsetErr != nil || offset < 0 || fingerprintErr != nil {
+		return offsetCursor{}, status.Errorf(codes.InvalidArgument, "invalid page_token: malformed page token")
+	}
+	if uint32(got) != want {
+		return offsetCursor{}, status.Errorf(codes.InvalidArgument, "page_token belongs to a different request")
+	}
+
+	return offsetCursor{offset: offset, fingerprint: want}, nil
+}
+
+// page возвращает границы страницы в выдаче из total элементов и токен следующей страницы
+func (c offsetCursor) page(total, pageSize int) (start, end int, nextToken string) {
+	start = min(c.offset, total)
+	end = min(start+pageSize, total)
+	if end < total {
+		nextToken = encodeOffsetToken(offsetCursor{offset: end, fingerprint: c.fingerprint})
+	}
+	return start, end, nextToken
+}
+
+// matchesFilter проверяет наблюдение на соответствие всем условиям фильтра
+func matchesFilter(s *ufoV1.Sighting, f *ufoV1.ListFilter) bool {
+	if s.GetDeletedAt() != nil && !f.GetIncludeDeleted() {
+		return false
+	}
+
+	info := s.GetInfo()
+	observedAt := info.GetObservedAt().AsTime()
+
+	if f.GetObservedFrom() != nil && observedAt.Before(f.GetObservedFrom().AsTime()) {
+		return false
+	}
+
+	if f.GetObservedTo() != nil && !observedAt.Before(f.GetObservedTo().AsTime()) {
+		return false
+	}
+
+	if f.GetLocationContains() != "" &&
+		!strings.Contains(strings.ToLower(info.GetLocation()), strings.ToLower(f.GetLocationContains())) {
+		return false
+	}
+
+	if f.GetColor() != nil && (info.GetColor() == nil || !strings.EqualFold(info.GetColor().GetValue(), f.GetColor().GetValue())) {
+		return false
+	}
+
+	if f.GetSound() != nil && (info.GetSound() == nil || !strings.EqualFold(info.GetSound().GetValue(), f.GetSound().GetValue())) {
+		return false
+	}
+
+	if f.GetHasDuration() != nil && (info.GetDurationSeconds() != nil) != f.GetHasDuration().GetValue() {
+		return false
+	}
+
+	if len(f.GetStatuses()) > 0 && !slices.Contains(f.GetStatuses(), s.GetStatus()) {
+		return false
+	}
+
+	return true
+}
+
+func (s *ufoService) List(ctx context.Context, req *ufoV1.ListRequest) (*ufoV1.ListResponse, error) {
+	filterFingerprint, err := messageFingerprint(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	sightings, next, err := s.listMatching(ctx, req.GetPageSize(), req.GetPageToken(), filterFingerprint, func(sighting *ufoV1.Sighting) bool {
+		return matchesFilter(sighting, req.GetFilter())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ufoV1.ListResponse{Sightings: sightings, NextPageToken: next}, nil
+}
+
+// listMatching возвращает страницу наблюдений, подходящих под match, в порядке List
+// и токен следующей страницы. filterFingerprint - отпечаток условий, задающих match
+func (s *ufoService) listMatching(ctx context.Context, size int32, token string, filterFingerprint uint32, match func(*ufoV1.Sighting) bool) ([]*ufoV1.Sighting, string, error) {
+	pageSize, err := normalizePageSize(size)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var after *pageCursor
+	if token != "" {
+		cursor, err := decodePageToken(token)
+		if err != nil {
+			return nil, "", status.Errorf(codes.InvalidArgument, "invalid page_token: %v", err)
+		}
+		if cursor.fingerprint != filterFingerprint {
+			return nil, "", status.Errorf(codes.InvalidArgument, "page_token belongs to a different request")
+		}
+		after = &cursor
+	}
+
+	// В памяти держится только страница и одна запись сверх нее - по ней видно,
+	// что есть следующая страница. page всегда упорядочена
+	limit := pageSize + 1
+	page := make([]*ufoV1.Sighting, 0, limit)
+	err = s.repo.Scan(ctx, func(sighting *ufoV1.Sighting) error {
+		cursor := cursorOf(sighting)
+		if after != nil && !after.less(cursor) {
+			return nil
+		}
+		if !match(sighting) {
+			return nil
+		}
+		i := sort.Search(len(page), func(i int) bool {
+			return cursor.less(cursorOf(page[i]))
+		})
+		if i == limit {
+			return nil
+		}
+		page = slices.Insert(page, i, sighting)
+		if len(page) > limit {
+			page = page[:limit]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", repositoryError(err, "")
+	}
+
+	var next string
+	if len(page) > pageSize {
+		page = page[:pageSize]
+		cursor := cursorOf(page[pageSize-1])
+		cursor.fingerprint = filterFingerprint
+		next = encodePageToken(cursor)
+	}
+
+	return page, next, nil
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository/memory"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	want := pageCursor{createdAtNanos: -1_234_567_890, uuid: "6f1c2b8e-3a4d-4f5e-9b6a-7c8d9e0f1a2b", fingerprint: 0xdeadbeef}
+
+	got, err := decodePageToken(encodePageToken(want))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got != want {
+		t.Errorf("cursor = %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodePageTokenRejectsMalformed(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	for name, token := range map[string]string{
+		"not base64":        "!!!",
+		"padded base64":     base64.URLEncoding.EncodeToString([]byte("1/a/0")),
+		"no separators":     encode("12345"),
+		"no fingerprint":    encode("12345/uuid"),
+		"empty uuid":        encode("12345//0"),
+		"bad timestamp":     encode("soon/uuid/0"),
+		"bad fingerprint":   encode("12345/uuid/zz"),
+		"fingerprint 64bit": encode("12345/uuid/1ffffffff"),
+		"extra part":        encode("12345/uuid/0/1"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if c, err := decodePageToken(token); err == nil {
+				t.Errorf("decodePageToken = %+v, want error", c)
+			}
+		})
+	}
+}
+
+func TestNormalizePageSize(t *testing.T) {
+	tests := []struct {
+		size int32
+		want int
+	}{
+		{0, defaultPageSize},
+		{1, 1},
+		{maxPageSize, maxPageSize},
+		{maxPageSize + 1, maxPageSize},
+		{1 << 30, maxPageSize},
+	}
+	for _, tt := range tests {
+		got, err := normalizePageSize(tt.size)
+		if err != nil || got != tt.want {
+			t.Errorf("normalizePageSize(%d) = %d, %v, want %d", tt.size, got, err, tt.want)
+		}
+	}
+
+	_, err := normalizePageSize(-1)
+	wantCode(t, err, codes.InvalidArgument)
+}
+
+// seedSightings сохраняет n наблюдений с одинаковым временем создания, чтобы
+// порядок между ними определялся только UUID
+func seedSightings(t *testing.T, s *ufoService, n int, createdAt time.Time) {
+	t.Helper()
+
+	for i := range n {
+		sighting := &ufoV1.Sighting{
+			Uuid:      fmt.Sprintf("00000000-0000-4000-8000-%012d", (i*7)%n),
+			Info:      testInfo(fmt.Sprintf("Roswell %d", i), "Silver disc"),
+			Status:    ufoV1.SightingStatus_SIGHTING_STATUS_SUBMITTED,
+			Version:   1,
+			CreatedAt: timestamppb.New(createdAt),
+			UpdatedAt: timestamppb.New(createdAt),
+		}
+		if i%2 == 0 {
+			sighting.Info.Color = wrapperspb.String("green")
+		}
+		if err := s.repo.Create(context.Background(), sighting); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+}
+
+// listAll проходит все страницы List и возвращает UUID в порядке выдачи
+func listAll(t *testing.T, s *ufoService, req *ufoV1.ListRequest, between func()) []string {
+	t.Helper()
+
+	var ids []string
+	for {
+		resp, err := s.List(context.Background(), req)
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		for _, sighting := range resp.GetSightings() {
+			ids = append(ids, sighting.GetUuid())
+		}
+		if resp.GetNextPageToken() == "" {
+			return ids
+		}
+		if between != nil {
+			between()
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+}
+
+func TestListPagesInStableOrder(t *testing.T) {
+	s := newTestService(t, memory.NewRepository())
+	createdAt := time.Date(2026, 7, 9, 0, 0, 0, 0, time.UTC)
+	seedSightings(t, s, 10, createdAt)
+
+	// Более позднее наблюдение и наблюдение, созданное между запросами страниц
+	// раньше курсора, не должны ни сдвинуть, ни повторить выдачу
+	late := &ufoV1.Sighting{
+		Uuid: "ffffffff-0000-4000-8000-000000000000", Info: testInfo("Area 51", "Lights"),
+		Version: 1, CreatedAt: timestamppb.New(createdAt.Add(time.Hour)),
+	}
+	if err := s.repo.Create(context.Background(), late); err != nil {
+		t.Fatal(err)
+	}
+
+	inserted := false
+	got := listAll(t, s, &ufoV1.ListRequest{PageSize: 3}, func() {
+		if inserted {
+			return
+		}
+		inserted = true
+		early := &ufoV1.Sighting{
+			Uuid: "aaaaaaaa-0000-4000-8000-000000000000", Info: testInfo("Phoenix", "Lights"),
+			Version: 1, CreatedAt: timestamppb.New(createdAt.Add(-time.Hour)),
+		}
+		if err := s.repo.Create(context.Background(), early); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	var want []string
+	for i := range 10 {
+		want = append(want, fmt.Sprintf("00000000-0000-4000-8000-%012d", i))
+	}
+	want = append(want, late.Uuid)
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("pages =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestListFilterAcrossPages(t *testing.T) {
+	s := newTestService(t, memory.NewRepository())
+	seedSightings(t, s, 10, time.Date(2026, 7, 9, 0, 0, 0, 0, time.UTC))
+
+	filter := &ufoV1.ListFilter{Color: wrapperspb.String("GREEN")}
+	got := listAll(t, s, &ufoV1.ListRequest{PageSize: 2, Filter: filter}, nil)
+	if len(got) != 5 {
+		t.Errorf("filtered pages returned %d sightings, want 5: %v", len(got), got)
+	}
+}
+
+func TestListRejectsForeignPageToken(t *testing.T) {
+	s := newTestService(t, memory.NewRepository())
+	seedSightings(t, s, 10, time.Date(2026, 7, 9, 0, 0, 0, 0, time.UTC))
+
+	green := &ufoV1.ListFilter{Color: wrapperspb.String("green")}
+	resp, err := s.List(context.Background(), &ufoV1.ListRequest{PageSize: 2, Filter: green})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := resp.GetNextPageToken()
+
+	cursor, err := decodePageToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := cursor
+	tampered.fingerprint++
+
+	tests := map[string]*ufoV1.ListRequest{
+		"other filter":         {PageSize: 2, PageToken: token, Filter: &ufoV1.ListFilter{Color: wrapperspb.String("red")}},
+		"no filter":            {PageSize: 2, PageToken: token},
+		"tampered fingerprint": {PageSize: 2, PageToken: encodePageToken(tampered), Filter: green},
+		"garbage":              {PageSize: 2, PageToken: token + "x", Filter: green},
+	}
+	for name, req := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := s.List(context.Background(), req)
+			wantCode(t, err, codes.InvalidArgument)
+		})
+	}
+
+	// Размер страницы в отпечаток не входит и может меняться между запросами
+	if _, err = s.List(context.Background(), &ufoV1.ListRequest{PageSize: 5, PageToken: token, Filter: green}); err != nil {
+		t.Errorf("same filter, other page size: %v", err)
+	}
+}
+
+// scanOnlyRepo хранилище, которое не отдает все наблюдения списком
+type scanOnlyRepo struct {
+	repository.SightingRepository
+}
+
+func (scanOnlyRepo) List(context.Context) ([]*ufoV1.Sighting, error) {
+	return nil, errInjected
+}
+
+func TestFullPassesUseScan(t *testing.T) {
+	repo := scanOnlyRepo{SightingRepository: memory.NewRepository()}
+	for i := range 5 {
+		sighting := &ufoV1.Sighting{
+			Uuid: fmt.Sprintf("00000000-0000-4000-8000-%012d", i), Info: testInfo("Roswell", "Silver disc"),
+			Version: 1, CreatedAt: timestamppb.New(time.Date(2026, 7, 9, i, 0, 0, 0, time.UTC)),
+		}
+		if i == 4 {
+			sighting.DeletedAt = sighting.GetCreatedAt()
+		}
+		if err := repo.Create(context.Background(), sighting); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Индекс при старте, постраничная выдача и очистка удаленных обходятся без List
+	s := newTestService(t, repo)
+	if s.textIndex.Len() != 4 {
+		t.Errorf("indexed %d sightings, want 4", s.textIndex.Len())
+	}
+	if got := listAll(t, s, &ufoV1.ListRequest{PageSize: 2}, nil); len(got) != 4 {
+		t.Errorf("pages returned %d sightings, want 4: %v", len(got), got)
+	}
+	if purged, err := s.purgeDeletedBefore(context.Background(), time.Now()); err != nil || purged != 1 {
+		t.Errorf("retention purged %d, %v, want 1", purged, err)
+	}
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+)
+
+// newLogger создает структурированный логгер в формате из настроек
+func newLogger(cfg config) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: cfg.logLevel}
+	if cfg.logFormat == logFormatJSON {
+		return slog.New(slog.NewJSONHandler(os.Stderr, opts))
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, opts))
+}
@@ -2,8 +2,12 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
 	"net"
 	"os"
 	"os/signal"
@@ -12,49 +16,155 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/geo"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/interceptor"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/metrics"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/ratelimit"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/search"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/tracing"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/validator"
 	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
-const grpcPort = 50051
+const (
+	grpcPort = 50051
+
+	// gatewayShutdownTimeout сколько ждать завершения HTTP-запросов при остановке
+	gatewayShutdownTimeout = 5 * time.Second
+	// rateLimiterIdle через сколько простоя забывать корзину токенов клиента
+	rateLimiterIdle = 10 * time.Minute
+)
 
 type ufoService struct {
 	ufoV1.UnimplementedUFOServiceServer // Мы копируем все методы интерфейса и будем их сами переопределять
 
-	mu        sync.RWMutex
-	sightings map[string]*ufoV1.Sighting
+	// mu сериализует изменения: чтение-изменение-запись в хранилище и публикация
+	// события выполняются атомарно, поэтому порядок событий совпадает с порядком изменений
+	mu   sync.Mutex
+	repo repository.SightingRepository
+
+	events      *eventHub
+	idempotency *idempotencyStore
+	// textIndex и geoIndex индексы активных наблюдений для Search и FindNearby, меняются под mu
+	textIndex *search.Index
+	geoIndex  *geo.Index
+	// counts число наблюдений для метрик, меняется под mu вместе с индексами
+	counts sightingCounts
+
+	// duplicateWindow насколько могут расходиться observed_at повторных сообщений об одном событии
+	duplicateWindow time.Duration
+	// attachmentLimits ограничения UploadAttachment из конфигурации
+	attachmentLimits attachmentLimits
+	// createQuota суточная квота созданных наблюдений на клиента, nil - без ограничения
+	createQuota *createQuota
 }
 
-func (s *ufoService) Create(_ context.Context, req *ufoV1.CreateRequest) (*ufoV1.CreateResponse, error) {
+func (s *ufoService) Create(ctx context.Context, req *ufoV1.CreateRequest) (*ufoV1.CreateResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	key := idempotencyKeyOf(ctx, req.GetIdempotencyKey())
+	var fingerprint [sha256.Size]byte
+	if key.key != "" {
+		var err error
+		if fingerprint, err = fingerprintOf(req.GetInfo()); err != nil {
+			return nil, status.Errorf(codes.Internal, "fingerprint request: %v", err)
+		}
+
+		resp, err := s.idempotency.lookup(key, fingerprint, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if resp != nil {
+			log.Printf("Replay create with idempotency key %q: uuid %s", key.key, resp.GetUuid())
+			return resp, nil
+		}
+	}
+
+	client, err := s.createQuota.take(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	sighting, err := s.createLocked(ctx, req.GetInfo())
+	if err != nil {
+		s.createQuota.refund(client, 1)
+		return nil, err
+	}
+
+	resp := &ufoV1.CreateResponse{
+		Uuid:               sighting.GetUuid(),
+		Version:            sighting.GetVersion(),
+		PossibleDuplicates: sighting.GetPossibleDuplicates(),
+	}
+	if key.key != "" {
+		s.idempotency.remember(key, fingerprint, resp, time.Now())
+	}
+
+	return resp, nil
+}
+
+// createLocked сохраняет новое наблюдение и оповещает подписчиков, вызывается под s.mu
+func (s *ufoService) createLocked(ctx context.Context, info *ufoV1.SightingInfo) (*ufoV1.Sighting, error) {
+	sighting, err := s.storeLocked(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+
+	s.events.publish(ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_CREATED, sighting)
+	return sighting, nil
+}
+
+// storeLocked сохраняет новое наблюдение без публикации события, вызывается под s.mu
+func (s *ufoService) storeLocked(ctx context.Context, info *ufoV1.SightingInfo) (*ufoV1.Sighting, error) {
 	newUUID := uuid.NewString()
 	sighting := &ufoV1.Sighting{
 		Uuid:      newUUID,
-		Info:      req.GetInfo(),
+		Info:      info,
 		CreatedAt: timestamppb.New(time.Now()),
+		Version:   1,
+		Status:    ufoV1.SightingStatus_SIGHTING_STATUS_SUBMITTED,
+	}
+	if err := s.flagDuplicatesLocked(ctx, sighting); err != nil {
+		return nil, err
 	}
 
-	s.sightings[newUUID] = sighting
+	if err := s.repo.Create(ctx, sighting); err != nil {
+		return nil, repositoryError(err, newUUID)
+	}
+	revision := &ufoV1.Revision{Type: ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_CREATED}
+	if err := s.recordRevisionLocked(ctx, nil, sighting, revision); err != nil {
+		if rerr := s.repo.Delete(context.WithoutCancel(ctx), newUUID); rerr != nil {
+			log.Printf("Failed to roll back sighting %s: %v", newUUID, rerr)
+		}
+		return nil, err
+	}
+	s.indexSighting(sighting)
+	s.counts.move(nil, sighting)
+
 	log.Printf("Create new ufo with uuid: %s", newUUID)
-	return &ufoV1.CreateResponse{
-		Uuid: newUUID,
-	}, nil
+	return sighting, nil
 }
 
-func (s *ufoService) Get(_ context.Context, req *ufoV1.GetRequest) (*ufoV1.GetResponse, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	sighting, ok := s.sightings[req.GetUuid()]
-	if !ok {
-		return nil, status.Errorf(codes.NotFound, "sighing with UUID %s not found", req.GetUuid())
+func (s *ufoService) Get(ctx context.Context, req *ufoV1.GetRequest) (*ufoV1.GetResponse, error) {
+	sighting, err := s.repo.Get(ctx, req.GetUuid())
+	if err != nil {
+		return nil, repositoryError(err, req.GetUuid())
+	}
+	if sighting.GetDeletedAt() != nil && !req.GetIncludeDeleted() {
+		return nil, status.Errorf(codes.NotFound, "sighting with UUID %s not found", req.GetUuid())
 	}
 
 	return &ufoV1.GetResponse{
@@ -62,60 +172,225 @@ func (s *ufoService) Get(_ context.Context, req *ufoV1.GetRequest) (*ufoV1.GetRe
 	}, nil
 }
 
-func (s *ufoService) Update(_ context.Context, req *ufoV1.UpdateRequest) (*emptypb.Empty, error) {
+func (s *ufoService) Update(ctx context.Context, req *ufoV1.UpdateRequest) (*ufoV1.UpdateResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	sighting, ok := s.sightings[req.GetUuid()]
-	if !ok {
-		return nil, status.Errorf(codes.NotFound, "sighing with UUID %s not found", req.GetUuid())
-	}
-	if req.UpdateInfo == nil {
-		return nil, status.Errorf(codes.InvalidArgument, "UpdateInfo is nil")
+	paths, err := updatePaths(req)
+	if err != nil {
+		return nil, err
 	}
 
-	if req.GetUpdateInfo().ObservedAt != nil {
-		sighting.Info.ObservedAt = req.UpdateInfo.GetObservedAt()
+	sighting, err := s.activeSightingLocked(ctx, req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if err = checkVersion(sighting, req.GetExpectedVersion()); err != nil {
+		return nil, err
 	}
 
-	if req.GetUpdateInfo().Location != nil {
-		sighting.Info.Location = req.UpdateInfo.Location.Value
+	if err = applyUpdate(sighting.GetInfo(), req.GetUpdateInfo(), paths); err != nil {
+		return nil, err
 	}
 
-	if req.GetUpdateInfo().Description != nil {
-		sighting.Info.Description = req.GetUpdateInfo().Description.Value
+	sighting.UpdatedAt = timestamppb.New(time.Now())
+	if err = s.saveLocked(ctx, sighting, ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_UPDATED); err != nil {
+		return nil, err
 	}
 
-	if req.GetUpdateInfo().Color != nil {
-		sighting.Info.Color = req.GetUpdateInfo().Color
+	return &ufoV1.UpdateResponse{Version: sighting.GetVersion()}, nil
+}
+
+func (s *ufoService) Delete(ctx context.Context, req *ufoV1.DeleteRequest) (*emptypb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sighting, err := s.activeSightingLocked(ctx, req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if err = checkVersion(sighting, req.GetExpectedVersion()); err != nil {
+		return nil, err
 	}
 
-	if req.GetUpdateInfo().Sound != nil {
-		sighting.Info.Sound = req.GetUpdateInfo().Sound
+	sighting.DeletedAt = timestamppb.New(time.Now())
+	if err = s.saveLocked(ctx, sighting, ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_DELETED); err != nil {
+		return nil, err
 	}
 
-	if req.GetUpdateInfo().DurationSeconds != nil {
-		sighting.Info.DurationSeconds = req.GetUpdateInfo().DurationSeconds
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ufoService) Restore(ctx context.Context, req *ufoV1.RestoreRequest) (*emptypb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sighting, err := s.repo.Get(ctx, req.GetUuid())
+	if err != nil {
+		return nil, repositoryError(err, req.GetUuid())
+	}
+	if sighting.GetDeletedAt() == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "sighting with UUID %s is not deleted", req.GetUuid())
+	}
+	if sighting.GetMergedInto() != "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "sighting with UUID %s is merged into %s", req.GetUuid(), sighting.GetMergedInto())
+	}
+	if err = checkVersion(sighting, req.GetExpectedVersion()); err != nil {
+		return nil, err
 	}
 
+	sighting.DeletedAt = nil
 	sighting.UpdatedAt = timestamppb.New(time.Now())
+	if err = s.saveLocked(ctx, sighting, ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_RESTORED); err != nil {
+		return nil, err
+	}
+
 	return &emptypb.Empty{}, nil
 }
 
-func (s *ufoService) Delete(_ context.Context, req *ufoV1.DeleteRequest) (*emptypb.Empty, error) {
+func (s *ufoService) Purge(ctx context.Context, req *ufoV1.PurgeRequest) (*emptypb.Empty, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	sighting, ok := s.sightings[req.GetUuid()]
-	if !ok {
-		return nil, status.Errorf(codes.NotFound, "sighing with UUID %s not found", req.GetUuid())
+	sighting, err := s.repo.Get(ctx, req.GetUuid())
+	if err != nil {
+		return nil, repositoryError(err, req.GetUuid())
+	}
+	// Безвозвратно удаляем только то, что уже прошло мягкое удаление
+	if sighting.GetDeletedAt() == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "sighting with UUID %s must be deleted before purge", req.GetUuid())
+	}
+	// Как и при очистке по сроку хранения, объединенные повторы остаются: на них ссылается merged_from
+	if sighting.GetMergedInto() != "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "sighting with UUID %s is merged into %s and cannot be purged", req.GetUuid(), sighting.GetMergedInto())
 	}
 
-	sighting.DeletedAt = timestamppb.New(time.Now())
+	if err = s.purgeLocked(ctx, sighting); err != nil {
+		return nil, err
+	}
 	return &emptypb.Empty{}, nil
 }
 
+// activeSightingLocked возвращает неудаленное наблюдение, удаленные для клиента не существуют
+func (s *ufoService) activeSightingLocked(ctx context.Context, id string) (*ufoV1.Sighting, error) {
+	return s.activeSighting(ctx, id)
+}
+
+// activeSighting то же, что activeSightingLocked, для чтения без s.mu: результат
+// может устареть сразу после возврата, поэтому для изменений он не годится
+func (s *ufoService) activeSighting(ctx context.Context, id string) (*ufoV1.Sighting, error) {
+	sighting, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, repositoryError(err, id)
+	}
+	if sighting.GetDeletedAt() != nil {
+		return nil, status.Errorf(codes.NotFound, "sighting with UUID %s not found", id)
+	}
+	return sighting, nil
+}
+
+// saveLocked сохраняет измененное наблюдение со следующей версией и оповещает подписчиков
+func (s *ufoService) saveLocked(ctx context.Context, sighting *ufoV1.Sighting, eventType ufoV1.SightingEventType) error {
+	return s.commitLocked(ctx, sighting, &ufoV1.Revision{Type: eventType})
+}
+
+// commitLocked то же, что saveLocked, но вид изменения для истории и событий задает revision
+func (s *ufoService) commitLocked(ctx context.Context, sighting *ufoV1.Sighting, revision *ufoV1.Revision) error {
+	previous, err := s.repo.Get(ctx, sighting.GetUuid())
+	if err != nil {
+		return repositoryError(err, sighting.GetUuid())
+	}
+
+	sighting.Version++
+	if err = s.repo.Update(ctx, sighting); err != nil {
+		return repositoryError(err, sighting.GetUuid())
+	}
+	// Изменение без ревизии выпало бы из истории, поэтому без нее откатываем и его
+	if err = s.recordRevisionLocked(ctx, previous, sighting, revision); err != nil {
+		if rerr := s.repo.Update(context.WithoutCancel(ctx), previous); rerr != nil {
+			log.Printf("Failed to roll back sighting %s: %v", sighting.GetUuid(), rerr)
+		}
+		return err
+	}
+	s.indexSighting(sighting)
+	s.counts.move(previous, sighting)
+
+	s.events.publish(revision.GetType(), sighting)
+	return nil
+}
+
+// checkVersion сверяет версию записи с той, что видел клиент. Проверка выполняется
+// под s.mu вместе с записью, поэтому между ними никто не успеет изменить наблюдение
+func checkVersion(sighting *ufoV1.Sighting, expected *wrapperspb.Int64Value) error {
+	if expected == nil || expected.GetValue() == sighting.GetVersion() {
+		return nil
+	}
+	return status.Errorf(codes.Aborted, "sighting with UUID %s has version %d, expected %d: reload it and retry",
+		sighting.GetUuid(), sighting.GetVersion(), expected.GetValue())
+}
+
+func (s *ufoService) purgeLocked(ctx context.Context, sighting *ufoV1.Sighting) error {
+	if err := s.repo.Delete(ctx, sighting.GetUuid()); err != nil {
+		return repositoryError(err, sighting.GetUuid())
+	}
+	s.unindexSighting(sighting.GetUuid())
+	s.counts.move(sighting, nil)
+	s.deleteAttachmentsLocked(ctx, sighting)
+	// История уходит вместе с наблюдением: после очистки по сроку хранения данных не остается
+	if err := s.repo.DeleteRevisions(ctx, sighting.GetUuid()); err != nil {
+		log.Printf("Failed to delete history of sighting %s: %v", sighting.GetUuid(), err)
+	}
+
+	s.events.publish(ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_PURGED, sighting)
+	log.Printf("Purge ufo with uuid: %s", sighting.GetUuid())
+	return nil
+}
+
+// repositoryError переводит ошибку хранилища в gRPC-статус
+func repositoryError(err error, id string) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return status.Errorf(codes.NotFound, "sighting with UUID %s not found", id)
+	case errors.Is(err, repository.ErrAlreadyExists):
+		return status.Errorf(codes.AlreadyExists, "sighting with UUID %s already exists", id)
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		log.Printf("Storage error for sighting %s: %v", id, err)
+		return status.Errorf(codes.Internal, "storage error")
+	}
+}
+
 func main() {
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Printf("Failed to load config: %v\n", err)
+		return
+	}
+
+	logger := newLogger(cfg)
+	// Остальные log.Printf сервера тоже идут через slog в том же формате
+	slog.SetDefault(logger)
+
+	// Правило, которое валидатор не умеет проверять, выглядело бы действующим
+	if err = validator.CheckRules(ufoV1.File_ufo_v1_ufo_proto); err != nil {
+		log.Printf("Unsupported validation rules in ufo.proto: %v\n", err)
+		return
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), "ufo-server", cfg.traceExporter)
+	if err != nil {
+		log.Printf("Failed to set up tracing: %v\n", err)
+		return
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), gatewayShutdownTimeout)
+		defer cancel()
+		if serr := shutdownTracing(ctx); serr != nil {
+			log.Printf("Failed to flush traces: %v\n", serr)
+		}
+	}()
+
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", grpcPort))
 	if err != nil {
 		log.Printf("Failed to listen: %v\n", err)
@@ -127,31 +402,176 @@ func main() {
 		}
 	}()
 
-	s := grpc.NewServer()
+	repo, err := newRepository(cfg)
+	if err != nil {
+		log.Printf("Failed to open storage: %v\n", err)
+		return
+	}
+	defer func() {
+		if cerr := repo.Close(); cerr != nil {
+			log.Printf("Failed to close storage: %v\n", cerr)
+		}
+	}()
+
+	// Без сертификата сервер, как и раньше, работает без шифрования
+	serverCreds, gatewayCreds := insecure.NewCredentials(), insecure.NewCredentials()
+	var gatewayTLS *tls.Config
+	tlsReloader, err := newTLSReloader(cfg)
+	if err != nil {
+		log.Printf("Failed to load TLS files: %v\n", err)
+		return
+	}
+	gatewayReloader, err := newGatewayTLSReloader(cfg)
+	if err != nil {
+		log.Printf("Failed to load REST gateway TLS files: %v\n", err)
+		return
+	}
+	if tlsReloader != nil {
+		serverTLS, err := tlsReloader.ServerConfig(cfg.requireClientCert)
+		if err != nil {
+			log.Printf("Failed to configure TLS: %v\n", err)
+			return
+		}
+		serverCreds = credentials.NewTLS(serverTLS)
+		gatewayCreds = credentials.NewTLS(tlsReloader.LoopbackConfig(gatewayReloader))
+		// REST-шлюз требует клиентский сертификат так же, как gRPC, иначе mTLS можно обойти через него
+		gatewayTLS = serverTLS
+	}
 
 	service := &ufoService{
-		sightings: make(map[string]*ufoV1.Sighting),
+		repo:            repo,
+		events:          newEventHub(),
+		idempotency:     newIdempotencyStore(cfg.idempotencyTTL),
+		textIndex:       search.NewIndex(),
+		geoIndex:        geo.NewIndex(),
+		duplicateWindow: cfg.duplicateWindow,
+		createQuota:     newCreateQuota(cfg.createDailyQuota),
+		attachmentLimits: attachmentLimits{
+			maxBytes: int64(cfg.attachmentMaxBytes),
+			types:    cfg.attachmentTypes,
+			budget:   newUploadBudget(int64(cfg.uploadBudgetBytes)),
+		},
+	}
+	if err = service.buildIndex(context.Background()); err != nil {
+		log.Printf("Failed to build search index: %v\n", err)
+		return
 	}
 
+	metricsRegistry := newMetricsRegistry(service)
+	grpcMetrics := metrics.NewGRPC(metricsRegistry)
+
+	authn, err := newAuthenticator(cfg)
+	if err != nil {
+		log.Printf("Failed to configure authentication: %v\n", err)
+		return
+	}
+
+	// Порядок важен: идентификатор запроса нужен логам, логгер должен увидеть код Internal
+	// после восстановления от паники, а валидация выполняется уже внутри дедлайна
+	// и только для аутентифицированных вызовов
+	unary := []grpc.UnaryServerInterceptor{
+		interceptor.UnaryRequestID(),
+		interceptor.UnaryMetrics(grpcMetrics),
+		interceptor.UnaryLogger(logger),
+		interceptor.UnaryRecovery(logger),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		interceptor.StreamRequestID(),
+		interceptor.StreamMetrics(grpcMetrics),
+		interceptor.StreamLogger(logger),
+		interceptor.StreamRecovery(logger),
+	}
+	if authn != nil {
+		unary = append(unary, interceptor.UnaryAuth(logger, authn, accessPolicy))
+		stream = append(stream, interceptor.StreamAuth(logger, authn, accessPolicy))
+		// Контакты очевидцев скрываются от ролей без доступа к ним во всех ответах, включая Watch
+		unary = append(unary, interceptor.UnaryRedact(redactResponse))
+		stream = append(stream, interceptor.StreamRedact(redactResponse))
+	}
+	limiter := ratelimit.NewLimiter(methodLimits(cfg.rateLimits))
+	unary = append(unary,
+		interceptor.UnaryRateLimit(limiter),
+		interceptor.UnaryDeadline(cfg.requestTimeout, cfg.maxTimeout),
+		interceptor.UnaryValidator(),
+	)
+	stream = append(stream,
+		interceptor.StreamRateLimit(limiter),
+		interceptor.StreamDeadline(cfg.streamTimeout,
+			ufoV1.UFOService_ImportSightings_FullMethodName,
+			ufoV1.UFOService_UploadAttachment_FullMethodName,
+			ufoV1.UFOService_DownloadAttachment_FullMethodName,
+		),
+		interceptor.StreamValidator(),
+	)
+
+	s := grpc.NewServer(
+		grpc.Creds(serverCreds),
+		// Спан на каждый вызов с родителем из метаданных traceparent клиента
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+
 	ufoV1.RegisterUFOServiceServer(s, service)
 
+	// Пока хранилище не проверено, сервер не готов принимать запросы
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus(ufoV1.UFOService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(s, healthServer)
+
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	go service.runRetention(backgroundCtx, cfg.deletedRetention, cfg.retentionInterval)
+	go service.idempotency.run(backgroundCtx)
+	go runHealthCheck(backgroundCtx, healthServer, service, cfg.healthInterval)
+	go limiter.Run(backgroundCtx, rateLimiterIdle)
+	if tlsReloader != nil {
+		go tlsReloader.Run(backgroundCtx, cfg.tlsReloadInterval)
+	}
+	if gatewayReloader != nil {
+		go gatewayReloader.Run(backgroundCtx, cfg.tlsReloadInterval)
+	}
+
 	// Рефлексия - это возможность клиента спрашивать какие есть методы у сервера
 	// из-за этого в постмане можно сразу увидеть список методов
 	reflection.Register(s)
 
+	// Шлюз подключается к gRPC-серверу лениво, поэтому его можно создать до Serve
+	gw, err := newGateway(backgroundCtx, cfg.httpAddr, fmt.Sprintf("localhost:%d", grpcPort), gatewayCreds, gatewayTLS)
+	if err != nil {
+		log.Printf("Failed to create gateway: %v\n", err)
+		return
+	}
+
 	go func() {
 		log.Printf("🚀 Starting gRPC server on port %d\n", grpcPort)
-		err = s.Serve(lis)
-		if err != nil {
+		if err := s.Serve(lis); err != nil {
 			log.Printf("Failed to serve: %v\n", err)
 			return
 		}
 	}()
+	go gw.serve()
+
+	metricsServer := newMetricsServer(cfg.metricsAddr, metricsRegistry)
+	go serveMetrics(metricsServer)
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("🛑 Shutting down gRPC server...")
+	// Сначала сообщаем балансировщику, что новых запросов слать не нужно.
+	// Shutdown выставляет NOT_SERVING всем сервисам и игнорирует дальнейшие обновления
+	healthServer.Shutdown()
+	// Watch-стримы сами не завершаются, поэтому отключаем подписчиков до GracefulStop
+	service.events.close()
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), gatewayShutdownTimeout)
+	defer cancelShutdown()
+	gw.shutdown(shutdownCtx)
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shut down metrics server: %v\n", err)
+	}
 	s.GracefulStop()
 	log.Println("✅ Server stopped")
 }
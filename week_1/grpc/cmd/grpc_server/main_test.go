@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// mustDelete мягко удаляет наблюдение
+func mustDelete(t *testing.T, s *ufoService, id string) {
+	t.Helper()
+
+	if _, err := s.Delete(context.Background(), &ufoV1.DeleteRequest{Uuid: id}); err != nil {
+		t.Fatalf("delete %s: %v", id, err)
+	}
+}
+
+func TestDeletedSightingIsHidden(t *testing.T) {
+	s := newTestService(t, nil)
+	ctx := context.Background()
+	deleted := mustCreate(t, s, testInfo("Roswell", "silver disc"))
+	active := mustCreate(t, s, testInfo("Phoenix", "lights"))
+	mustDelete(t, s, deleted)
+
+	_, err := s.Get(ctx, &ufoV1.GetRequest{Uuid: deleted})
+	wantCode(t, err, codes.NotFound)
+	if got := mustGet(t, s, deleted); got.GetDeletedAt() == nil {
+		t.Errorf("include_deleted returned %v without deleted_at", got)
+	}
+
+	_, err = s.Update(ctx, &ufoV1.UpdateRequest{
+		Uuid:       deleted,
+		UpdateInfo: &ufoV1.SightingUpdateInfo{Location: wrapperspb.String("Area 51")},
+	})
+	wantCode(t, err, codes.NotFound)
+	_, err = s.Delete(ctx, &ufoV1.DeleteRequest{Uuid: deleted})
+	wantCode(t, err, codes.NotFound)
+
+	if got := listAll(t, s, &ufoV1.ListRequest{}, nil); !slices.Equal(got, []string{active}) {
+		t.Errorf("list = %v, want only %s", got, active)
+	}
+	got := listAll(t, s, &ufoV1.ListRequest{Filter: &ufoV1.ListFilter{IncludeDeleted: true}}, nil)
+	if len(got) != 2 || !slices.Contains(got, deleted) {
+		t.Errorf("list with include_deleted = %v, want both sightings", got)
+	}
+}
+
+func TestRestoreClearsDeletedAt(t *testing.T) {
+	s := newTestService(t, nil)
+	ctx := context.Background()
+	id := mustCreate(t, s, testInfo("Roswell", "silver disc"))
+
+	_, err := s.Restore(ctx, &ufoV1.RestoreRequest{Uuid: id})
+	wantCode(t, err, codes.FailedPrecondition)
+
+	mustDelete(t, s, id)
+	if _, err = s.Restore(ctx, &ufoV1.RestoreRequest{Uuid: id}); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+
+	resp, err := s.Get(ctx, &ufoV1.GetRequest{Uuid: id})
+	if err != nil {
+		t.Fatalf("get restored: %v", err)
+	}
+	if got := resp.GetSighting(); got.GetDeletedAt() != nil || got.GetUpdatedAt() == nil {
+		t.Errorf("restored sighting = %v, want no deleted_at and a fresh updated_at", got)
+	}
+
+	_, err = s.Restore(ctx, &ufoV1.RestoreRequest{Uuid: "00000000-0000-4000-8000-000000000000"})
+	wantCode(t, err, codes.NotFound)
+}
+
+func TestPurgeRemovesOnlyDeleted(t *testing.T) {
+	s := newTestService(t, nil)
+	ctx := context.Background()
+	id := mustCreate(t, s, testInfo("Roswell", "silver disc"))
+
+	_, err := s.Purge(ctx, &ufoV1.PurgeRequest{Uuid: id})
+	wantCode(t, err, codes.FailedPrecondition)
+	mustGet(t, s, id)
+
+	mustDelete(t, s, id)
+	if _, err = s.Purge(ctx, &ufoV1.PurgeRequest{Uuid: id}); err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	_, err = s.Get(ctx, &ufoV1.GetRequest{Uuid: id, IncludeDeleted: true})
+	wantCode(t, err, codes.NotFound)
+	_, err = s.Restore(ctx, &ufoV1.RestoreRequest{Uuid: id})
+	wantCode(t, err, codes.NotFound)
+	_, err = s.Purge(ctx, &ufoV1.PurgeRequest{Uuid: id})
+	wantCode(t, err, codes.NotFound)
+}
+
+// deleteAt мягко удаляет наблюдение так, будто это произошло в момент at
+func deleteAt(t *testing.T, s *ufoService, id string, at time.Time) {
+	t.Helper()
+
+	mustDelete(t, s, id)
+	sighting := mustGet(t, s, id)
+	sighting.DeletedAt = timestamppb.New(at)
+	if err := s.repo.Update(context.Background(), sighting); err != nil {
+		t.Fatalf("backdate %s: %v", id, err)
+	}
+}
+
+func TestRetentionPurgesOnlyExpired(t *testing.T) {
+	s := newTestService(t, nil)
+	const retention = 24 * time.Hour
+	now := time.Now()
+
+	expired := mustCreate(t, s, testInfo("Roswell", "deleted long ago"))
+	deleteAt(t, s, expired, now.Add(-retention-time.Minute))
+	recent := mustCreate(t, s, testInfo("Phoenix", "deleted recently"))
+	deleteAt(t, s, recent, now.Add(-retention+time.Hour))
+	active := mustCreate(t, s, testInfo("Kecksburg", "never deleted"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.runRetention(ctx, retention, time.Millisecond)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	waitFor(t, func() bool {
+		_, err := s.repo.Get(context.Background(), expired)
+		return err != nil
+	})
+	_, err := s.Get(context.Background(), &ufoV1.GetRequest{Uuid: expired, IncludeDeleted: true})
+	wantCode(t, err, codes.NotFound)
+	if got := mustGet(t, s, recent); got.GetDeletedAt() == nil {
+		t.Errorf("recently deleted sighting = %v", got)
+	}
+	mustGet(t, s, active)
+}
+
+func TestPurgeKeepsMergedDuplicates(t *testing.T) {
+	s := newTestService(t, nil)
+	ctx := context.Background()
+
+	canonical := mustCreate(t, s, testInfo("Roswell", "silver disc"))
+	duplicate := mustCreate(t, s, testInfo("Roswell", "silver disc again"))
+	if _, err := s.MergeSightings(ctx, &ufoV1.MergeSightingsRequest{CanonicalUuid: canonical, DuplicateUuids: []string{duplicate}}); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+
+	_, err := s.Purge(ctx, &ufoV1.PurgeRequest{Uuid: duplicate})
+	wantCode(t, err, codes.FailedPrecondition)
+	mustGet(t, s, duplicate)
+}
+
+func TestOptimisticConcurrency(t *testing.T) {
+	s := newTestService(t, nil)
+	ctx := context.Background()
+	id := mustCreate(t, s, testInfo("Roswell", "silver disc"))
+	update := func(version *wrapperspb.Int64Value) error {
+		_, err := s.Update(ctx, &ufoV1.UpdateRequest{
+			Uuid:            id,
+			UpdateInfo:      &ufoV1.SightingUpdateInfo{Description: wrapperspb.String("disc")},
+			ExpectedVersion: version,
+		})
+		return err
+	}
+
+	// Каждая запись увеличивает версию ровно на единицу
+	if err := update(wrapperspb.Int64(1)); err != nil {
+		t.Fatalf("update v1: %v", err)
+	}
+	if v := mustGet(t, s, id).GetVersion(); v != 2 {
+		t.Fatalf("version after update = %d, want 2", v)
+	}
+
+	// Устаревшая версия отклоняется и ничего не меняет
+	wantCode(t, update(wrapperspb.Int64(1)), codes.Aborted)
+	_, err := s.Delete(ctx, &ufoV1.DeleteRequest{Uuid: id, ExpectedVersion: wrapperspb.Int64(1)})
+	wantCode(t, err, codes.Aborted)
+	if got := mustGet(t, s, id); got.GetVersion() != 2 || got.GetDeletedAt() != nil {
+		t.Fatalf("rejected writes changed the sighting: %v", got)
+	}
+
+	// Без expected_version проверки нет
+	if err = update(nil); err != nil {
+		t.Fatalf("update without version: %v", err)
+	}
+	if _, err = s.Delete(ctx, &ufoV1.DeleteRequest{Uuid: id, ExpectedVersion: wrapperspb.Int64(3)}); err != nil {
+		t.Fatalf("delete v3: %v", err)
+	}
+
+	_, err = s.Restore(ctx, &ufoV1.RestoreRequest{Uuid: id, ExpectedVersion: wrapperspb.Int64(3)})
+	wantCode(t, err, codes.Aborted)
+	if _, err = s.Restore(ctx, &ufoV1.RestoreRequest{Uuid: id, ExpectedVersion: wrapperspb.Int64(4)}); err != nil {
+		t.Fatalf("restore v4: %v", err)
+	}
+	if _, err = s.Delete(ctx, &ufoV1.DeleteRequest{Uuid: id}); err != nil {
+		t.Fatalf("delete without version: %v", err)
+	}
+	if v := mustGet(t, s, id).GetVersion(); v != 6 {
+		t.Errorf("version after five writes = %d, want 6", v)
+	}
+}
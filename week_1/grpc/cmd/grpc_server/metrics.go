@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+)
+
+var (
+	sightingsDesc = prometheus.NewDesc(
+		"ufo_sightings",
+		"Number of stored sightings by state.",
+		[]string{"state"}, nil,
+	)
+	watchSubscribersDesc = prometheus.NewDesc(
+		"ufo_watch_subscribers",
+		"Number of active Watch subscribers.",
+		nil, nil,
+	)
+)
+
+// sightingCounts число активных и удаленных наблюдений в хранилище. Считается
+// при старте в buildIndex и дальше меняется под s.mu вместе с индексами,
+// а читается при сборе метрик без блокировки
+type sightingCounts struct {
+	active  atomic.Int64
+	deleted atomic.Int64
+}
+
+// move учитывает переход наблюдения из previous в current:
+// previous nil при создании, current nil при безвозвратном удалении
+func (c *sightingCounts) move(previous, current *ufoV1.Sighting) {
+	c.add(previous, -1)
+	c.add(current, 1)
+}
+
+func (c *sightingCounts) add(sighting *ufoV1.Sighting, delta int64) {
+	switch {
+	case sighting == nil:
+	case sighting.GetDeletedAt() != nil:
+		c.deleted.Add(delta)
+	default:
+		c.active.Add(delta)
+	}
+}
+
+// domainCollector отдает доменные показатели, которые сервис поддерживает сам:
+// сбор метрик не обходит хранилище, сколько бы в нем ни было наблюдений
+type domainCollector struct {
+	service *ufoService
+}
+
+func (c domainCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sightingsDesc
+	ch <- watchSubscribersDesc
+}
+
+func (c domainCollector) Collect(ch chan<- prometheus.Metric) {
+	counts := &c.service.counts
+	ch <- prometheus.MustNewConstMetric(watchSubscribersDesc, prometheus.GaugeValue, float64(c.service.events.subscriberCount()))
+	ch <- prometheus.MustNewConstMetric(sightingsDesc, prometheus.GaugeValue, float64(counts.active.Load()), "active")
+	ch <- prometheus.MustNewConstMetric(sightingsDesc, prometheus.GaugeValue, float64(counts.deleted.Load()), "deleted")
+}
+
+// newMetricsRegistry создает реестр с метриками процесса и доменными метриками сервиса
+func newMetricsRegistry(service *ufoService) *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		domainCollector{service: service},
+	)
+	return reg
+}
+
+// serveMetrics отдает /metrics на отдельном порту, чтобы не открывать его вместе с API
+func serveMetrics(server *http.Server) {
+	log.Printf("📈 Serving metrics on %s/metrics\n", server.Addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("Failed to serve metrics: %v\n", err)
+	}
+}
+
+func newMetricsServer(addr string, reg *prometheus.Registry) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}))
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: gatewayReadHeaderTimeout,
+	}
+}
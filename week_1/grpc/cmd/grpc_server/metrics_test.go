@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository/memory"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// sightingGauges возвращает значения ufo_sightings по состояниям из реестра метрик
+func sightingGauges(t *testing.T, s *ufoService) map[string]float64 {
+	t.Helper()
+
+	families, err := newMetricsRegistry(s).Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+	gauges := make(map[string]float64)
+	for _, family := range families {
+		if family.GetName() != "ufo_sightings" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "state" {
+					gauges[label.GetValue()] = m.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	return gauges
+}
+
+// checkCounts сверяет метрики и с ожидаемыми числами, и с полным обходом хранилища
+func checkCounts(t *testing.T, s *ufoService, step string, active, deleted int) {
+	t.Helper()
+
+	var stored [2]int
+	err := s.repo.Scan(context.Background(), func(sighting *ufoV1.Sighting) error {
+		if sighting.GetDeletedAt() != nil {
+			stored[1]++
+		} else {
+			stored[0]++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%s: scan: %v", step, err)
+	}
+	if stored != [2]int{active, deleted} {
+		t.Fatalf("%s: storage has %d active and %d deleted, want %d and %d", step, stored[0], stored[1], active, deleted)
+	}
+
+	gauges := sightingGauges(t, s)
+	if gauges["active"] != float64(active) || gauges["deleted"] != float64(deleted) {
+		t.Errorf("%s: ufo_sightings = %v, want active %d, deleted %d", step, gauges, active, deleted)
+	}
+}
+
+func TestSightingCountsAtStartup(t *testing.T) {
+	// Наблюдения, оставшиеся в хранилище с прошлого запуска
+	repo := memory.NewRepository()
+	for i, deleted := range []bool{false, true, false} {
+		sighting := &ufoV1.Sighting{Uuid: string(rune('a' + i)), Info: testInfo("Roswell", "disc"), Version: 1}
+		if deleted {
+			sighting.DeletedAt = timestamppb.Now()
+		}
+		if err := repo.Create(context.Background(), sighting); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := newTestService(t, repo)
+	checkCounts(t, s, "startup", 2, 1)
+}
+
+func TestSightingCountsFollowChanges(t *testing.T) {
+	repo := &faultyRepo{SightingRepository: memory.NewRepository()}
+	s := newTestService(t, repo)
+	ctx := context.Background()
+	checkCounts(t, s, "empty", 0, 0)
+
+	first := mustCreate(t, s, testInfo("Roswell", "disc"))
+	second := mustCreate(t, s, testInfo("Phoenix", "lights"))
+	checkCounts(t, s, "create", 2, 0)
+
+	if _, err := s.Delete(ctx, &ufoV1.DeleteRequest{Uuid: first}); err != nil {
+		t.Fatal(err)
+	}
+	checkCounts(t, s, "delete", 1, 1)
+
+	if _, err := s.Restore(ctx, &ufoV1.RestoreRequest{Uuid: first}); err != nil {
+		t.Fatal(err)
+	}
+	checkCounts(t, s, "restore", 2, 0)
+
+	for _, id := range []string{first, second} {
+		if _, err := s.Delete(ctx, &ufoV1.DeleteRequest{Uuid: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Purge(ctx, &ufoV1.PurgeRequest{Uuid: first}); err != nil {
+		t.Fatal(err)
+	}
+	checkCounts(t, s, "purge", 0, 1)
+
+	if purged, err := s.purgeDeletedBefore(ctx, time.Now().Add(time.Hour)); err != nil || purged != 1 {
+		t.Fatalf("retention purged %d, %v, want 1", purged, err)
+	}
+	checkCounts(t, s, "retention", 0, 0)
+
+	// Отклоненные изменения счетчики не трогают
+	repo.failAppendRevision = func(*ufoV1.Revision) bool { return true }
+	_, err := s.Create(ctx, &ufoV1.CreateRequest{Info: testInfo("Roswell", "disc")})
+	wantCode(t, err, codes.Internal)
+	checkCounts(t, s, "failed create", 0, 0)
+
+	repo.failAppendRevision = nil
+	third := mustCreate(t, s, testInfo("Rendlesham", "lights"))
+	repo.failAppendRevision = func(*ufoV1.Revision) bool { return true }
+	_, err = s.Delete(ctx, &ufoV1.DeleteRequest{Uuid: third})
+	wantCode(t, err, codes.Internal)
+	checkCounts(t, s, "failed delete", 1, 0)
+
+	// Атомарный импорт откатывает уже сохраненные записи
+	var revisions int
+	repo.failAppendRevision = func(*ufoV1.Revision) bool {
+		revisions++
+		return revisions == 2
+	}
+	err = s.ImportSightings(importStream(true, testInfo("Roswell", "first"), testInfo("Phoenix", "second")))
+	wantCode(t, err, codes.Internal)
+	checkCounts(t, s, "failed import", 1, 0)
+}
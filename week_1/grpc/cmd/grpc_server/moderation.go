@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"log"
+	"slices"
+	"time"
+
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// statusTransitions допустимые переходы между статусами модерации.
+// Из окончательных статусов можно вернуться только на повторную проверку
+var statusTransitions = map[ufoV1.SightingStatus][]ufoV1.SightingStatus{
+	ufoV1.SightingStatus_SIGHTING_STATUS_SUBMITTED: {
+		ufoV1.SightingStatus_SIGHTING_STATUS_UNDER_REVIEW,
+		ufoV1.SightingStatus_SIGHTING_STATUS_REJECTED,
+	},
+	ufoV1.SightingStatus_SIGHTING_STATUS_UNDER_REVIEW: {
+		ufoV1.SightingStatus_SIGHTING_STATUS_SUBMITTED,
+		ufoV1.SightingStatus_SIGHTING_STATUS_VERIFIED,
+		ufoV1.SightingStatus_SIGHTING_STATUS_REJECTED,
+		ufoV1.SightingStatus_SIGHTING_STATUS_HOAX,
+	},
+	ufoV1.SightingStatus_SIGHTING_STATUS_VERIFIED: {
+		ufoV1.SightingStatus_SIGHTING_STATUS_UNDER_REVIEW,
+		ufoV1.SightingStatus_SIGHTING_STATUS_HOAX,
+	},
+	ufoV1.SightingStatus_SIGHTING_STATUS_REJECTED: {
+		ufoV1.SightingStatus_SIGHTING_STATUS_UNDER_REVIEW,
+	},
+	ufoV1.SightingStatus_SIGHTING_STATUS_HOAX: {
+		ufoV1.SightingStatus_SIGHTING_STATUS_UNDER_REVIEW,
+	},
+}
+
+// noteRequired статусы, переход в которые нужно объяснить в заметке
+var noteRequired = []ufoV1.SightingStatus{
+	ufoV1.SightingStatus_SIGHTING_STATUS_REJECTED,
+	ufoV1.SightingStatus_SIGHTING_STATUS_HOAX,
+}
+
+// checkTransition проверяет, что переход from -> to разрешен
+func checkTransition(uuid string, from, to ufoV1.SightingStatus, note string) error {
+	allowed := statusTransitions[from]
+	if !slices.Contains(allowed, to) {
+		return status.Errorf(codes.FailedPrecondition, "sighting with UUID %s cannot move from %s to %s, allowed: %v", uuid, from, to, allowed)
+	}
+	if note == "" && slices.Contains(noteRequired, to) {
+		return status.Errorf(codes.FailedPrecondition, "note is required to move sighting to %s", to)
+	}
+	return nil
+}
+
+func (s *ufoService) TransitionStatus(ctx context.Context, req *ufoV1.TransitionStatusRequest) (*ufoV1.TransitionStatusResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sighting, err := s.activeSightingLocked(ctx, req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if err = checkVersion(sighting, req.GetExpectedVersion()); err != nil {
+		return nil, err
+	}
+
+	from := sighting.GetStatus()
+	if err = checkTransition(sighting.GetUuid(), from, req.GetStatus(), req.GetNote()); err != nil {
+		return nil, err
+	}
+
+	now := timestamppb.New(time.Now())
+	sighting.Status = req.GetStatus()
+	sighting.ReviewNotes = append(sighting.ReviewNotes, &ufoV1.ReviewNote{
+		Reviewer:   actorOf(ctx),
+		FromStatus: from,
+		ToStatus:   req.GetStatus(),
+		Note:       req.GetNote(),
+		CreatedAt:  now,
+	})
+	sighting.UpdatedAt = now
+	if err = s.saveLocked(ctx, sighting, ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_UPDATED); err != nil {
+		return nil, err
+	}
+
+	log.Printf("🛂 Sighting %s moved from %s to %s by %s", sighting.GetUuid(), from, req.GetStatus(), actorOf(ctx))
+	return &ufoV1.TransitionStatusResponse{Sighting: sighting}, nil
+}
+
+func (s *ufoService) ListByStatus(ctx context.Context, req *ufoV1.ListByStatusRequest) (*ufoV1.ListByStatusResponse, error) {
+	resp, err := s.List(ctx, &ufoV1.ListRequest{
+		PageSize:  req.GetPageSize(),
+		PageToken: req.GetPageToken(),
+		Filter:    &ufoV1.ListFilter{Statuses: []ufoV1.SightingStatus{req.GetStatus()}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ufoV1.ListByStatusResponse{Sightings: resp.GetSightings(), NextPageToken: resp.GetNextPageToken()}, nil
+}
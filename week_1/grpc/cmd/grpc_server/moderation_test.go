@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/auth"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestTransitionMatrix(t *testing.T) {
+	const (
+		unspecified = ufoV1.SightingStatus_SIGHTING_STATUS_UNSPECIFIED
+		submitted   = ufoV1.SightingStatus_SIGHTING_STATUS_SUBMITTED
+		review      = ufoV1.SightingStatus_SIGHTING_STATUS_UNDER_REVIEW
+		verified    = ufoV1.SightingStatus_SIGHTING_STATUS_VERIFIED
+		rejected    = ufoV1.SightingStatus_SIGHTING_STATUS_REJECTED
+		hoax        = ufoV1.SightingStatus_SIGHTING_STATUS_HOAX
+	)
+	statuses := []ufoV1.SightingStatus{unspecified, submitted, review, verified, rejected, hoax}
+
+	// Матрица задана отдельно от statusTransitions: изменение правил должно
+	// сопровождаться осознанным изменением теста
+	allowed := map[[2]ufoV1.SightingStatus]bool{
+		{submitted, review}:   true,
+		{submitted, rejected}: true,
+		{review, submitted}:   true,
+		{review, verified}:    true,
+		{review, rejected}:    true,
+		{review, hoax}:        true,
+		{verified, review}:    true,
+		{verified, hoax}:      true,
+		{rejected, review}:    true,
+		{hoax, review}:        true,
+	}
+	if len(ufoV1.SightingStatus_name) != len(statuses) {
+		t.Fatalf("SightingStatus has %d values, matrix covers %d", len(ufoV1.SightingStatus_name), len(statuses))
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			err := checkTransition("s", from, to, "explained")
+			if want := allowed[[2]ufoV1.SightingStatus{from, to}]; (err == nil) != want {
+				t.Errorf("%s -> %s: err = %v, want allowed %t", from, to, err, want)
+			} else if err != nil {
+				wantCode(t, err, codes.FailedPrecondition)
+			}
+		}
+	}
+}
+
+func TestTransitionNoteRequired(t *testing.T) {
+	tests := []struct {
+		from, to ufoV1.SightingStatus
+		needNote bool
+	}{
+		{ufoV1.SightingStatus_SIGHTING_STATUS_SUBMITTED, ufoV1.SightingStatus_SIGHTING_STATUS_REJECTED, true},
+		{ufoV1.SightingStatus_SIGHTING_STATUS_UNDER_REVIEW, ufoV1.SightingStatus_SIGHTING_STATUS_HOAX, true},
+		{ufoV1.SightingStatus_SIGHTING_STATUS_UNDER_REVIEW, ufoV1.SightingStatus_SIGHTING_STATUS_VERIFIED, false},
+		{ufoV1.SightingStatus_SIGHTING_STATUS_SUBMITTED, ufoV1.SightingStatus_SIGHTING_STATUS_UNDER_REVIEW, false},
+	}
+	for _, tt := range tests {
+		err := checkTransition("s", tt.from, tt.to, "")
+		if (err != nil) != tt.needNote {
+			t.Errorf("%s -> %s without note: err = %v, want note required %t", tt.from, tt.to, err, tt.needNote)
+		}
+	}
+}
+
+func TestTransitionStatus(t *testing.T) {
+	s := newTestService(t, nil)
+	ctx := auth.NewContext(context.Background(), &auth.Principal{Subject: "skinner", Roles: []string{auth.RoleAnalyst}})
+	id := mustCreate(t, s, testInfo("Roswell", "Silver disc"))
+
+	path := []struct {
+		to   ufoV1.SightingStatus
+		note string
+	}{
+		{ufoV1.SightingStatus_SIGHTING_STATUS_UNDER_REVIEW, ""},
+		{ufoV1.SightingStatus_SIGHTING_STATUS_VERIFIED, "radar confirms"},
+		{ufoV1.SightingStatus_SIGHTING_STATUS_HOAX, "weather balloon"},
+		{ufoV1.SightingStatus_SIGHTING_STATUS_UNDER_REVIEW, "new evidence"},
+	}
+	for i, step := range path {
+		resp, err := s.TransitionStatus(ctx, &ufoV1.TransitionStatusRequest{
+			Uuid: id, Status: step.to, Note: step.note, ExpectedVersion: wrapperspb.Int64(int64(i + 1)),
+		})
+		if err != nil {
+			t.Fatalf("step %d to %s: %v", i, step.to, err)
+		}
+		if got := resp.GetSighting(); got.GetStatus() != step.to || got.GetVersion() != int64(i+2) {
+			t.Fatalf("step %d: status %s version %d", i, got.GetStatus(), got.GetVersion())
+		}
+	}
+
+	notes := mustGet(t, s, id).GetReviewNotes()
+	if len(notes) != len(path) {
+		t.Fatalf("review notes = %d, want %d", len(notes), len(path))
+	}
+	last := notes[len(notes)-1]
+	if last.GetReviewer() != "skinner" || last.GetFromStatus() != ufoV1.SightingStatus_SIGHTING_STATUS_HOAX || last.GetNote() != "new evidence" {
+		t.Errorf("last review note = %v", last)
+	}
+
+	// Запрещенный переход, устаревшая версия и отсутствующая заметка ничего не меняют
+	_, err := s.TransitionStatus(ctx, &ufoV1.TransitionStatusRequest{Uuid: id, Status: ufoV1.SightingStatus_SIGHTING_STATUS_UNDER_REVIEW})
+	wantCode(t, err, codes.FailedPrecondition)
+	_, err = s.TransitionStatus(ctx, &ufoV1.TransitionStatusRequest{
+		Uuid: id, Status: ufoV1.SightingStatus_SIGHTING_STATUS_VERIFIED, ExpectedVersion: wrapperspb.Int64(1),
+	})
+	wantCode(t, err, codes.Aborted)
+	_, err = s.TransitionStatus(ctx, &ufoV1.TransitionStatusRequest{Uuid: id, Status: ufoV1.SightingStatus_SIGHTING_STATUS_REJECTED})
+	wantCode(t, err, codes.FailedPrecondition)
+
+	if got := mustGet(t, s, id); got.GetVersion() != int64(len(path)+1) || len(got.GetReviewNotes()) != len(path) {
+		t.Errorf("rejected transitions changed the sighting: version %d, %d notes", got.GetVersion(), len(got.GetReviewNotes()))
+	}
+
+	if _, err = s.Delete(ctx, &ufoV1.DeleteRequest{Uuid: id}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.TransitionStatus(ctx, &ufoV1.TransitionStatusRequest{Uuid: id, Status: ufoV1.SightingStatus_SIGHTING_STATUS_VERIFIED})
+	wantCode(t, err, codes.NotFound)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/geo"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func pointOf(p *ufoV1.GeoPoint) geo.Point {
+	return geo.Point{Lat: p.GetLatitude(), Lon: p.GetLongitude()}
+}
+
+// areaFingerprint отпечаток области поиска для токена страницы
+func areaFingerprint(req *ufoV1.FindNearbyRequest) (uint32, error) {
+	area := proto.Clone(req).(*ufoV1.FindNearbyRequest)
+	area.PageSize, area.PageToken = 0, ""
+	return messageFingerprint(area)
+}
+
+func (s *ufoService) FindNearby(ctx context.Context, req *ufoV1.FindNearbyRequest) (*ufoV1.FindNearbyResponse, error) {
+	pageSize, err := normalizePageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	want, err := areaFingerprint(req)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := decodeOffsetToken(req.GetPageToken(), want)
+	if err != nil {
+		return nil, err
+	}
+
+	var hits []geo.Hit
+	switch area := req.GetArea().(type) {
+	case *ufoV1.FindNearbyRequest_Circle:
+		hits = s.geoIndex.WithinRadius(pointOf(area.Circle.GetCenter()), area.Circle.GetRadiusKm())
+	case *ufoV1.FindNearbyRequest_Box:
+		box := geo.Box{SouthWest: pointOf(area.Box.GetSouthWest()), NorthEast: pointOf(area.Box.GetNorthEast())}
+		if box.SouthWest.Lat > box.NorthEast.Lat {
+			return nil, badRequest([]*errdetails.BadRequest_FieldViolation{{
+				Field:       "box.south_west.latitude",
+				Description: "must not be greater than box.north_east.latitude",
+			}})
+		}
+		hits = s.geoIndex.WithinBox(box)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "circle or box is required")
+	}
+
+	resp := &ufoV1.FindNearbyResponse{TotalSize: int32(len(hits))}
+	start, end, next := cursor.page(len(hits), pageSize)
+	resp.NextPageToken = next
+
+	resp.Sightings = make([]*ufoV1.NearbySighting, 0, end-start)
+	for _, hit := range hits[start:end] {
+		sighting, err := s.repo.Get(ctx, hit.ID)
+		// Наблюдение могли удалить между поиском по индексу и чтением
+		if errors.Is(err, repository.ErrNotFound) || (err == nil && sighting.GetDeletedAt() != nil) {
+			continue
+		}
+		if err != nil {
+			return nil, repositoryError(err, hit.ID)
+		}
+		resp.Sightings = append(resp.Sightings, &ufoV1.NearbySighting{Sighting: sighting, DistanceKm: hit.DistanceKm})
+	}
+
+	return resp, nil
+}
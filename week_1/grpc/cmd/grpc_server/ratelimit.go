@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/interceptor"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/ratelimit"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// defaultLimitKey лимит для методов UFOService, не перечисленных явно
+const defaultLimitKey = "*"
+
+// parseRateLimits разбирает UFO_RATE_LIMITS вида "*=50:100,Create=10:20":
+// короткое имя метода UFOService или * и лимит rate:burst
+func parseRateLimits(spec string) (map[string]ratelimit.Limit, error) {
+	limits := make(map[string]ratelimit.Limit)
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		method, rawLimit, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("rate limit %q must look like Method=rate:burst", item)
+		}
+		method = strings.TrimSpace(method)
+		if method != defaultLimitKey && !isUFOMethod(method) {
+			return nil, fmt.Errorf("rate limit %q: unknown method %s", item, method)
+		}
+
+		limit, err := ratelimit.ParseLimit(strings.TrimSpace(rawLimit))
+		if err != nil {
+			return nil, err
+		}
+		limits[method] = limit
+	}
+	return limits, nil
+}
+
+// methodLimits раскладывает лимиты по полным именам всех методов UFOService.
+// Служебные сервисы (health, reflection) не ограничиваются
+func methodLimits(limits map[string]ratelimit.Limit) map[string]ratelimit.Limit {
+	byFullName := make(map[string]ratelimit.Limit)
+	for _, name := range ufoMethods() {
+		limit, ok := limits[name]
+		if !ok {
+			limit, ok = limits[defaultLimitKey]
+		}
+		if ok {
+			byFullName["/"+ufoV1.UFOService_ServiceDesc.ServiceName+"/"+name] = limit
+		}
+	}
+	return byFullName
+}
+
+func ufoMethods() []string {
+	desc := ufoV1.UFOService_ServiceDesc
+	names := make([]string, 0, len(desc.Methods)+len(desc.Streams))
+	for _, m := range desc.Methods {
+		names = append(names, m.MethodName)
+	}
+	for _, s := range desc.Streams {
+		names = append(names, s.StreamName)
+	}
+	return names
+}
+
+func isUFOMethod(name string) bool {
+	for _, m := range ufoMethods() {
+		if m == name {
+			return true
+		}
+	}
+	return false
+}
+
+// createQuota суточная квота на создание наблюдений, общая для Create и ImportSightings.
+// Списывается в обработчиках за каждое созданное наблюдение: повтор Create по ключу
+// идемпотентности ничего не создает, а одна загрузка может создать тысячи наблюдений.
+// nil - без ограничения
+type createQuota struct {
+	quota *ratelimit.DailyQuota
+}
+
+func newCreateQuota(limit int) *createQuota {
+	if limit <= 0 {
+		return nil
+	}
+	return &createQuota{quota: ratelimit.NewDailyQuota(limit)}
+}
+
+// take списывает n созданий у вызывающего и возвращает его ключ для refund
+func (q *createQuota) take(ctx context.Context, n int) (string, error) {
+	if q == nil {
+		return "", nil
+	}
+
+	client := interceptor.ClientKey(ctx)
+	ok, resetIn := q.quota.TakeN(client, n, time.Now())
+	if ok {
+		return client, nil
+	}
+
+	st, err := status.New(codes.ResourceExhausted, fmt.Sprintf("daily quota of %d created sightings is exhausted", q.quota.Limit())).
+		WithDetails(
+			&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{{
+				Subject:     client,
+				Description: fmt.Sprintf("%d created sightings per day", q.quota.Limit()),
+			}}},
+			&errdetails.RetryInfo{RetryDelay: durationpb.New(resetIn)},
+		)
+	if err != nil {
+		return "", status.Errorf(codes.ResourceExhausted, "daily quota of %d created sightings is exhausted", q.quota.Limit())
+	}
+	return "", st.Err()
+}
+
+// refund возвращает n созданий, списанных через take, если наблюдения так и не создались
+func (q *createQuota) refund(client string, n int) {
+	if q == nil {
+		return
+	}
+	q.quota.Refund(client, n, time.Now())
+}
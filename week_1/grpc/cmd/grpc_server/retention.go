@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+)
+
+// runRetention периодически безвозвратно удаляет наблюдения, удаленные раньше чем retention назад
+func (s *ufoService) runRetention(ctx context.Context, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			deadline := now.Add(-retention)
+			purged, err := s.purgeDeletedBefore(ctx, deadline)
+			if err != nil {
+				log.Printf("Retention failed: %v", err)
+			}
+			if purged > 0 {
+				log.Printf("🧹 Retention: purged %d sightings deleted before %s", purged, deadline.Format(time.RFC3339))
+			}
+		}
+	}
+}
+
+// purgeDeletedBefore безвозвратно удаляет наблюдения, удаленные раньше deadline
+func (s *ufoService) purgeDeletedBefore(ctx context.Context, deadline time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Внутри Scan хранилище трогать нельзя, поэтому сначала собираем просроченные
+	var expired []*ufoV1.Sighting
+	err := s.repo.Scan(ctx, func(sighting *ufoV1.Sighting) error {
+		// Объединенные наблюдения остаются: на них ссылается merged_from основного
+		if sighting.GetMergedInto() != "" {
+			return nil
+		}
+		if sighting.GetDeletedAt() != nil && sighting.GetDeletedAt().AsTime().Before(deadline) {
+			expired = append(expired, sighting)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, sighting := range expired {
+		if err = s.purgeLocked(ctx, sighting); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/search"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *ufoService) Search(ctx context.Context, req *ufoV1.SearchRequest) (*ufoV1.SearchResponse, error) {
+	pageSize, err := normalizePageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(search.Analyze(req.GetQuery())) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "query %q has no searchable words", req.GetQuery())
+	}
+
+	cursor, err := decodeOffsetToken(req.GetPageToken(), fingerprint([]byte(req.GetQuery())))
+	if err != nil {
+		return nil, err
+	}
+
+	hits := s.textIndex.Search(req.GetQuery())
+	resp := &ufoV1.SearchResponse{TotalSize: int32(len(hits))}
+	start, end, next := cursor.page(len(hits), pageSize)
+	resp.NextPageToken = next
+	page := hits[start:end]
+
+	resp.Results = make([]*ufoV1.SearchResult, 0, len(page))
+	for _, hit := range page {
+		sighting, err := s.repo.Get(ctx, hit.ID)
+		// Наблюдение могли удалить между поиском по индексу и чтением
+		if errors.Is(err, repository.ErrNotFound) || (err == nil && sighting.GetDeletedAt() != nil) {
+			continue
+		}
+		if err != nil {
+			return nil, repositoryError(err, hit.ID)
+		}
+		resp.Results = append(resp.Results, &ufoV1.SearchResult{Sighting: sighting, Score: hit.Score})
+	}
+
+	return resp, nil
+}
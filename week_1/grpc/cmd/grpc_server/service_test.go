@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/geo"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository/memory"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/search"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newTestService собирает сервис на хранилище в памяти, repo nil - пустое хранилище
+func newTestService(t *testing.T, repo repository.SightingRepository) *ufoService {
+	t.Helper()
+
+	if repo == nil {
+		repo = memory.NewRepository()
+	}
+	s := &ufoService{
+		repo:            repo,
+		events:          newEventHub(),
+		idempotency:     newIdempotencyStore(defaultIdempotencyTTL),
+		textIndex:       search.NewIndex(),
+		geoIndex:        geo.NewIndex(),
+		duplicateWindow: defaultDuplicateWindow,
+		attachmentLimits: attachmentLimits{
+			maxBytes: defaultAttachmentMaxBytes,
+			types:    []string{"image/png", "text/plain"},
+		},
+	}
+	if err := s.buildIndex(context.Background()); err != nil {
+		t.Fatalf("build index: %v", err)
+	}
+	return s
+}
+
+// testInfo возвращает корректное описание наблюдения
+func testInfo(location, description string) *ufoV1.SightingInfo {
+	return &ufoV1.SightingInfo{
+		ObservedAt:  timestamppb.New(time.Date(2026, 7, 8, 21, 30, 0, 0, time.UTC)),
+		Location:    location,
+		Description: description,
+	}
+}
+
+func mustCreate(t *testing.T, s *ufoService, info *ufoV1.SightingInfo) string {
+	t.Helper()
+
+	resp, err := s.Create(context.Background(), &ufoV1.CreateRequest{Info: info})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	return resp.GetUuid()
+}
+
+func mustGet(t *testing.T, s *ufoService, id string) *ufoV1.Sighting {
+	t.Helper()
+
+	resp, err := s.Get(context.Background(), &ufoV1.GetRequest{Uuid: id, IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("get %s: %v", id, err)
+	}
+	return resp.GetSighting()
+}
+
+func wantCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+
+	if status.Code(err) != code {
+		t.Fatalf("err = %v, want code %s", err, code)
+	}
+}
+
+// faultyRepo хранилище, которое отказывает в выбранных операциях
+type faultyRepo struct {
+	repository.SightingRepository
+	// failUpdate решает, отказать ли в Update наблюдения
+	failUpdate func(*ufoV1.Sighting) bool
+	// failAppendRevision решает, отказать ли в записи ревизии
+	failAppendRevision func(*ufoV1.Revision) bool
+}
+
+var errInjected = errors.New("injected failure")
+
+func (r *faultyRepo) Update(ctx context.Context, sighting *ufoV1.Sighting) error {
+	if r.failUpdate != nil && r.failUpdate(sighting) {
+		return errInjected
+	}
+	return r.SightingRepository.Update(ctx, sighting)
+}
+
+func (r *faultyRepo) AppendRevision(ctx context.Context, revision *ufoV1.Revision) error {
+	if r.failAppendRevision != nil && r.failAppendRevision(revision) {
+		return errInjected
+	}
+	return r.SightingRepository.AppendRevision(ctx, revision)
+}
+
+// fakeClientStream клиентский стрим с заранее заданными сообщениями, запоминает ответ
+type fakeClientStream[Req, Resp any] struct {
+	grpc.ServerStream
+	ctx  context.Context
+	reqs []*Req
+	resp *Resp
+}
+
+func (f *fakeClientStream[Req, Resp]) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeClientStream[Req, Resp]) Recv() (*Req, error) {
+	if len(f.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := f.reqs[0]
+	f.reqs = f.reqs[1:]
+	return req, nil
+}
+
+func (f *fakeClientStream[Req, Resp]) SendAndClose(resp *Resp) error {
+	f.resp = resp
+	return nil
+}
+
+// fakeServerStream серверный стрим, который копит отправленные сообщения
+type fakeServerStream[Resp any] struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*Resp
+}
+
+func (f *fakeServerStream[Resp]) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeServerStream[Resp]) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (f *fakeServerStream[Resp]) Send(resp *Resp) error {
+	f.sent = append(f.sent, resp)
+	return nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// statsAccumulator собирает статистику за один проход по наблюдениям
+type statsAccumulator struct {
+	total     int
+	byDay     map[time.Time]int
+	byWeek    map[time.Time]int
+	byMonth   map[time.Time]int
+	byColor   map[string]int
+	bySound   map[string]int
+	byPlace   map[string]int
+	durations []int32
+}
+
+func newStatsAccumulator() *statsAccumulator {
+	return &statsAccumulator{
+		byDay:   make(map[time.Time]int),
+		byWeek:  make(map[time.Time]int),
+		byMonth: make(map[time.Time]int),
+		byColor: make(map[string]int),
+		bySound: make(map[string]int),
+		byPlace: make(map[string]int),
+	}
+}
+
+func (a *statsAccumulator) add(sighting *ufoV1.Sighting) {
+	info := sighting.GetInfo()
+	a.total++
+
+	day := info.GetObservedAt().AsTime().UTC().Truncate(24 * time.Hour)
+	a.byDay[day]++
+	// Неделя по ISO 8601 начинается с понедельника, а time.Weekday считает с воскресенья
+	a.byWeek[day.AddDate(0, 0, -(int(day.Weekday())+6)%7)]++
+	a.byMonth[time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)]++
+
+	// У незаданного цвета или звука GetValue пустой: такие наблюдения попадают в группу ""
+	a.byColor[groupKey(info.GetColor().GetValue())]++
+	a.bySound[groupKey(info.GetSound().GetValue())]++
+	a.byPlace[groupKey(info.GetLocation())]++
+
+	if info.GetDurationSeconds() != nil {
+		a.durations = append(a.durations, info.GetDurationSeconds().GetValue())
+	}
+}
+
+// groupKey приводит значение к ключу группы: регистр и крайние пробелы не различаются
+func groupKey(v string) string {
+	return strings.ToLower(strings.TrimSpace(v))
+}
+
+func (a *statsAccumulator) response(groupLimit int) *ufoV1.GetStatsResponse {
+	return &ufoV1.GetStatsResponse{
+		Total:      int32(a.total),
+		ByDay:      periodCounts(a.byDay),
+		ByWeek:     periodCounts(a.byWeek),
+		ByMonth:    periodCounts(a.byMonth),
+		ByColor:    groupCounts(a.byColor, groupLimit),
+		BySound:    groupCounts(a.bySound, groupLimit),
+		ByLocation: groupCounts(a.byPlace, groupLimit),
+		Duration:   durationStats(a.durations),
+	}
+}
+
+// periodCounts упорядочивает периоды по времени начала
+func periodCounts(counts map[time.Time]int) []*ufoV1.PeriodCount {
+	starts := make([]time.Time, 0, len(counts))
+	for start := range counts {
+		starts = append(starts, start)
+	}
+	slices.SortFunc(starts, time.Time.Compare)
+
+	periods := make([]*ufoV1.PeriodCount, 0, len(starts))
+	for _, start := range starts {
+		periods = append(periods, &ufoV1.PeriodCount{Start: timestamppb.New(start), Count: int32(counts[start])})
+	}
+	return periods
+}
+
+// groupCounts упорядочивает группы по убыванию числа наблюдений, при равенстве - по значению,
+// и оставляет limit первых, если limit больше нуля
+func groupCounts(counts map[string]int, limit int) []*ufoV1.GroupCount {
+	groups := make([]*ufoV1.GroupCount, 0, len(counts))
+	for value, count := range counts {
+		groups = append(groups, &ufoV1.GroupCount{Value: value, Count: int32(count)})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].GetCount() != groups[j].GetCount() {
+			return groups[i].GetCount() > groups[j].GetCount()
+		}
+		return groups[i].GetValue() < groups[j].GetValue()
+	})
+
+	if limit > 0 && len(groups) > limit {
+		groups = groups[:limit]
+	}
+	return groups
+}
+
+func durationStats(durations []int32) *ufoV1.DurationStats {
+	if len(durations) == 0 {
+		return nil
+	}
+	slices.Sort(durations)
+
+	var sum int64
+	for _, d := range durations {
+		sum += int64(d)
+	}
+
+	return &ufoV1.DurationStats{
+		Count:       int32(len(durations)),
+		MinSeconds:  durations[0],
+		MaxSeconds:  durations[len(durations)-1],
+		MeanSeconds: float64(sum) / float64(len(durations)),
+		P50Seconds:  percentile(durations, 50),
+		P90Seconds:  percentile(durations, 90),
+		P95Seconds:  percentile(durations, 95),
+		P99Seconds:  percentile(durations, 99),
+	}
+}
+
+// percentile возвращает p-й перцентиль отсортированных значений методом ближайшего ранга
+func percentile(sorted []int32, p float64) int32 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// GetStats считает статистику за один проход Scan: в памяти держатся только счетчики
+// и длительности, а не копии всех наблюдений. Группировку не отдаем хранилищу:
+// фильтр тот же, что у List, и ни у одного хранилища нет индексов, по которым его
+// можно было бы проверить без чтения самих наблюдений
+func (s *ufoService) GetStats(ctx context.Context, req *ufoV1.GetStatsRequest) (*ufoV1.GetStatsResponse, error) {
+	acc := newStatsAccumulator()
+	err := s.repo.Scan(ctx, func(sighting *ufoV1.Sighting) error {
+		if matchesFilter(sighting, req.GetFilter()) {
+			acc.add(sighting)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, repositoryError(err, "")
+	}
+
+	return acc.response(int(req.GetGroupLimit())), nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestPercentile(t *testing.T) {
+	oneToTen := []int32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	tests := []struct {
+		sorted []int32
+		p      float64
+		want   int32
+	}{
+		// Метод ближайшего ранга: наименьшее значение, не меньше которого p% выборки
+		{oneToTen, 50, 5},
+		{oneToTen, 90, 9},
+		{oneToTen, 91, 10},
+		{oneToTen, 95, 10},
+		{oneToTen, 99, 10},
+		{oneToTen, 10, 1},
+		{oneToTen, 0, 1},
+		{[]int32{42}, 50, 42},
+		{[]int32{42}, 99, 42},
+		{[]int32{1, 100}, 50, 1},
+		{[]int32{1, 100}, 51, 100},
+	}
+	for _, tt := range tests {
+		if got := percentile(tt.sorted, tt.p); got != tt.want {
+			t.Errorf("percentile(%v, %v) = %d, want %d", tt.sorted, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestDurationStats(t *testing.T) {
+	if got := durationStats(nil); got != nil {
+		t.Errorf("durationStats(nil) = %v, want nil", got)
+	}
+
+	// На вход длительности приходят в порядке наблюдений
+	got := durationStats([]int32{30, 5, 600, 60, 5})
+	want := &ufoV1.DurationStats{
+		Count: 5, MinSeconds: 5, MaxSeconds: 600, MeanSeconds: 140,
+		P50Seconds: 30, P90Seconds: 600, P95Seconds: 600, P99Seconds: 600,
+	}
+	if got.String() != want.String() {
+		t.Errorf("durationStats = %v, want %v", got, want)
+	}
+}
+
+// periodStarts возвращает начала периодов в виде дат
+func periodStarts(periods []*ufoV1.PeriodCount) map[string]int32 {
+	starts := make(map[string]int32, len(periods))
+	for _, p := range periods {
+		starts[p.GetStart().AsTime().Format(time.DateOnly)] = p.GetCount()
+	}
+	return starts
+}
+
+func TestStatsWeeksAtYearBoundary(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	tests := []struct {
+		observed time.Time
+		day      string
+		week     string
+		month    string
+	}{
+		// 1 января 2026 - четверг, ISO-неделя 2026-W01 начинается 29 декабря 2025
+		{time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC), "2026-01-01", "2025-12-29", "2026-01-01"},
+		// Воскресенье 3 января 2027 еще в неделе 2026-W53, начавшейся 28 декабря
+		{time.Date(2027, 1, 3, 23, 59, 59, 0, time.UTC), "2027-01-03", "2026-12-28", "2027-01-01"},
+		// Понедельник начинает свою неделю, даже если год еще старый
+		{time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), "2024-12-30", "2024-12-30", "2024-12-01"},
+		// Группировка по UTC: по Москве уже 1 января, в UTC еще 31 декабря
+		{time.Date(2026, 1, 1, 1, 0, 0, 0, msk), "2025-12-31", "2025-12-29", "2025-12-01"},
+		// Воскресенье относится к неделе, начавшейся в предыдущий понедельник
+		{time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), "2026-03-01", "2026-02-23", "2026-03-01"},
+	}
+
+	for _, tt := range tests {
+		acc := newStatsAccumulator()
+		acc.add(&ufoV1.Sighting{Info: &ufoV1.SightingInfo{ObservedAt: timestamppb.New(tt.observed)}})
+		resp := acc.response(0)
+
+		if got := periodStarts(resp.GetByDay()); got[tt.day] != 1 || len(got) != 1 {
+			t.Errorf("%s: days = %v, want %s", tt.observed, got, tt.day)
+		}
+		if got := periodStarts(resp.GetByWeek()); got[tt.week] != 1 || len(got) != 1 {
+			t.Errorf("%s: weeks = %v, want %s", tt.observed, got, tt.week)
+		}
+		if got := periodStarts(resp.GetByMonth()); got[tt.month] != 1 || len(got) != 1 {
+			t.Errorf("%s: months = %v, want %s", tt.observed, got, tt.month)
+		}
+	}
+}
+
+func TestStatsPeriodsAreOrdered(t *testing.T) {
+	acc := newStatsAccumulator()
+	for _, day := range []int{31, 1, 15, 1} {
+		acc.add(&ufoV1.Sighting{Info: &ufoV1.SightingInfo{
+			ObservedAt: timestamppb.New(time.Date(2025, 12, day, 0, 0, 0, 0, time.UTC)),
+		}})
+	}
+
+	days := acc.response(0).GetByDay()
+	var got []string
+	for _, p := range days {
+		got = append(got, p.GetStart().AsTime().Format("02"))
+	}
+	if len(got) != 3 || got[0] != "01" || got[1] != "15" || got[2] != "31" || days[0].GetCount() != 2 {
+		t.Errorf("days = %v", days)
+	}
+}
+
+func TestGetStats(t *testing.T) {
+	s := newTestService(t, nil)
+
+	for i, color := range []string{"Green", " green ", "red", "", "GREEN"} {
+		info := testInfo("Roswell", "Disc")
+		if color != "" {
+			info.Color = wrapperspb.String(color)
+		}
+		info.DurationSeconds = wrapperspb.Int32(int32(10 * (i + 1)))
+		mustCreate(t, s, info)
+	}
+	deleted := mustCreate(t, s, testInfo("Phoenix", "Lights"))
+	if _, err := s.Delete(context.Background(), &ufoV1.DeleteRequest{Uuid: deleted}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := s.GetStats(context.Background(), &ufoV1.GetStatsRequest{GroupLimit: 2})
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+
+	// Удаленные наблюдения не считаются, как и в List
+	if resp.GetTotal() != 5 {
+		t.Errorf("total = %d, want 5", resp.GetTotal())
+	}
+	colors := resp.GetByColor()
+	if len(colors) != 2 || colors[0].GetValue() != "green" || colors[0].GetCount() != 3 ||
+		colors[1].GetValue() != "" || colors[1].GetCount() != 1 {
+		t.Errorf("colors = %v, want green:3 and \"\":1 (limit 2, ties by value)", colors)
+	}
+	if d := resp.GetDuration(); d.GetCount() != 5 || d.GetP50Seconds() != 30 || d.GetMaxSeconds() != 50 {
+		t.Errorf("duration = %v", d)
+	}
+
+	filtered, err := s.GetStats(context.Background(), &ufoV1.GetStatsRequest{
+		Filter: &ufoV1.ListFilter{Color: wrapperspb.String("red")},
+	})
+	if err != nil || filtered.GetTotal() != 1 {
+		t.Errorf("filtered stats = %v, %v, want total 1", filtered, err)
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"log"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository/bolt"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository/memory"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository/traced"
+)
+
+// newRepository создает хранилище наблюдений, выбранное в конфигурации,
+// и оборачивает его спанами трейсинга
+func newRepository(cfg config) (repository.SightingRepository, error) {
+	var repo repository.SightingRepository
+	switch cfg.storage {
+	case storageBolt:
+		log.Printf("💾 Using bolt storage at %s", cfg.boltPath)
+		boltRepo, err := bolt.NewRepository(cfg.boltPath)
+		if err != nil {
+			return nil, err
+		}
+		repo = boltRepo
+	default:
+		log.Println("💾 Using in-memory storage, data will be lost on restart")
+		repo = memory.NewRepository()
+	}
+
+	return traced.NewRepository(repo, cfg.storage), nil
+}
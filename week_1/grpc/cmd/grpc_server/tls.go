@@ -0,0 +1,31 @@
+package main
+
+import (
+	"log"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/tlsconfig"
+)
+
+// newTLSReloader загружает сертификаты сервера, nil если TLS не настроен
+func newTLSReloader(cfg config) (*tlsconfig.Reloader, error) {
+	if !cfg.tlsFiles.HasKeyPair() {
+		log.Printf("⚠️ TLS is disabled, set UFO_TLS_CERT and UFO_TLS_KEY to enable it")
+		return nil, nil
+	}
+
+	reloader, err := tlsconfig.NewReloader(cfg.tlsFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🔐 TLS is enabled (client certificate required: %t)", cfg.requireClientCert)
+	return reloader, nil
+}
+
+// newGatewayTLSReloader загружает клиентский сертификат REST-шлюза, nil если он не задан
+func newGatewayTLSReloader(cfg config) (*tlsconfig.Reloader, error) {
+	if !cfg.gatewayTLSFiles.HasKeyPair() {
+		return nil, nil
+	}
+	return tlsconfig.NewReloader(cfg.gatewayTLSFiles)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/tracing"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTracePropagation(t *testing.T) {
+	// Спаны пишутся в память вместо экспортера
+	recorder := tracetest.NewSpanRecorder()
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	if _, err := tracing.Setup(context.Background(), "ufo-server", tracing.ExporterNone); err != nil {
+		t.Fatal(err)
+	}
+
+	// Хранилище и обработчик статистики собираются так же, как в main
+	repo, err := newRepository(config{storage: storageMemory})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newTestService(t, repo)
+	id := mustCreate(t, s, testInfo("Roswell", "Silver disc"))
+
+	server := grpc.NewServer(grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	ufoV1.RegisterUFOServiceServer(server, s)
+	client := ufoV1.NewUFOServiceClient(bufconnClient(t, server))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Родительский спан клиента приходит в метаданных traceparent
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+	ctx = metadata.AppendToOutgoingContext(ctx, "traceparent", "00-"+traceID+"-"+spanID+"-01")
+	if _, err = client.Get(ctx, &ufoV1.GetRequest{Uuid: id}); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	checkParent := func(name string, parent trace.SpanID) trace.SpanContext {
+		t.Helper()
+		span, ok := spans[name]
+		if !ok {
+			t.Fatalf("no %s span among %v", name, recorder.Ended())
+		}
+		if got := span.SpanContext().TraceID().String(); got != traceID {
+			t.Errorf("%s span trace ID = %s, want %s", name, got, traceID)
+		}
+		if got := span.Parent().SpanID(); got != parent {
+			t.Errorf("%s span parent = %s, want %s", name, got, parent)
+		}
+		return span.SpanContext()
+	}
+
+	remote, err := trace.SpanIDFromHex(spanID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rpc := checkParent(ufoV1.UFOService_Get_FullMethodName[1:], remote)
+	checkParent("SightingRepository.Get", rpc.SpanID())
+}
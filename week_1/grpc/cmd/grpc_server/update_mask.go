@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/validator"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// wildcardPath путь маски, означающий все обновляемые поля
+const wildcardPath = "*"
+
+// fieldUpdater переносит одно поле из SightingUpdateInfo в SightingInfo.
+// Незаданное значение сбрасывает поле или, для обязательных полей, возвращает ошибку
+type fieldUpdater func(info *ufoV1.SightingInfo, upd *ufoV1.SightingUpdateInfo) error
+
+var errRequiredField = fmt.Errorf("required field cannot be cleared, set a value in update_info")
+
+// updatablePaths обновляемые поля в порядке объявления в SightingUpdateInfo
+var updatablePaths = []string{"observed_at", "location", "description", "color", "sound", "duration_seconds", "coordinates"}
+
+var fieldUpdaters = map[string]fieldUpdater{
+	"observed_at": func(info *ufoV1.SightingInfo, upd *ufoV1.SightingUpdateInfo) error {
+		if upd.GetObservedAt() == nil {
+			return errRequiredField
+		}
+		info.ObservedAt = upd.GetObservedAt()
+		return nil
+	},
+	"location": func(info *ufoV1.SightingInfo, upd *ufoV1.SightingUpdateInfo) error {
+		if upd.GetLocation() == nil {
+			return errRequiredField
+		}
+		info.Location = upd.GetLocation().GetValue()
+		return nil
+	},
+	"description": func(info *ufoV1.SightingInfo, upd *ufoV1.SightingUpdateInfo) error {
+		if upd.GetDescription() == nil {
+			return errRequiredField
+		}
+		info.Description = upd.GetDescription().GetValue()
+		return nil
+	},
+	"color": func(info *ufoV1.SightingInfo, upd *ufoV1.SightingUpdateInfo) error {
+		info.Color = upd.GetColor()
+		return nil
+	},
+	"sound": func(info *ufoV1.SightingInfo, upd *ufoV1.SightingUpdateInfo) error {
+		info.Sound = upd.GetSound()
+		return nil
+	},
+	"duration_seconds": func(info *ufoV1.SightingInfo, upd *ufoV1.SightingUpdateInfo) error {
+		info.DurationSeconds = upd.GetDurationSeconds()
+		return nil
+	},
+	"coordinates": func(info *ufoV1.SightingInfo, upd *ufoV1.SightingUpdateInfo) error {
+		info.Coordinates = upd.GetCoordinates()
+		return nil
+	},
+}
+
+// updatePaths возвращает пути полей, которые меняет запрос. Без маски это поля,
+// заданные в update_info, как было до появления update_mask
+func updatePaths(req *ufoV1.UpdateRequest) ([]string, error) {
+	if len(req.GetUpdateMask().GetPaths()) == 0 {
+		if req.GetUpdateInfo() == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "update_info is required when update_mask is empty")
+		}
+		return setPaths(req.GetUpdateInfo()), nil
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	paths := make([]string, 0, len(req.GetUpdateMask().GetPaths()))
+	for i, path := range req.GetUpdateMask().GetPaths() {
+		switch _, known := fieldUpdaters[path]; {
+		case path == wildcardPath:
+			paths = append(paths, updatablePaths...)
+		case known:
+			paths = append(paths, path)
+		default:
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       fmt.Sprintf("update_mask.paths[%d]", i),
+				Description: fmt.Sprintf("unknown path %q, allowed: %q or %q", path, updatablePaths, wildcardPath),
+			})
+		}
+	}
+	if len(violations) > 0 {
+		return nil, badRequest(violations)
+	}
+
+	return paths, nil
+}
+
+// applyUpdate меняет поля info по списку путей из updatePaths
+func applyUpdate(info *ufoV1.SightingInfo, upd *ufoV1.SightingUpdateInfo, paths []string) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, path := range paths {
+		if err := fieldUpdaters[path](info, upd); err != nil {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       "update_info." + path,
+				Description: err.Error(),
+			})
+		}
+	}
+	if len(violations) > 0 {
+		return badRequest(violations)
+	}
+
+	return nil
+}
+
+func setPaths(upd *ufoV1.SightingUpdateInfo) []string {
+	var paths []string
+	if upd.GetObservedAt() != nil {
+		paths = append(paths, "observed_at")
+	}
+	if upd.GetLocation() != nil {
+		paths = append(paths, "location")
+	}
+	if upd.GetDescription() != nil {
+		paths = append(paths, "description")
+	}
+	if upd.GetColor() != nil {
+		paths = append(paths, "color")
+	}
+	if upd.GetSound() != nil {
+		paths = append(paths, "sound")
+	}
+	if upd.GetDurationSeconds() != nil {
+		paths = append(paths, "duration_seconds")
+	}
+	if upd.GetCoordinates() != nil {
+		paths = append(paths, "coordinates")
+	}
+	return paths
+}
+
+func badRequest(violations []*errdetails.BadRequest_FieldViolation) error {
+	return validator.BadRequest(fmt.Sprintf("%s: %s", violations[0].GetField(), violations[0].GetDescription()), violations)
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// violationFields возвращает поля из BadRequest в ошибке
+func violationFields(err error) []string {
+	var fields []string
+	for _, detail := range status.Convert(err).Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				fields = append(fields, v.GetField())
+			}
+		}
+	}
+	return fields
+}
+
+// createColored создает наблюдение с цветом, звуком и длительностью
+func createColored(t *testing.T, s *ufoService) string {
+	t.Helper()
+
+	info := testInfo("Roswell", "Silver disc")
+	info.Color = wrapperspb.String("green")
+	info.Sound = wrapperspb.String("humming")
+	info.DurationSeconds = wrapperspb.Int32(30)
+	return mustCreate(t, s, info)
+}
+
+func TestUpdatePaths(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *ufoV1.UpdateRequest
+		want []string
+	}{
+		{
+			name: "no mask uses set fields",
+			req: &ufoV1.UpdateRequest{UpdateInfo: &ufoV1.SightingUpdateInfo{
+				Location: wrapperspb.String("Area 51"),
+				Sound:    wrapperspb.String(""),
+			}},
+			want: []string{"location", "sound"},
+		},
+		{
+			name: "mask wins over set fields",
+			req: &ufoV1.UpdateRequest{
+				UpdateInfo: &ufoV1.SightingUpdateInfo{Location: wrapperspb.String("Area 51")},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"color"}},
+			},
+			want: []string{"color"},
+		},
+		{
+			name: "wildcard",
+			req:  &ufoV1.UpdateRequest{UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"*"}}},
+			want: updatablePaths,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := updatePaths(tt.req)
+			if err != nil || !slices.Equal(got, tt.want) {
+				t.Errorf("updatePaths = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdatePathsRejectsUnknown(t *testing.T) {
+	_, err := updatePaths(&ufoV1.UpdateRequest{
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"color", "info.color", "status", "uuid"}},
+	})
+	wantCode(t, err, codes.InvalidArgument)
+
+	want := []string{"update_mask.paths[1]", "update_mask.paths[2]", "update_mask.paths[3]"}
+	if got := violationFields(err); !slices.Equal(got, want) {
+		t.Errorf("violations = %v, want %v", got, want)
+	}
+}
+
+func TestUpdateWithoutMaskOrInfo(t *testing.T) {
+	s := newTestService(t, nil)
+	id := createColored(t, s)
+
+	_, err := s.Update(context.Background(), &ufoV1.UpdateRequest{Uuid: id})
+	wantCode(t, err, codes.InvalidArgument)
+}
+
+func TestUpdateWithoutMaskKeepsUnsetFields(t *testing.T) {
+	s := newTestService(t, nil)
+	id := createColored(t, s)
+
+	_, err := s.Update(context.Background(), &ufoV1.UpdateRequest{
+		Uuid:       id,
+		UpdateInfo: &ufoV1.SightingUpdateInfo{Location: wrapperspb.String("Area 51")},
+	})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	info := mustGet(t, s, id).GetInfo()
+	if info.GetLocation() != "Area 51" || info.GetColor().GetValue() != "green" || info.GetSound().GetValue() != "humming" {
+		t.Errorf("info after update = %v", info)
+	}
+}
+
+func TestUpdateMaskClearsOptionalFields(t *testing.T) {
+	s := newTestService(t, nil)
+	id := createColored(t, s)
+
+	// Поля в маске без значения в update_info сбрасываются, остальные не меняются
+	_, err := s.Update(context.Background(), &ufoV1.UpdateRequest{
+		Uuid:       id,
+		UpdateInfo: &ufoV1.SightingUpdateInfo{},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"color", "sound"}},
+	})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	info := mustGet(t, s, id).GetInfo()
+	if info.GetColor() != nil || info.GetSound() != nil {
+		t.Errorf("color = %v, sound = %v, want both cleared", info.GetColor(), info.GetSound())
+	}
+	if info.GetDurationSeconds().GetValue() != 30 || info.GetLocation() != "Roswell" {
+		t.Errorf("fields outside the mask changed: %v", info)
+	}
+}
+
+func TestUpdateMaskCannotClearRequiredFields(t *testing.T) {
+	s := newTestService(t, nil)
+	id := createColored(t, s)
+
+	_, err := s.Update(context.Background(), &ufoV1.UpdateRequest{
+		Uuid:       id,
+		UpdateInfo: &ufoV1.SightingUpdateInfo{Color: wrapperspb.String("red")},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"*"}},
+	})
+	wantCode(t, err, codes.InvalidArgument)
+
+	want := []string{"update_info.observed_at", "update_info.location", "update_info.description"}
+	if got := violationFields(err); !slices.Equal(got, want) {
+		t.Errorf("violations = %v, want %v", got, want)
+	}
+
+	// Ошибка не оставляет частичных изменений
+	sighting := mustGet(t, s, id)
+	if sighting.GetInfo().GetColor().GetValue() != "green" || sighting.GetVersion() != 1 {
+		t.Errorf("sighting changed by a rejected update: %v", sighting)
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// eventHistorySize сколько последних событий хранится для возобновления подписки
+	eventHistorySize = 1024
+	// subscriberBufferSize сколько событий может накопиться у медленного подписчика до отключения
+	subscriberBufferSize = 256
+)
+
+var (
+	errSubscriberLagged = status.Error(codes.ResourceExhausted, "subscriber is too slow, resume watching from the last received sequence")
+	errHubClosed        = status.Error(codes.Unavailable, "server is shutting down")
+)
+
+// subscriber подписчик на события. Канал закрывает только eventHub,
+// предварительно записав причину отключения в err
+type subscriber struct {
+	ch  chan *ufoV1.SightingEvent
+	err error
+}
+
+// eventHub раздает события изменения наблюдений подписчикам Watch
+// и хранит последние события для возобновления подписки
+type eventHub struct {
+	mu          sync.Mutex
+	sequence    uint64
+	history     []*ufoV1.SightingEvent
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// subscriberCount возвращает число активных подписчиков
+func (h *eventHub) subscriberCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers)
+}
+
+// publish рассылает событие всем подписчикам. Вызывается под блокировкой сервиса,
+// поэтому порядок событий совпадает с порядком изменений
+func (h *eventHub) publish(eventType ufoV1.SightingEventType, sighting *ufoV1.Sighting) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	h.sequence++
+	event := &ufoV1.SightingEvent{
+		Sequence:   h.sequence,
+		Type:       eventType,
+		Sighting:   proto.Clone(sighting).(*ufoV1.Sighting),
+		OccurredAt: timestamppb.New(time.Now()),
+	}
+
+	h.history = append(h.history, event)
+	if len(h.history) > eventHistorySize {
+		h.history = h.history[len(h.history)-eventHistorySize:]
+	}
+
+	for sub := range h.subscribers {
+		select {
+		case sub.ch <- event:
+		default:
+			// Не блокируем изменения из-за медленного клиента: отключаем его,
+			// а клиент может переподписаться с последнего полученного номера
+			h.dropLocked(sub, errSubscriberLagged)
+		}
+	}
+}
+
+// subscribe регистрирует подписчика и возвращает события после lastSequence,
+// которые клиент пропустил. Регистрация и выборка истории атомарны, поэтому
+// между ними не теряется ни одно событие
+func (h *eventHub) subscribe(lastSequence uint64) (*subscriber, []*ufoV1.SightingEvent, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, nil, errHubClosed
+	}
+
+	var backlog []*ufoV1.SightingEvent
+	if lastSequence > 0 {
+		if lastSequence > h.sequence {
+			return nil, nil, status.Errorf(codes.OutOfRange, "sequence %d is ahead of the latest event %d", lastSequence, h.sequence)
+		}
+		if len(h.history) > 0 && h.history[0].GetSequence() > lastSequence+1 {
+			return nil, nil, status.Errorf(codes.OutOfRange, "events after sequence %d are no longer available, resync with List", lastSequence)
+		}
+
+		for _, event := range h.history {
+			if event.GetSequence() > lastSequence {
+				backlog = append(backlog, event)
+			}
+		}
+	}
+
+	sub := &subscriber{ch: make(chan *ufoV1.SightingEvent, subscriberBufferSize)}
+	h.subscribers[sub] = struct{}{}
+
+	return sub, backlog, nil
+}
+
+func (h *eventHub) unsubscribe(sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.subscribers, sub)
+}
+
+// close отключает всех подписчиков, иначе GracefulStop ждал бы бесконечные стримы
+func (h *eventHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sub := range h.subscribers {
+		h.dropLocked(sub, errHubClosed)
+	}
+}
+
+func (h *eventHub) dropLocked(sub *subscriber, err error) {
+	sub.err = err
+	close(sub.ch)
+	delete(h.subscribers, sub)
+}
+
+func (s *ufoService) Watch(req *ufoV1.WatchRequest, stream grpc.ServerStreamingServer[ufoV1.WatchResponse]) error {
+	sub, backlog, err := s.events.subscribe(req.GetLastSequence())
+	if err != nil {
+		return err
+	}
+	defer s.events.unsubscribe(sub)
+
+	// Заголовки отправляются после регистрации подписчика: дождавшись их,
+	// клиент знает, что не пропустит ни одного нового события
+	if err = stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for _, event := range backlog {
+		if err = stream.Send(&ufoV1.WatchResponse{Event: event}); err != nil {
+			return err
+		}
+	}
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case event, ok := <-sub.ch:
+			if !ok {
+				return sub.err
+			}
+			if err = stream.Send(&ufoV1.WatchResponse{Event: event}); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+)
+
+// publishN публикует n событий создания
+func publishN(h *eventHub, n int) {
+	for range n {
+		h.publish(ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_CREATED, &ufoV1.Sighting{Uuid: "s"})
+	}
+}
+
+func sequences(events []*ufoV1.SightingEvent) []uint64 {
+	seqs := make([]uint64, 0, len(events))
+	for _, e := range events {
+		seqs = append(seqs, e.GetSequence())
+	}
+	return seqs
+}
+
+func TestEventHubResumeFromSequence(t *testing.T) {
+	h := newEventHub()
+	publishN(h, 5)
+
+	sub, backlog, err := h.subscribe(3)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer h.unsubscribe(sub)
+
+	if got := sequences(backlog); len(got) != 2 || got[0] != 4 || got[1] != 5 {
+		t.Fatalf("backlog = %v, want [4 5]", got)
+	}
+
+	// Новые события приходят в канал следом за историей, без пропусков
+	publishN(h, 1)
+	if event := <-sub.ch; event.GetSequence() != 6 {
+		t.Errorf("live event sequence = %d, want 6", event.GetSequence())
+	}
+}
+
+func TestEventHubResumeEdges(t *testing.T) {
+	h := newEventHub()
+	publishN(h, 3)
+
+	// С нуля - только новые события, с последнего номера - пустая история
+	for _, last := range []uint64{0, 3} {
+		sub, backlog, err := h.subscribe(last)
+		if err != nil || len(backlog) != 0 {
+			t.Errorf("subscribe(%d) = %v, %v, want empty backlog", last, sequences(backlog), err)
+		}
+		if sub != nil {
+			h.unsubscribe(sub)
+		}
+	}
+
+	_, _, err := h.subscribe(4)
+	wantCode(t, err, codes.OutOfRange)
+}
+
+func TestEventHubResumeBeyondHistory(t *testing.T) {
+	h := newEventHub()
+	publishN(h, eventHistorySize+10)
+
+	if len(h.history) != eventHistorySize {
+		t.Fatalf("history holds %d events, want %d", len(h.history), eventHistorySize)
+	}
+
+	// События 1-10 вытеснены, возобновить с 9 нельзя: 10 уже потеряно
+	_, _, err := h.subscribe(9)
+	wantCode(t, err, codes.OutOfRange)
+
+	// С 10 можно: следующее событие 11 - самое старое в истории
+	sub, backlog, err := h.subscribe(10)
+	if err != nil {
+		t.Fatalf("subscribe(10): %v", err)
+	}
+	defer h.unsubscribe(sub)
+
+	if len(backlog) != eventHistorySize || backlog[0].GetSequence() != 11 {
+		t.Errorf("backlog has %d events starting at %d, want %d starting at 11",
+			len(backlog), backlog[0].GetSequence(), eventHistorySize)
+	}
+}
+
+func TestEventHubDropsSlowSubscriber(t *testing.T) {
+	h := newEventHub()
+
+	slow, _, err := h.subscribe(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fast, _, err := h.subscribe(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.unsubscribe(fast)
+
+	// fast читает, slow - нет
+	for range subscriberBufferSize + 1 {
+		publishN(h, 1)
+		<-fast.ch
+	}
+
+	for range subscriberBufferSize {
+		if _, ok := <-slow.ch; !ok {
+			t.Fatal("slow subscriber lost buffered events")
+		}
+	}
+	if _, ok := <-slow.ch; ok {
+		t.Fatal("slow subscriber channel is still open")
+	}
+	if slow.err != errSubscriberLagged {
+		t.Errorf("slow subscriber err = %v, want errSubscriberLagged", slow.err)
+	}
+	if n := h.subscriberCount(); n != 1 {
+		t.Errorf("subscriberCount = %d, want 1", n)
+	}
+}
+
+func TestEventHubClose(t *testing.T) {
+	h := newEventHub()
+	sub, _, err := h.subscribe(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h.close()
+	if _, ok := <-sub.ch; ok || sub.err != errHubClosed {
+		t.Errorf("after close: open=%t err=%v", ok, sub.err)
+	}
+
+	_, _, err = h.subscribe(0)
+	wantCode(t, err, codes.Unavailable)
+	publishN(h, 1)
+	if h.sequence != 0 {
+		t.Errorf("closed hub accepted an event")
+	}
+}
+
+func TestWatchUnsubscribesOnCancel(t *testing.T) {
+	s := newTestService(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream := &fakeServerStream[ufoV1.WatchResponse]{ctx: ctx}
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Watch(&ufoV1.WatchRequest{}, stream)
+	}()
+
+	waitFor(t, func() bool { return s.events.subscriberCount() == 1 })
+	cancel()
+
+	select {
+	case err := <-done:
+		wantCode(t, err, codes.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Watch did not return after cancel")
+	}
+	if n := s.events.subscriberCount(); n != 0 {
+		t.Errorf("subscriberCount after cancel = %d, want 0", n)
+	}
+}
+
+// waitFor ждет выполнения cond, проверяя его в цикле
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/auth"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxWitnessesPerSighting сколько очевидцев можно добавить к одному наблюдению
+const maxWitnessesPerSighting = 100
+
+// contactRoles роли, которым видны контакты очевидцев и имена анонимных очевидцев
+var contactRoles = []string{auth.RoleAnalyst, auth.RoleAdmin}
+
+// canSeeContacts проверяет, видны ли вызывающему контакты очевидцев. Вызывающего нет
+// только при выключенной аутентификации, тогда скрывать не от кого
+func canSeeContacts(ctx context.Context) bool {
+	p := auth.FromContext(ctx)
+	return p == nil || p.HasRole(contactRoles...)
+}
+
+// redactWitness скрывает контакт очевидца, а у анонимного - и имя
+func redactWitness(w *ufoV1.Witness) {
+	if w.GetInfo() == nil {
+		return
+	}
+	w.Info.Contact = ""
+	if w.GetInfo().GetAnonymous() {
+		w.Info.Name = ""
+	}
+	w.Redacted = true
+}
+
+// redactWitnesses скрывает контакты во всех сообщениях Witness внутри m, на любой глубине
+func redactWitnesses(m protoreflect.Message) {
+	if w, ok := m.Interface().(*ufoV1.Witness); ok {
+		redactWitness(w)
+		return
+	}
+
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Message() == nil:
+		case fd.IsList():
+			for i := range v.List().Len() {
+				redactWitnesses(v.List().Get(i).Message())
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, item protoreflect.Value) bool {
+					redactWitnesses(item.Message())
+					return true
+				})
+			}
+		default:
+			redactWitnesses(v.Message())
+		}
+		return true
+	})
+}
+
+// redactResponse скрывает контакты очевидцев в ответе, если вызывающему они не положены.
+// Ответы бывают общими (события Watch рассылаются всем подписчикам), поэтому меняется копия
+func redactResponse(ctx context.Context, resp proto.Message) proto.Message {
+	if canSeeContacts(ctx) {
+		return resp
+	}
+
+	redacted := proto.Clone(resp)
+	redactWitnesses(redacted.ProtoReflect())
+	return redacted
+}
+
+// withoutContacts возвращает наблюдение со скрытыми контактами очевидцев. История хранит
+// значения полей как JSON, где redactResponse их уже не найдет, поэтому контакты в нее не попадают
+func withoutContacts(sighting *ufoV1.Sighting) *ufoV1.Sighting {
+	if len(sighting.GetWitnesses()) == 0 {
+		return sighting
+	}
+
+	redacted := proto.Clone(sighting).(*ufoV1.Sighting)
+	for _, w := range redacted.GetWitnesses() {
+		redactWitness(w)
+	}
+	return redacted
+}
+
+func (s *ufoService) AddWitness(ctx context.Context, req *ufoV1.AddWitnessRequest) (*ufoV1.AddWitnessResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sighting, err := s.activeSightingLocked(ctx, req.GetSightingUuid())
+	if err != nil {
+		return nil, err
+	}
+	if err = checkVersion(sighting, req.GetExpectedVersion()); err != nil {
+		return nil, err
+	}
+	if len(sighting.GetWitnesses()) >= maxWitnessesPerSighting {
+		return nil, status.Errorf(codes.FailedPrecondition, "sighting with UUID %s already has %d witnesses", sighting.GetUuid(), maxWitnessesPerSighting)
+	}
+
+	now := timestamppb.New(time.Now())
+	witness := &ufoV1.Witness{
+		Id:      uuid.NewString(),
+		Info:    req.GetWitness(),
+		AddedBy: actorOf(ctx),
+		AddedAt: now,
+	}
+	sighting.Witnesses = append(sighting.Witnesses, witness)
+	sighting.UpdatedAt = now
+	if err = s.saveLocked(ctx, sighting, ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_UPDATED); err != nil {
+		return nil, err
+	}
+
+	log.Printf("👀 Added witness %s to sighting %s", witness.GetId(), sighting.GetUuid())
+	return &ufoV1.AddWitnessResponse{Witness: witness, Version: sighting.GetVersion()}, nil
+}
+
+func (s *ufoService) RemoveWitness(ctx context.Context, req *ufoV1.RemoveWitnessRequest) (*ufoV1.RemoveWitnessResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sighting, err := s.activeSightingLocked(ctx, req.GetSightingUuid())
+	if err != nil {
+		return nil, err
+	}
+	if err = checkVersion(sighting, req.GetExpectedVersion()); err != nil {
+		return nil, err
+	}
+
+	idx := slices.IndexFunc(sighting.GetWitnesses(), func(w *ufoV1.Witness) bool {
+		return w.GetId() == req.GetWitnessId()
+	})
+	if idx < 0 {
+		return nil, status.Errorf(codes.NotFound, "witness %s of sighting %s not found", req.GetWitnessId(), req.GetSightingUuid())
+	}
+
+	sighting.Witnesses = slices.Delete(sighting.Witnesses, idx, idx+1)
+	sighting.UpdatedAt = timestamppb.New(time.Now())
+	if err = s.saveLocked(ctx, sighting, ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_UPDATED); err != nil {
+		return nil, err
+	}
+
+	log.Printf("👀 Removed witness %s from sighting %s", req.GetWitnessId(), sighting.GetUuid())
+	return &ufoV1.RemoveWitnessResponse{Version: sighting.GetVersion()}, nil
+}
+
+// witnessMatcher проверяет, упомянут ли очевидец из запроса в наблюдении.
+// Без доступа к контактам искать можно только по имени и только неанонимных очевидцев,
+// иначе по ответам можно было бы восстановить скрытые данные
+func witnessMatcher(ctx context.Context, req *ufoV1.FindSightingsByWitnessRequest) (func(*ufoV1.Witness) bool, error) {
+	privileged := canSeeContacts(ctx)
+
+	switch key := req.GetWitness().(type) {
+	case *ufoV1.FindSightingsByWitnessRequest_Name:
+		name := strings.TrimSpace(key.Name)
+		return func(w *ufoV1.Witness) bool {
+			return (privileged || !w.GetInfo().GetAnonymous()) && strings.EqualFold(strings.TrimSpace(w.GetInfo().GetName()), name)
+		}, nil
+	case *ufoV1.FindSightingsByWitnessRequest_Contact:
+		if !privileged {
+			return nil, status.Errorf(codes.PermissionDenied, "searching by witness contact requires one of roles %v", contactRoles)
+		}
+		contact := strings.TrimSpace(key.Contact)
+		return func(w *ufoV1.Witness) bool {
+			return strings.EqualFold(strings.TrimSpace(w.GetInfo().GetContact()), contact)
+		}, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "witness name or contact is required")
+	}
+}
+
+func (s *ufoService) FindSightingsByWitness(ctx context.Context, req *ufoV1.FindSightingsByWitnessRequest) (*ufoV1.FindSightingsByWitnessResponse, error) {
+	matchWitness, err := witnessMatcher(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	witness := proto.Clone(req).(*ufoV1.FindSightingsByWitnessRequest)
+	witness.PageSize, witness.PageToken = 0, ""
+	witnessFingerprint, err := messageFingerprint(witness)
+	if err != nil {
+		return nil, err
+	}
+
+	sightings, next, err := s.listMatching(ctx, req.GetPageSize(), req.GetPageToken(), witnessFingerprint, func(sighting *ufoV1.Sighting) bool {
+		return sighting.GetDeletedAt() == nil && slices.ContainsFunc(sighting.GetWitnesses(), matchWitness)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ufoV1.FindSightingsByWitnessResponse{Sightings: sightings, NextPageToken: next}, nil
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/auth"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/interceptor"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	witnessContact = "fox@fbi.gov"
+	anonymousName  = "Deep Throat"
+)
+
+// tokenRoles аутентификатор для тестов: токен - это имя вызывающего
+type tokenRoles map[string][]string
+
+func (t tokenRoles) Authenticate(_ context.Context, token string) (*auth.Principal, error) {
+	roles, ok := t[token]
+	if !ok {
+		return nil, auth.ErrInvalidToken
+	}
+	return &auth.Principal{Subject: token, Roles: roles}, nil
+}
+
+// redactionServer поднимает gRPC-сервер с аутентификацией и скрытием контактов,
+// как в main, и возвращает его адрес
+func redactionServer(t *testing.T, s *ufoService) string {
+	t.Helper()
+
+	authn := tokenRoles{"reporter": {auth.RoleReporter}, "analyst": {auth.RoleAnalyst}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			interceptor.UnaryAuth(logger, authn, accessPolicy),
+			interceptor.UnaryRedact(redactResponse),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptor.StreamAuth(logger, authn, accessPolicy),
+			interceptor.StreamRedact(redactResponse),
+		),
+	)
+	ufoV1.RegisterUFOServiceServer(server, s)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	return lis.Addr().String()
+}
+
+func dialUFO(t *testing.T, addr string) ufoV1.UFOServiceClient {
+	t.Helper()
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return ufoV1.NewUFOServiceClient(conn)
+}
+
+func as(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+// addWitnesses добавляет к наблюдению очевидца с контактом и анонимного очевидца
+func addWitnesses(t *testing.T, s *ufoService, id string) {
+	t.Helper()
+
+	for _, info := range []*ufoV1.WitnessInfo{
+		{Name: "Fox", Contact: witnessContact},
+		{Name: anonymousName, Contact: "+1 555 0100", Anonymous: true},
+	} {
+		if _, err := s.AddWitness(context.Background(), &ufoV1.AddWitnessRequest{SightingUuid: id, Witness: info}); err != nil {
+			t.Fatalf("add witness: %v", err)
+		}
+	}
+}
+
+// checkWitnesses проверяет, скрыты ли контакты очевидцев в сообщении m
+func checkWitnesses(t *testing.T, path string, m proto.Message, wantRedacted bool) {
+	t.Helper()
+
+	var witnesses []*ufoV1.Witness
+	switch resp := m.(type) {
+	case *ufoV1.GetResponse:
+		witnesses = resp.GetSighting().GetWitnesses()
+	case *ufoV1.ListResponse:
+		for _, s := range resp.GetSightings() {
+			witnesses = append(witnesses, s.GetWitnesses()...)
+		}
+	case *ufoV1.SearchResponse:
+		for _, r := range resp.GetResults() {
+			witnesses = append(witnesses, r.GetSighting().GetWitnesses()...)
+		}
+	case *ufoV1.WatchResponse:
+		witnesses = resp.GetEvent().GetSighting().GetWitnesses()
+	default:
+		t.Fatalf("%s: unexpected response %T", path, m)
+	}
+
+	if len(witnesses) != 2 {
+		t.Fatalf("%s: %d witnesses, want 2", path, len(witnesses))
+	}
+	open, anonymous := witnesses[0], witnesses[1]
+	if wantRedacted {
+		if open.GetInfo().GetContact() != "" || anonymous.GetInfo().GetContact() != "" || anonymous.GetInfo().GetName() != "" {
+			t.Errorf("%s: contacts leaked: %v", path, witnesses)
+		}
+		if !open.GetRedacted() || !anonymous.GetRedacted() || open.GetInfo().GetName() != "Fox" {
+			t.Errorf("%s: witnesses not marked redacted or open name hidden: %v", path, witnesses)
+		}
+		return
+	}
+	if open.GetInfo().GetContact() != witnessContact || anonymous.GetInfo().GetName() != anonymousName || open.GetRedacted() {
+		t.Errorf("%s: contacts hidden from a privileged caller: %v", path, witnesses)
+	}
+}
+
+func TestRedactionOnEachPath(t *testing.T) {
+	s := newTestService(t, nil)
+	id := mustCreate(t, s, testInfo("Roswell", "Silver disc"))
+	addWitnesses(t, s, id)
+	client := dialUFO(t, redactionServer(t, s))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for _, caller := range []struct {
+		token    string
+		redacted bool
+	}{
+		// Запросы reporter идут первыми: если бы скрытие меняло общий объект,
+		// analyst после него увидел бы пустые контакты
+		{"reporter", true},
+		{"analyst", false},
+	} {
+		callCtx := as(ctx, caller.token)
+
+		get, err := client.Get(callCtx, &ufoV1.GetRequest{Uuid: id})
+		if err != nil {
+			t.Fatalf("Get as %s: %v", caller.token, err)
+		}
+		checkWitnesses(t, "Get as "+caller.token, get, caller.redacted)
+
+		list, err := client.List(callCtx, &ufoV1.ListRequest{})
+		if err != nil {
+			t.Fatalf("List as %s: %v", caller.token, err)
+		}
+		checkWitnesses(t, "List as "+caller.token, list, caller.redacted)
+
+		search, err := client.Search(callCtx, &ufoV1.SearchRequest{Query: "roswell"})
+		if err != nil {
+			t.Fatalf("Search as %s: %v", caller.token, err)
+		}
+		checkWitnesses(t, "Search as "+caller.token, search, caller.redacted)
+
+		// События с очевидцами из истории подписки
+		watch, err := client.Watch(callCtx, &ufoV1.WatchRequest{LastSequence: 2})
+		if err != nil {
+			t.Fatalf("Watch as %s: %v", caller.token, err)
+		}
+		event, err := watch.Recv()
+		if err != nil {
+			t.Fatalf("Watch as %s: %v", caller.token, err)
+		}
+		checkWitnesses(t, "Watch as "+caller.token, event, caller.redacted)
+	}
+}
+
+func TestRedactionOfSharedWatchEvents(t *testing.T) {
+	s := newTestService(t, nil)
+	id := mustCreate(t, s, testInfo("Roswell", "Silver disc"))
+	addWitnesses(t, s, id)
+	client := dialUFO(t, redactionServer(t, s))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Одно и то же событие рассылается обоим подписчикам
+	streams := make(map[string]grpc.ServerStreamingClient[ufoV1.WatchResponse])
+	for _, token := range []string{"reporter", "analyst"} {
+		stream, err := client.Watch(as(ctx, token), &ufoV1.WatchRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Заголовки приходят после регистрации подписчика
+		if _, err = stream.Header(); err != nil {
+			t.Fatal(err)
+		}
+		streams[token] = stream
+	}
+
+	if _, err := s.TransitionStatus(context.Background(), &ufoV1.TransitionStatusRequest{
+		Uuid: id, Status: ufoV1.SightingStatus_SIGHTING_STATUS_UNDER_REVIEW,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, token := range []string{"reporter", "analyst"} {
+		event, err := streams[token].Recv()
+		if err != nil {
+			t.Fatalf("Watch as %s: %v", token, err)
+		}
+		checkWitnesses(t, "live Watch as "+token, event, token == "reporter")
+	}
+}
+
+func TestRedactionThroughGateway(t *testing.T) {
+	s := newTestService(t, nil)
+	id := mustCreate(t, s, testInfo("Roswell", "Silver disc"))
+	addWitnesses(t, s, id)
+	addr := redactionServer(t, s)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	gw, err := newGateway(ctx, "", addr, insecure.NewCredentials(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = gw.conn.Close() })
+	server := httptest.NewServer(gw.server.Handler)
+	t.Cleanup(server.Close)
+
+	get := func(token, path string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("GET %s as %s: %s", path, token, resp.Status)
+		}
+		return resp
+	}
+
+	for _, token := range []string{"reporter", "analyst"} {
+		body, err := io.ReadAll(get(token, "/api/v1/sightings/"+id).Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var sighting ufoV1.GetResponse
+		if err = protojson.Unmarshal(body, &sighting); err != nil {
+			t.Fatalf("decode %s: %v", body, err)
+		}
+		checkWitnesses(t, "gateway Get as "+token, &sighting, token == "reporter")
+
+		// Стрим шлюз отдает построчно в виде {"result": ...}
+		line, err := bufio.NewReader(get(token, "/api/v1/sightings:watch?last_sequence=2").Body).ReadBytes('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		var chunk struct {
+			Result json.RawMessage `json:"result"`
+		}
+		if err = json.Unmarshal(line, &chunk); err != nil {
+			t.Fatalf("decode %s: %v", line, err)
+		}
+		var event ufoV1.WatchResponse
+		if err = protojson.Unmarshal(chunk.Result, &event); err != nil {
+			t.Fatalf("decode %s: %v", chunk.Result, err)
+		}
+		checkWitnesses(t, "gateway Watch as "+token, &event, token == "reporter")
+	}
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// apiKey запись файла ключей
+type apiKey struct {
+	Key     string   `json:"key"`
+	Subject string   `json:"subject"`
+	Roles   []string `json:"roles"`
+}
+
+// APIKeys проверяет статические ключи. Ключи хранятся в памяти только в виде хешей,
+// поэтому поиск по ним не зависит по времени от совпадения префикса
+type APIKeys struct {
+	principals map[[sha256.Size]byte]*Principal
+}
+
+// LoadAPIKeys читает ключи из JSON-файла вида
+//
+//	[{"key": "s3cr3t", "subject": "mulder", "roles": ["reporter", "analyst"]}]
+func LoadAPIKeys(path string) (*APIKeys, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read api keys: %w", err)
+	}
+
+	var keys []apiKey
+	if err = json.Unmarshal(raw, &keys); err != nil {
+		return nil, fmt.Errorf("parse api keys: %w", err)
+	}
+
+	principals := make(map[[sha256.Size]byte]*Principal, len(keys))
+	for i, k := range keys {
+		if k.Key == "" || k.Subject == "" {
+			return nil, fmt.Errorf("api key %d: key and subject are required", i)
+		}
+
+		hash := sha256.Sum256([]byte(k.Key))
+		if _, ok := principals[hash]; ok {
+			return nil, fmt.Errorf("api key %d: duplicate key", i)
+		}
+		principals[hash] = &Principal{Subject: k.Subject, Roles: k.Roles}
+	}
+	if len(principals) == 0 {
+		return nil, errors.New("api keys file is empty")
+	}
+
+	return &APIKeys{principals: principals}, nil
+}
+
+func (a *APIKeys) Authenticate(_ context.Context, token string) (*Principal, error) {
+	p, ok := a.principals[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	return p, nil
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// Роли пользователей UFOService
+const (
+	// RoleReporter сообщает о новых наблюдениях
+	RoleReporter = "reporter"
+	// RoleAnalyst уточняет данные наблюдений
+	RoleAnalyst = "analyst"
+	// RoleAdmin удаляет и восстанавливает наблюдения
+	RoleAdmin = "admin"
+)
+
+// ErrInvalidToken токен не распознан, подделан или просрочен
+var ErrInvalidToken = errors.New("invalid token")
+
+// Principal аутентифицированный вызывающий
+type Principal struct {
+	// Subject идентификатор пользователя или сервиса
+	Subject string
+	// Roles роли, выданные токеном
+	Roles []string
+}
+
+// HasRole сообщает, есть ли у вызывающего хотя бы одна из ролей
+func (p *Principal) HasRole(roles ...string) bool {
+	if p == nil {
+		return false
+	}
+	for _, role := range roles {
+		if slices.Contains(p.Roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticator проверяет bearer-токен и возвращает его владельца или ErrInvalidToken
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*Principal, error)
+}
+
+type principalCtxKey struct{}
+
+// NewContext кладет вызывающего в контекст
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalCtxKey{}, p)
+}
+
+// FromContext возвращает вызывающего из контекста, nil если запрос не аутентифицирован
+func FromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalCtxKey{}).(*Principal)
+	return p
+}
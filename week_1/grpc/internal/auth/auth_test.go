@@ -0,0 +1,106 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/auth"
+)
+
+func TestPolicy(t *testing.T) {
+	policy := auth.Policy{
+		Public:        []string{"/grpc.health.v1.Health/"},
+		Authenticated: []string{"/svc/Get"},
+		Roles:         map[string][]string{"/svc/Delete": {auth.RoleAdmin}},
+	}
+	admin := &auth.Principal{Subject: "skinner", Roles: []string{auth.RoleAdmin}}
+	reporter := &auth.Principal{Subject: "scully", Roles: []string{auth.RoleReporter}}
+	noRoles := &auth.Principal{Subject: "guest"}
+
+	if !policy.IsPublic("/grpc.health.v1.Health/Check") || policy.IsPublic("/svc/Get") {
+		t.Error("IsPublic does not match by prefix")
+	}
+
+	tests := []struct {
+		principal *auth.Principal
+		method    string
+		want      bool
+	}{
+		{admin, "/svc/Delete", true},
+		{reporter, "/svc/Delete", false},
+		{nil, "/svc/Delete", false},
+		{noRoles, "/svc/Get", true},
+		{nil, "/svc/Get", false},
+		// Метод без правила запрещен всем
+		{admin, "/svc/Drop", false},
+		{noRoles, "/svc/Drop", false},
+	}
+	for _, tt := range tests {
+		if got := policy.Allowed(tt.principal, tt.method); got != tt.want {
+			t.Errorf("Allowed(%v, %s) = %t, want %t", tt.principal, tt.method, got, tt.want)
+		}
+	}
+
+	for method, want := range map[string]bool{
+		"/svc/Delete": true, "/svc/Get": true, "/grpc.health.v1.Health/Check": true, "/svc/Drop": false,
+	} {
+		if got := policy.Covers(method); got != want {
+			t.Errorf("Covers(%s) = %t, want %t", method, got, want)
+		}
+	}
+}
+
+func TestAPIKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	keys := `[{"key": "s3cr3t", "subject": "mulder", "roles": ["analyst"]}]`
+	if err := os.WriteFile(path, []byte(keys), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := auth.LoadAPIKeys(path)
+	if err != nil {
+		t.Fatalf("load api keys: %v", err)
+	}
+
+	p, err := a.Authenticate(context.Background(), "s3cr3t")
+	if err != nil || p.Subject != "mulder" || !p.HasRole(auth.RoleAnalyst) {
+		t.Fatalf("authenticate = %+v, %v", p, err)
+	}
+	if _, err = a.Authenticate(context.Background(), "s3cr3"); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Fatalf("wrong key: err = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestChainRoutesByTokenShape(t *testing.T) {
+	jwtCalled, keyCalled := false, false
+	chain := auth.Chain{
+		JWT:     authFunc(func(string) { jwtCalled = true }),
+		APIKeys: authFunc(func(string) { keyCalled = true }),
+	}
+
+	_, _ = chain.Authenticate(context.Background(), "a.b.c")
+	if !jwtCalled || keyCalled {
+		t.Errorf("JWT-shaped token: jwt=%t, api keys=%t", jwtCalled, keyCalled)
+	}
+
+	jwtCalled = false
+	_, _ = chain.Authenticate(context.Background(), "plain-key")
+	if jwtCalled || !keyCalled {
+		t.Errorf("plain token: jwt=%t, api keys=%t", jwtCalled, keyCalled)
+	}
+
+	if _, err := (auth.Chain{}).Authenticate(context.Background(), "a.b.c"); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("empty chain: err = %v, want ErrInvalidToken", err)
+	}
+}
+
+// authFunc Authenticator, который только отмечает вызов
+type authFunc func(token string)
+
+func (f authFunc) Authenticate(_ context.Context, token string) (*auth.Principal, error) {
+	f(token)
+	return &auth.Principal{Subject: token}, nil
+}
@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"context"
+	"strings"
+)
+
+// Chain пробует JWT для токенов в формате JWT и статические ключи для остальных.
+// Любое из полей может быть nil, если соответствующий способ не настроен
+type Chain struct {
+	JWT     Authenticator
+	APIKeys Authenticator
+}
+
+func (c Chain) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	if c.JWT != nil && strings.Count(token, ".") == 2 {
+		return c.JWT.Authenticate(ctx, token)
+	}
+	if c.APIKeys != nil {
+		return c.APIKeys.Authenticate(ctx, token)
+	}
+	return nil, ErrInvalidToken
+}
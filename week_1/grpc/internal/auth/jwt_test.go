@@ -0,0 +1,236 @@
+package auth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/auth"
+)
+
+const (
+	testIssuer   = "https://idp.example.com"
+	testAudience = "ufo-api"
+)
+
+// signer закрытый ключ с kid и алгоритмом подписи
+type signer struct {
+	kid    string
+	method jwt.SigningMethod
+	key    crypto.Signer
+}
+
+func newSigners(t *testing.T) []signer {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return []signer{
+		{kid: "rsa", method: jwt.SigningMethodRS256, key: rsaKey},
+		{kid: "ec", method: jwt.SigningMethodES256, key: ecKey},
+		{kid: "ed", method: jwt.SigningMethodEdDSA, key: edKey},
+	}
+}
+
+func b64(raw []byte) string {
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// jwk публичная часть ключа в формате JWK
+func (s signer) jwk() map[string]string {
+	switch pub := s.key.Public().(type) {
+	case *rsa.PublicKey:
+		return map[string]string{"kty": "RSA", "kid": s.kid, "n": b64(pub.N.Bytes()), "e": b64(big.NewInt(int64(pub.E)).Bytes())}
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		return map[string]string{"kty": "EC", "kid": s.kid, "crv": "P-256", "x": b64(pub.X.FillBytes(make([]byte, size))), "y": b64(pub.Y.FillBytes(make([]byte, size)))}
+	case ed25519.PublicKey:
+		return map[string]string{"kty": "OKP", "kid": s.kid, "crv": "Ed25519", "x": b64(pub)}
+	}
+	panic("unsupported key")
+}
+
+// loadJWT записывает JWKS с ключами signers и загружает его
+func loadJWT(t *testing.T, signers []signer, opts auth.JWTOptions) *auth.JWT {
+	t.Helper()
+
+	set := map[string][]map[string]string{"keys": {}}
+	for _, s := range signers {
+		set["keys"] = append(set["keys"], s.jwk())
+	}
+	raw, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err = os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	j, err := auth.LoadJWT(path, opts)
+	if err != nil {
+		t.Fatalf("load jwks: %v", err)
+	}
+	return j
+}
+
+// validClaims claims, которые проходят все проверки
+func validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"sub":   "mulder",
+		"iss":   testIssuer,
+		"aud":   testAudience,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"roles": []string{auth.RoleAnalyst},
+	}
+}
+
+func sign(t *testing.T, s signer, claims jwt.MapClaims, withKid bool) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(s.method, claims)
+	if withKid {
+		token.Header["kid"] = s.kid
+	}
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return signed
+}
+
+func TestJWTAcceptsEachKeyType(t *testing.T) {
+	signers := newSigners(t)
+	j := loadJWT(t, signers, auth.JWTOptions{Issuer: testIssuer, Audience: testAudience})
+
+	for _, s := range signers {
+		t.Run(s.method.Alg(), func(t *testing.T) {
+			p, err := j.Authenticate(context.Background(), sign(t, s, validClaims(), true))
+			if err != nil {
+				t.Fatalf("authenticate: %v", err)
+			}
+			if p.Subject != "mulder" || !slices.Equal(p.Roles, []string{auth.RoleAnalyst}) {
+				t.Errorf("principal = %+v", p)
+			}
+		})
+	}
+}
+
+func TestJWTRejects(t *testing.T) {
+	signers := newSigners(t)
+	j := loadJWT(t, signers, auth.JWTOptions{Issuer: testIssuer, Audience: testAudience})
+	rsaSigner := signers[0]
+
+	with := func(change func(c jwt.MapClaims)) jwt.MapClaims {
+		c := validClaims()
+		change(c)
+		return c
+	}
+
+	// Ключ с тем же kid, но не из набора
+	foreign := newSigners(t)[0]
+
+	hmac := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims())
+	hmac.Header["kid"] = rsaSigner.kid
+	hs256, err := hmac.SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims())
+	none, err := unsigned.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"expired":          sign(t, rsaSigner, with(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() }), true),
+		"without exp":      sign(t, rsaSigner, with(func(c jwt.MapClaims) { delete(c, "exp") }), true),
+		"not yet valid":    sign(t, rsaSigner, with(func(c jwt.MapClaims) { c["nbf"] = time.Now().Add(time.Hour).Unix() }), true),
+		"wrong issuer":     sign(t, rsaSigner, with(func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }), true),
+		"wrong audience":   sign(t, rsaSigner, with(func(c jwt.MapClaims) { c["aud"] = "other-api" }), true),
+		"missing sub":      sign(t, rsaSigner, with(func(c jwt.MapClaims) { delete(c, "sub") }), true),
+		"missing kid":      sign(t, rsaSigner, validClaims(), false),
+		"unknown kid":      sign(t, signer{kid: "other", method: rsaSigner.method, key: rsaSigner.key}, validClaims(), true),
+		"foreign key":      sign(t, foreign, validClaims(), true),
+		"kid of other key": sign(t, signer{kid: "ec", method: rsaSigner.method, key: rsaSigner.key}, validClaims(), true),
+		"hs256":            hs256,
+		"alg none":         none,
+		"garbage":          "not.a.token",
+	}
+
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := j.Authenticate(context.Background(), token)
+			if !errors.Is(err, auth.ErrInvalidToken) {
+				t.Fatalf("authenticate = %+v, %v, want ErrInvalidToken", p, err)
+			}
+		})
+	}
+}
+
+func TestJWTWithoutKidSingleKey(t *testing.T) {
+	signers := newSigners(t)[:1]
+	j := loadJWT(t, signers, auth.JWTOptions{})
+
+	claims := validClaims()
+	claims["iss"] = "anyone"
+	claims["aud"] = "anything"
+	if _, err := j.Authenticate(context.Background(), sign(t, signers[0], claims, false)); err != nil {
+		t.Fatalf("token without kid and single key: %v", err)
+	}
+}
+
+func TestLoadJWTRejectsBadKeySets(t *testing.T) {
+	tests := map[string]string{
+		"not json":        `{`,
+		"no keys":         `{"keys": []}`,
+		"only enc keys":   `{"keys": [{"kty": "OKP", "crv": "Ed25519", "use": "enc", "x": "` + b64(make([]byte, 32)) + `"}]}`,
+		"duplicate kid":   `{"keys": [{"kty": "OKP", "kid": "a", "crv": "Ed25519", "x": "` + b64(make([]byte, 32)) + `"}, {"kty": "OKP", "kid": "a", "crv": "Ed25519", "x": "` + b64(make([]byte, 32)) + `"}]}`,
+		"symmetric key":   `{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`,
+		"unknown curve":   `{"keys": [{"kty": "EC", "crv": "P-192", "x": "AQ", "y": "AQ"}]}`,
+		"point off curve": `{"keys": [{"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`,
+		"short ed25519":   `{"keys": [{"kty": "OKP", "crv": "Ed25519", "x": "AQ"}]}`,
+		"empty modulus":   `{"keys": [{"kty": "RSA", "n": "", "e": "AQAB"}]}`,
+	}
+
+	for name, jwks := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "jwks.json")
+			if err := os.WriteFile(path, []byte(jwks), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := auth.LoadJWT(path, auth.JWTOptions{}); err == nil {
+				t.Fatal("LoadJWT succeeded, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"slices"
+	"strings"
+)
+
+// Policy правила доступа к методам gRPC по их полному имени. Метод, которого нет
+// ни в одном списке, запрещен всем: новый метод без решения о доступе не откроется случайно
+type Policy struct {
+	// Public префиксы методов, доступных без токена, например health-проверки
+	Public []string
+	// Authenticated методы, доступные любому аутентифицированному вызывающему
+	Authenticated []string
+	// Roles роли, которым разрешен метод
+	Roles map[string][]string
+}
+
+// IsPublic сообщает, можно ли вызывать метод без токена
+func (p Policy) IsPublic(method string) bool {
+	for _, prefix := range p.Public {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allowed сообщает, разрешен ли метод вызывающему
+func (p Policy) Allowed(principal *Principal, method string) bool {
+	if roles, ok := p.Roles[method]; ok {
+		return principal.HasRole(roles...)
+	}
+	return principal != nil && slices.Contains(p.Authenticated, method)
+}
+
+// Covers сообщает, есть ли для метода правило доступа
+func (p Policy) Covers(method string) bool {
+	_, ok := p.Roles[method]
+	return ok || slices.Contains(p.Authenticated, method) || p.IsPublic(method)
+}
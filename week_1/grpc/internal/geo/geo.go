@@ -0,0 +1,103 @@
+// Package geo хранит координаты наблюдений в пространственном индексе и ищет их
+// в круге заданного радиуса или в прямоугольнике широт и долгот.
+//
+// Расстояния считаются по формуле гаверсинусов на сфере со средним радиусом Земли,
+// погрешность относительно эллипсоида WGS 84 не превышает 0,5%.
+package geo
+
+import "math"
+
+// EarthRadiusKm средний радиус Земли
+const EarthRadiusKm = 6371.0088
+
+// Point точка на поверхности Земли в градусах: широта -90..90, долгота -180..180
+type Point struct {
+	Lat, Lon float64
+}
+
+// Box прямоугольник широт и долгот. Если долгота SouthWest больше долготы NorthEast,
+// прямоугольник пересекает 180-й меридиан
+type Box struct {
+	SouthWest, NorthEast Point
+}
+
+// DistanceKm возвращает расстояние между точками по дуге большого круга
+func DistanceKm(a, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat := lat2 - lat1
+	dLon := radians(b.Lon - a.Lon)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Contains сообщает, лежит ли точка в прямоугольнике, включая границы
+func (b Box) Contains(p Point) bool {
+	if p.Lat < b.SouthWest.Lat || p.Lat > b.NorthEast.Lat {
+		return false
+	}
+	if b.crossesAntimeridian() {
+		return p.Lon >= b.SouthWest.Lon || p.Lon <= b.NorthEast.Lon
+	}
+	return p.Lon >= b.SouthWest.Lon && p.Lon <= b.NorthEast.Lon
+}
+
+// Center возвращает середину прямоугольника
+func (b Box) Center() Point {
+	east := b.NorthEast.Lon
+	if b.crossesAntimeridian() {
+		east += 360
+	}
+	return Point{
+		Lat: (b.SouthWest.Lat + b.NorthEast.Lat) / 2,
+		Lon: normalizeLon((b.SouthWest.Lon + east) / 2),
+	}
+}
+
+func (b Box) crossesAntimeridian() bool {
+	return b.SouthWest.Lon > b.NorthEast.Lon
+}
+
+// circleBounds возвращает прямоугольник, описанный вокруг круга. Если круг
+// захватывает полюс или больше половины параллели, берутся все долготы
+func circleBounds(center Point, radiusKm float64) Box {
+	angular := radiusKm / EarthRadiusKm
+	minLat := center.Lat - degrees(angular)
+	maxLat := center.Lat + degrees(angular)
+	if minLat <= -90 || maxLat >= 90 {
+		return Box{
+			SouthWest: Point{Lat: math.Max(minLat, -90), Lon: -180},
+			NorthEast: Point{Lat: math.Min(maxLat, 90), Lon: 180},
+		}
+	}
+
+	// Долгота точки касания круга с меридианом, а не просто радиус на параллели центра
+	ratio := math.Sin(angular) / math.Cos(radians(center.Lat))
+	if angular >= math.Pi/2 || ratio >= 1 {
+		return Box{SouthWest: Point{Lat: minLat, Lon: -180}, NorthEast: Point{Lat: maxLat, Lon: 180}}
+	}
+	dLon := degrees(math.Asin(ratio))
+	return Box{
+		SouthWest: Point{Lat: minLat, Lon: normalizeLon(center.Lon - dLon)},
+		NorthEast: Point{Lat: maxLat, Lon: normalizeLon(center.Lon + dLon)},
+	}
+}
+
+// normalizeLon приводит долготу к -180..180
+func normalizeLon(lon float64) float64 {
+	for lon > 180 {
+		lon -= 360
+	}
+	for lon < -180 {
+		lon += 360
+	}
+	return lon
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,110 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistanceKm(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Point
+		want float64
+	}{
+		{"same point", Point{55.75, 37.62}, Point{55.75, 37.62}, 0},
+		{"Moscow - Saint Petersburg", Point{55.7558, 37.6173}, Point{59.9343, 30.3351}, 634},
+		{"across the antimeridian", Point{0, 179.9}, Point{0, -179.9}, 22.24},
+		{"pole to pole", Point{90, 0}, Point{-90, 0}, math.Pi * EarthRadiusKm},
+		{"any longitude at the pole", Point{90, -120}, Point{90, 45}, 0},
+	}
+	for _, tt := range tests {
+		if got := DistanceKm(tt.a, tt.b); math.Abs(got-tt.want) > 1 {
+			t.Errorf("%s: DistanceKm = %.2f, want %.2f", tt.name, got, tt.want)
+		}
+	}
+}
+
+// destination возвращает точку на расстоянии distanceKm от start по азимуту bearing в градусах
+func destination(start Point, bearing, distanceKm float64) Point {
+	lat1, lon1, theta := radians(start.Lat), radians(start.Lon), radians(bearing)
+	delta := distanceKm / EarthRadiusKm
+
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(delta) + math.Cos(lat1)*math.Sin(delta)*math.Cos(theta))
+	lon2 := lon1 + math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(lat1), math.Cos(delta)-math.Sin(lat1)*math.Sin(lat2))
+	return Point{Lat: degrees(lat2), Lon: normalizeLon(degrees(lon2))}
+}
+
+// expand расширяет прямоугольник на eps градусов, чтобы граница круга не выпадала из-за округления
+func expand(b Box, eps float64) Box {
+	return Box{
+		SouthWest: Point{Lat: b.SouthWest.Lat - eps, Lon: b.SouthWest.Lon - eps},
+		NorthEast: Point{Lat: b.NorthEast.Lat + eps, Lon: b.NorthEast.Lon + eps},
+	}
+}
+
+func TestCircleBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		center    Point
+		radiusKm  float64
+		allLons   bool
+		crossing  bool
+		wantSWLat float64
+		wantNELat float64
+	}{
+		{name: "equator", center: Point{0, 0}, radiusKm: 111.2, wantSWLat: -1, wantNELat: 1},
+		{name: "mid latitude", center: Point{55.75, 37.62}, radiusKm: 300, wantSWLat: 53.05, wantNELat: 58.45},
+		{name: "covers north pole", center: Point{89.5, 10}, radiusKm: 100, allLons: true, wantSWLat: 88.6, wantNELat: 90},
+		{name: "covers south pole", center: Point{-89.9, -170}, radiusKm: 50, allLons: true, wantSWLat: -90, wantNELat: -89.45},
+		{name: "close to the pole", center: Point{85, 0}, radiusKm: 500, allLons: false, wantSWLat: 80.5, wantNELat: 89.5},
+		{name: "east of antimeridian", center: Point{-17, 179.5}, radiusKm: 200, crossing: true, wantSWLat: -18.8, wantNELat: -15.2},
+		{name: "west of antimeridian", center: Point{65, -179.8}, radiusKm: 100, crossing: true, wantSWLat: 64.1, wantNELat: 65.9},
+		{name: "half of the globe", center: Point{10, 0}, radiusKm: 10008, allLons: true, wantSWLat: -80, wantNELat: 90},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box := circleBounds(tt.center, tt.radiusKm)
+
+			if math.Abs(box.SouthWest.Lat-tt.wantSWLat) > 0.1 || math.Abs(box.NorthEast.Lat-tt.wantNELat) > 0.1 {
+				t.Errorf("latitudes = %.2f..%.2f, want %.2f..%.2f", box.SouthWest.Lat, box.NorthEast.Lat, tt.wantSWLat, tt.wantNELat)
+			}
+			if all := box.SouthWest.Lon == -180 && box.NorthEast.Lon == 180; all != tt.allLons {
+				t.Errorf("all longitudes = %t, want %t (box %+v)", all, tt.allLons, box)
+			}
+			if box.crossesAntimeridian() != tt.crossing {
+				t.Errorf("crosses antimeridian = %t, want %t (box %+v)", box.crossesAntimeridian(), tt.crossing, box)
+			}
+			if !box.Contains(tt.center) {
+				t.Errorf("box %+v does not contain the center", box)
+			}
+
+			// Вся окружность лежит внутри прямоугольника
+			loose := expand(box, 1e-9)
+			for bearing := 0.0; bearing < 360; bearing += 0.5 {
+				if p := destination(tt.center, bearing, tt.radiusKm); !loose.Contains(p) {
+					t.Fatalf("circle point %+v at bearing %.1f is outside %+v", p, bearing, box)
+				}
+			}
+		})
+	}
+}
+
+func TestBoxAcrossAntimeridian(t *testing.T) {
+	box := Box{SouthWest: Point{-20, 170}, NorthEast: Point{-10, -170}}
+
+	for _, p := range []Point{{-15, 175}, {-15, -175}, {-15, 180}, {-15, -180}, {-10, 170}} {
+		if !box.Contains(p) {
+			t.Errorf("Contains(%+v) = false", p)
+		}
+	}
+	for _, p := range []Point{{-15, 0}, {-15, 169}, {-15, -169}, {-21, 175}} {
+		if box.Contains(p) {
+			t.Errorf("Contains(%+v) = true", p)
+		}
+	}
+
+	if c := box.Center(); math.Abs(c.Lat+15) > 1e-9 || math.Abs(math.Abs(c.Lon)-180) > 1e-9 {
+		t.Errorf("Center = %+v, want -15, 180", c)
+	}
+}
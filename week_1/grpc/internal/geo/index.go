@@ -0,0 +1,163 @@
+package geo
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// cellSizeDeg размер ячейки сетки в градусах: около 111 км по широте
+const cellSizeDeg = 1.0
+
+// Hit найденная точка и ее расстояние до центра поиска
+type Hit struct {
+	ID         string
+	DistanceKm float64
+}
+
+// cell ячейка сетки широт и долгот
+type cell struct {
+	lat, lon int
+}
+
+func cellOf(p Point) cell {
+	return cell{
+		lat: int(math.Floor(p.Lat / cellSizeDeg)),
+		lon: int(math.Floor(p.Lon / cellSizeDeg)),
+	}
+}
+
+// Index пространственный индекс точек на равномерной сетке широт и долгот.
+// Поиск проверяет только ячейки, пересекающие область. Безопасен для конкурентного использования
+type Index struct {
+	mu     sync.RWMutex
+	cells  map[cell]map[string]Point
+	points map[string]Point
+}
+
+// NewIndex создает пустой индекс
+func NewIndex() *Index {
+	return &Index{
+		cells:  make(map[cell]map[string]Point),
+		points: make(map[string]Point),
+	}
+}
+
+// Put сохраняет точку документа, заменяя прежнюю
+func (idx *Index) Put(id string, p Point) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.removeLocked(id)
+	c := cellOf(p)
+	points, ok := idx.cells[c]
+	if !ok {
+		points = make(map[string]Point)
+		idx.cells[c] = points
+	}
+	points[id] = p
+	idx.points[id] = p
+}
+
+// Remove убирает точку документа, отсутствующий документ игнорируется
+func (idx *Index) Remove(id string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.removeLocked(id)
+}
+
+func (idx *Index) removeLocked(id string) {
+	p, ok := idx.points[id]
+	if !ok {
+		return
+	}
+
+	c := cellOf(p)
+	delete(idx.cells[c], id)
+	if len(idx.cells[c]) == 0 {
+		delete(idx.cells, c)
+	}
+	delete(idx.points, id)
+}
+
+// Len возвращает число проиндексированных точек
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return len(idx.points)
+}
+
+// WithinRadius находит точки не дальше radiusKm от центра, ближайшие первыми
+func (idx *Index) WithinRadius(center Point, radiusKm float64) []Hit {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var hits []Hit
+	idx.scanLocked(circleBounds(center, radiusKm), func(id string, p Point) {
+		if d := DistanceKm(center, p); d <= radiusKm {
+			hits = append(hits, Hit{ID: id, DistanceKm: d})
+		}
+	})
+	sortHits(hits)
+	return hits
+}
+
+// WithinBox находит точки внутри прямоугольника, ближайшие к его центру первыми
+func (idx *Index) WithinBox(box Box) []Hit {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	center := box.Center()
+	var hits []Hit
+	idx.scanLocked(box, func(id string, p Point) {
+		if box.Contains(p) {
+			hits = append(hits, Hit{ID: id, DistanceKm: DistanceKm(center, p)})
+		}
+	})
+	sortHits(hits)
+	return hits
+}
+
+// scanLocked передает в fn точки из ячеек, пересекающих box. Если ячеек больше,
+// чем точек в индексе, дешевле просмотреть все точки
+func (idx *Index) scanLocked(box Box, fn func(id string, p Point)) {
+	sw, ne := cellOf(box.SouthWest), cellOf(box.NorthEast)
+	lonRanges := [][2]int{{sw.lon, ne.lon}}
+	if box.crossesAntimeridian() {
+		east, west := cellOf(Point{Lon: 180}), cellOf(Point{Lon: -180})
+		lonRanges = [][2]int{{sw.lon, east.lon}, {west.lon, ne.lon}}
+	}
+
+	cellCount := 0
+	for _, r := range lonRanges {
+		cellCount += (r[1] - r[0] + 1) * (ne.lat - sw.lat + 1)
+	}
+	if cellCount > len(idx.points) {
+		for id, p := range idx.points {
+			fn(id, p)
+		}
+		return
+	}
+
+	for lat := sw.lat; lat <= ne.lat; lat++ {
+		for _, r := range lonRanges {
+			for lon := r[0]; lon <= r[1]; lon++ {
+				for id, p := range idx.cells[cell{lat: lat, lon: lon}] {
+					fn(id, p)
+				}
+			}
+		}
+	}
+}
+
+// sortHits упорядочивает по расстоянию, при равенстве - по id
+func sortHits(hits []Hit) {
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].DistanceKm != hits[j].DistanceKm {
+			return hits[i].DistanceKm < hits[j].DistanceKm
+		}
+		return hits[i].ID < hits[j].ID
+	})
+}
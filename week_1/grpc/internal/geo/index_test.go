@@ -0,0 +1,132 @@
+package geo
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// randomIndex заполняет индекс n точками, равномерно разбросанными по сфере
+func randomIndex(n int) (*Index, map[string]Point) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	idx := NewIndex()
+	points := make(map[string]Point, n)
+	for i := range n {
+		p := Point{
+			Lat: degrees(math.Asin(2*rnd.Float64() - 1)),
+			Lon: rnd.Float64()*360 - 180,
+		}
+		id := fmt.Sprintf("p%05d", i)
+		idx.Put(id, p)
+		points[id] = p
+	}
+	return idx, points
+}
+
+// bruteForce находит точки в радиусе полным перебором
+func bruteForce(points map[string]Point, center Point, radiusKm float64) []string {
+	var ids []string
+	for id, p := range points {
+		if DistanceKm(center, p) <= radiusKm {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func TestWithinRadiusMatchesBruteForce(t *testing.T) {
+	// Точек больше, чем ячеек в прямоугольниках запросов, поэтому проверяется обход сетки
+	idx, points := randomIndex(50000)
+
+	tests := []struct {
+		name     string
+		center   Point
+		radiusKm float64
+	}{
+		{"mid latitude", Point{55.75, 37.62}, 800},
+		{"north pole", Point{89.9, 0}, 600},
+		{"south pole", Point{-88, 120}, 500},
+		{"east of antimeridian", Point{10, 179.8}, 700},
+		{"west of antimeridian", Point{-45, -179.5}, 900},
+		{"high latitude across antimeridian", Point{75, 179}, 800},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits := idx.WithinRadius(tt.center, tt.radiusKm)
+
+			want := bruteForce(points, tt.center, tt.radiusKm)
+			got := make([]string, 0, len(hits))
+			for _, h := range hits {
+				got = append(got, h.ID)
+			}
+			slices.Sort(got)
+			if len(want) == 0 || !slices.Equal(got, want) {
+				t.Fatalf("found %d points, brute force %d", len(got), len(want))
+			}
+
+			// Ближайшие первыми
+			if !slices.IsSortedFunc(hits, func(a, b Hit) int {
+				return cmp.Compare(a.DistanceKm, b.DistanceKm)
+			}) {
+				t.Error("hits are not ordered by distance")
+			}
+		})
+	}
+}
+
+func TestWithinRadiusOrdering(t *testing.T) {
+	idx := NewIndex()
+	center := Point{0, 179.9}
+	idx.Put("far", Point{0, -179.0})   // ~111 км через 180-й меридиан
+	idx.Put("near", Point{0, -179.95}) // ~17 км
+	idx.Put("tie-b", Point{0.5, 179.9})
+	idx.Put("tie-a", Point{-0.5, 179.9})
+	idx.Put("outside", Point{0, 170})
+
+	var got []string
+	for _, h := range idx.WithinRadius(center, 200) {
+		got = append(got, h.ID)
+	}
+	if want := []string{"near", "tie-a", "tie-b", "far"}; !slices.Equal(got, want) {
+		t.Errorf("WithinRadius = %v, want %v", got, want)
+	}
+}
+
+func TestWithinBoxAcrossAntimeridian(t *testing.T) {
+	idx := NewIndex()
+	idx.Put("center", Point{-15, 180})
+	idx.Put("east", Point{-15, -172})
+	idx.Put("west", Point{-15, 173})
+	idx.Put("outside", Point{-15, 0})
+
+	var got []string
+	for _, h := range idx.WithinBox(Box{SouthWest: Point{-20, 170}, NorthEast: Point{-10, -170}}) {
+		got = append(got, h.ID)
+	}
+	if want := []string{"center", "west", "east"}; !slices.Equal(got, want) {
+		t.Errorf("WithinBox = %v, want %v", got, want)
+	}
+}
+
+func TestIndexPutMovesPoint(t *testing.T) {
+	idx := NewIndex()
+	idx.Put("a", Point{10, 10})
+	idx.Put("a", Point{-10, -10})
+
+	if hits := idx.WithinRadius(Point{10, 10}, 10); len(hits) != 0 {
+		t.Errorf("old position still found: %v", hits)
+	}
+	if hits := idx.WithinRadius(Point{-10, -10}, 10); len(hits) != 1 {
+		t.Errorf("new position not found: %v", hits)
+	}
+
+	idx.Remove("a")
+	if idx.Len() != 0 || len(idx.cells) != 0 {
+		t.Errorf("index not empty after remove: %d points, %d cells", idx.Len(), len(idx.cells))
+	}
+}
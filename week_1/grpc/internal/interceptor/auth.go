@@ -0,0 +1,87 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationKey = "authorization"
+	bearerScheme     = "bearer"
+)
+
+// UnaryAuth проверяет bearer-токен из метаданных authorization, кладет вызывающего
+// в контекст и отклоняет вызовы, не разрешенные политикой
+func UnaryAuth(logger *slog.Logger, authn auth.Authenticator, policy auth.Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorize(ctx, logger, authn, policy, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuth то же, что UnaryAuth, для стримов
+func StreamAuth(logger *slog.Logger, authn auth.Authenticator, policy auth.Policy) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), logger, authn, policy, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func authorize(ctx context.Context, logger *slog.Logger, authn auth.Authenticator, policy auth.Policy, method string) (context.Context, error) {
+	if policy.IsPublic(method) {
+		return ctx, nil
+	}
+
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	principal, err := authn.Authenticate(ctx, token)
+	if err != nil {
+		// Причину пишем только в лог, клиенту незачем знать, чем именно плох токен
+		logger.WarnContext(ctx, "authentication failed",
+			slog.String("method", method),
+			slog.String("request_id", RequestIDFromContext(ctx)),
+			slog.String("error", err.Error()),
+		)
+		if errors.Is(err, auth.ErrInvalidToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return nil, status.Error(codes.Internal, "authentication failed")
+	}
+
+	if !policy.Allowed(principal, method) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not allowed to call %s", principal.Subject, method)
+	}
+
+	return auth.NewContext(ctx, principal), nil
+}
+
+// bearerToken достает токен из заголовка "authorization: Bearer <token>"
+func bearerToken(ctx context.Context) (string, error) {
+	values := metadata.ValueFromIncomingContext(ctx, authorizationKey)
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "authorization token is required")
+	}
+
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, bearerScheme) || strings.TrimSpace(token) == "" {
+		return "", status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+	}
+	return strings.TrimSpace(token), nil
+}
@@ -0,0 +1,49 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryDeadline ставит таймаут defaultTimeout запросам, пришедшим без дедлайна,
+// и сокращает до maxTimeout слишком далекие дедлайны
+func UnaryDeadline(defaultTimeout, maxTimeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, cancel := withDeadline(ctx, defaultTimeout, maxTimeout)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+// StreamDeadline ограничивает таймаутом timeout стримы из methods, и без дедлайна
+// клиента, и с более далеким. Остальные стримы не ограничиваются: Watch живет,
+// пока клиент подписан
+func StreamDeadline(timeout time.Duration, methods ...string) grpc.StreamServerInterceptor {
+	bounded := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		bounded[method] = true
+	}
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !bounded[info.FullMethod] {
+			return handler(srv, ss)
+		}
+
+		ctx, cancel := withDeadline(ss.Context(), timeout, timeout)
+		defer cancel()
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func withDeadline(ctx context.Context, defaultTimeout, maxTimeout time.Duration) (context.Context, context.CancelFunc) {
+	timeout := defaultTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	if timeout > maxTimeout {
+		timeout = maxTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
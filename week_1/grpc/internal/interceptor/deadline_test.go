@@ -0,0 +1,97 @@
+package interceptor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/interceptor"
+	"google.golang.org/grpc"
+)
+
+// remaining возвращает, сколько осталось до дедлайна контекста
+func remaining(t *testing.T, ctx context.Context) time.Duration {
+	t.Helper()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("handler got no deadline")
+	}
+	return time.Until(deadline)
+}
+
+func TestUnaryDeadline(t *testing.T) {
+	const (
+		defaultTimeout = 10 * time.Second
+		maxTimeout     = time.Minute
+		// slack запас на время между вызовом и проверкой
+		slack = time.Second
+	)
+	tests := []struct {
+		name   string
+		client time.Duration
+		want   time.Duration
+	}{
+		{name: "default without deadline", want: defaultTimeout},
+		{name: "client deadline kept", client: 3 * time.Second, want: 3 * time.Second},
+		{name: "client deadline above default kept", client: 30 * time.Second, want: 30 * time.Second},
+		{name: "capped at max", client: time.Hour, want: maxTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.client > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.client)
+				defer cancel()
+			}
+
+			var got time.Duration
+			_, err := interceptor.UnaryDeadline(defaultTimeout, maxTimeout)(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ any) (any, error) {
+				got = remaining(t, ctx)
+				return nil, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got > tt.want || got < tt.want-slack {
+				t.Errorf("handler deadline in %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamDeadline(t *testing.T) {
+	const (
+		timeout      = time.Minute
+		importMethod = "/ufo.v1.UFOService/ImportSightings"
+	)
+	deadline := interceptor.StreamDeadline(timeout, importMethod)
+
+	run := func(ctx context.Context, method string) context.Context {
+		var got context.Context
+		err := deadline(nil, &fakeStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: method}, func(_ any, ss grpc.ServerStream) error {
+			got = ss.Context()
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	if got := remaining(t, run(context.Background(), importMethod)); got > timeout || got < timeout-time.Second {
+		t.Errorf("import without deadline: deadline in %s, want %s", got, timeout)
+	}
+
+	far, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	if got := remaining(t, run(far, importMethod)); got > timeout {
+		t.Errorf("import with a far deadline: deadline in %s, want at most %s", got, timeout)
+	}
+
+	if _, ok := run(context.Background(), "/ufo.v1.UFOService/Watch").Deadline(); ok {
+		t.Error("Watch got a deadline, want unbounded")
+	}
+}
@@ -0,0 +1,82 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/metrics"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryLogger пишет по строке на каждый вызов: метод, код ответа, длительность и идентификатор запроса
+func UnaryLogger(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, logger, info.FullMethod, metrics.Unary, start, err)
+		return resp, err
+	}
+}
+
+// StreamLogger пишет строку при завершении стрима
+func StreamLogger(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(ss.Context(), logger, info.FullMethod, streamKind(info), start, err)
+		return err
+	}
+}
+
+func logCall(ctx context.Context, logger *slog.Logger, method, kind string, start time.Time, err error) {
+	st := status.Convert(err)
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("kind", kind),
+		slog.String("code", st.Code().String()),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if id := RequestIDFromContext(ctx); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()))
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		attrs = append(attrs, slog.String("peer", p.Addr.String()))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", st.Message()))
+	}
+
+	logger.LogAttrs(ctx, levelFor(st.Code()), "grpc call", attrs...)
+}
+
+// levelFor отделяет ошибки клиента от сбоев сервера, чтобы последние было видно в логах
+func levelFor(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK:
+		return slog.LevelInfo
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable, codes.Unimplemented:
+		return slog.LevelError
+	default:
+		return slog.LevelWarn
+	}
+}
+
+func streamKind(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && info.IsServerStream:
+		return metrics.BidiStream
+	case info.IsClientStream:
+		return metrics.ClientStream
+	default:
+		return metrics.ServerStream
+	}
+}
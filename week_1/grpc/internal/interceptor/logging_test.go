@@ -0,0 +1,69 @@
+package interceptor_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/interceptor"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryLogger(t *testing.T) {
+	tests := []struct {
+		err   error
+		code  string
+		level string
+	}{
+		{nil, "OK", "INFO"},
+		{status.Error(codes.NotFound, "sighting not found"), "NotFound", "WARN"},
+		{status.Error(codes.Internal, "storage error"), "Internal", "ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			logger, logs := bufferLogger()
+			info := &grpc.UnaryServerInfo{FullMethod: "/ufo.v1.UFOService/Get"}
+
+			_, err := interceptor.UnaryLogger(logger)(context.Background(), nil, info, func(context.Context, any) (any, error) {
+				return nil, tt.err
+			})
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+
+			var record map[string]any
+			if err = json.Unmarshal(logs.Bytes(), &record); err != nil {
+				t.Fatalf("decode log %q: %v", logs, err)
+			}
+			if record["code"] != tt.code || record["level"] != tt.level || record["method"] != info.FullMethod || record["kind"] != "unary" {
+				t.Errorf("log record = %v, want code %s at %s", record, tt.code, tt.level)
+			}
+			if _, ok := record["duration"]; !ok {
+				t.Errorf("log record has no duration: %v", record)
+			}
+		})
+	}
+}
+
+func TestStreamLogger(t *testing.T) {
+	logger, logs := bufferLogger()
+	info := &grpc.StreamServerInfo{FullMethod: "/ufo.v1.UFOService/ImportSightings", IsClientStream: true}
+
+	err := interceptor.StreamLogger(logger)(nil, &fakeStream{ctx: context.Background()}, info, func(any, grpc.ServerStream) error {
+		return status.Error(codes.Canceled, "canceled")
+	})
+	if status.Code(err) != codes.Canceled {
+		t.Fatalf("err = %v, want Canceled", err)
+	}
+
+	var record map[string]any
+	if err = json.Unmarshal(logs.Bytes(), &record); err != nil {
+		t.Fatalf("decode log %q: %v", logs, err)
+	}
+	if record["code"] != "Canceled" || record["kind"] != "client_stream" {
+		t.Errorf("log record = %v, want Canceled client stream", record)
+	}
+}
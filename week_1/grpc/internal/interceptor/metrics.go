@@ -0,0 +1,30 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryMetrics считает вызовы, их коды ответа, длительность и число выполняющихся.
+// Стоит в начале цепочки, чтобы учитывать и отклоненные аутентификацией и лимитами вызовы
+func UnaryMetrics(m *metrics.GRPC) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		done := m.Start(metrics.Unary, info.FullMethod)
+		resp, err := handler(ctx, req)
+		done(status.Code(err))
+		return resp, err
+	}
+}
+
+// StreamMetrics то же, что UnaryMetrics, для стримов: длительность - время жизни стрима
+func StreamMetrics(m *metrics.GRPC) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		done := m.Start(streamKind(info), info.FullMethod)
+		err := handler(srv, ss)
+		done(status.Code(err))
+		return err
+	}
+}
@@ -0,0 +1,88 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/auth"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/ratelimit"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// forwardedForKey адрес исходного клиента, который проставляет REST-шлюз
+const forwardedForKey = "x-forwarded-for"
+
+// UnaryRateLimit отклоняет вызовы сверх лимита с кодом ResourceExhausted и
+// google.rpc.RetryInfo, подсказывающим, через сколько повторить. Должен стоять
+// после аутентификации, чтобы лимит считался по пользователю, а не по адресу
+func UnaryRateLimit(limiter *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if ok, delay := limiter.Allow(info.FullMethod, ClientKey(ctx), time.Now()); !ok {
+			return nil, rateLimited(info.FullMethod, delay)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimit ограничивает частоту открытия стримов
+func StreamRateLimit(limiter *ratelimit.Limiter) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if ok, delay := limiter.Allow(info.FullMethod, ClientKey(ss.Context()), time.Now()); !ok {
+			return rateLimited(info.FullMethod, delay)
+		}
+		return handler(srv, ss)
+	}
+}
+
+func rateLimited(method string, delay time.Duration) error {
+	st, err := status.New(codes.ResourceExhausted, fmt.Sprintf("rate limit for %s exceeded, retry in %s", method, delay.Round(time.Millisecond))).
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	if err != nil {
+		return status.Errorf(codes.ResourceExhausted, "rate limit for %s exceeded", method)
+	}
+	return st.Err()
+}
+
+// ClientKey определяет, чей это вызов, для лимитов и квот: аутентифицированный
+// пользователь, а без аутентификации - IP-адрес клиента
+func ClientKey(ctx context.Context) string {
+	if p := auth.FromContext(ctx); p != nil {
+		return "principal:" + p.Subject
+	}
+	return "peer:" + peerIP(ctx)
+}
+
+// peerIP возвращает IP клиента. Запросам с loopback, то есть от REST-шлюза,
+// берем адрес из x-forwarded-for, иначе все браузерные клиенты делили бы одну корзину
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		if forwarded := metadata.ValueFromIncomingContext(ctx, forwardedForKey); len(forwarded) > 0 {
+			// Шлюз дописывает адрес, с которого пришел запрос, в конец списка.
+			// Все, что левее, прислал сам клиент, и этому верить нельзя
+			list := strings.Split(forwarded[len(forwarded)-1], ",")
+			if last := strings.TrimSpace(list[len(list)-1]); last != "" {
+				return last
+			}
+		}
+	}
+	return host
+}
@@ -0,0 +1,80 @@
+package interceptor_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/interceptor"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const secret = "password=hunter2"
+
+// bufferLogger пишет логи в JSON в буфер, чтобы тест мог их прочитать
+func bufferLogger() (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	return slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})), &buf
+}
+
+// fakeStream серверный стрим с заданным контекстом
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
+
+// checkRecovered проверяет, что паника стала Internal без подробностей для клиента
+func checkRecovered(t *testing.T, err error, logs *bytes.Buffer) {
+	t.Helper()
+
+	st := status.Convert(err)
+	if st.Code() != codes.Internal {
+		t.Fatalf("code = %s, want Internal", st.Code())
+	}
+	if strings.Contains(st.Message(), secret) {
+		t.Errorf("panic value leaked to the client: %q", st.Message())
+	}
+	if !strings.Contains(logs.String(), secret) || !strings.Contains(logs.String(), "panic in grpc handler") {
+		t.Errorf("panic is not logged: %s", logs)
+	}
+}
+
+func TestUnaryRecovery(t *testing.T) {
+	logger, logs := bufferLogger()
+	info := &grpc.UnaryServerInfo{FullMethod: "/ufo.v1.UFOService/Get"}
+
+	resp, err := interceptor.UnaryRecovery(logger)(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		panic(secret)
+	})
+	if resp != nil {
+		t.Errorf("resp = %v, want nil", resp)
+	}
+	checkRecovered(t, err, logs)
+
+	// Без паники ответ и ошибка проходят как есть
+	want := status.Error(codes.NotFound, "not found")
+	_, err = interceptor.UnaryRecovery(logger)(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		return nil, want
+	})
+	if err != want {
+		t.Errorf("err = %v, want %v", err, want)
+	}
+}
+
+func TestStreamRecovery(t *testing.T) {
+	logger, logs := bufferLogger()
+	info := &grpc.StreamServerInfo{FullMethod: "/ufo.v1.UFOService/Watch", IsServerStream: true}
+
+	err := interceptor.StreamRecovery(logger)(nil, &fakeStream{ctx: context.Background()}, info, func(any, grpc.ServerStream) error {
+		panic(secret)
+	})
+	checkRecovered(t, err, logs)
+}
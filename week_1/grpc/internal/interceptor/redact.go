@@ -0,0 +1,47 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// Redactor убирает из ответа данные, которые вызывающему видеть не положено.
+// Возвращает сообщение для отправки: исходное, если скрывать нечего, или измененную копию.
+// Исходное сообщение менять нельзя: его могут разделять несколько вызовов
+type Redactor func(ctx context.Context, resp proto.Message) proto.Message
+
+// UnaryRedact пропускает ответ через redact. Ставится после аутентификации,
+// чтобы redact видел вызывающего в контексте
+func UnaryRedact(redact Redactor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		if msg, ok := resp.(proto.Message); ok {
+			return redact(ctx, msg), nil
+		}
+		return resp, nil
+	}
+}
+
+// StreamRedact то же, что UnaryRedact, для каждого сообщения, отправленного сервером
+func StreamRedact(redact Redactor) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &redactingStream{ServerStream: ss, redact: redact})
+	}
+}
+
+type redactingStream struct {
+	grpc.ServerStream
+	redact Redactor
+}
+
+func (s *redactingStream) SendMsg(m any) error {
+	if msg, ok := m.(proto.Message); ok {
+		m = s.redact(s.Context(), msg)
+	}
+	return s.ServerStream.SendMsg(m)
+}
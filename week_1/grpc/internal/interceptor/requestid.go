@@ -0,0 +1,82 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDKey заголовок метаданных, в котором клиент передает идентификатор запроса
+// и сервер возвращает его обратно
+const RequestIDKey = "x-request-id"
+
+// maxRequestIDLen ограничивает длину присланного клиентом идентификатора, он попадает в логи
+const maxRequestIDLen = 128
+
+type requestIDCtxKey struct{}
+
+// RequestIDFromContext возвращает идентификатор текущего запроса или пустую строку
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDCtxKey{}).(string)
+	return id
+}
+
+// UnaryRequestID берет идентификатор запроса из метаданных или генерирует новый,
+// кладет его в контекст и возвращает клиенту в заголовке ответа
+func UnaryRequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = withRequestID(ctx)
+		return handler(ctx, req)
+	}
+}
+
+// StreamRequestID то же, что UnaryRequestID, для стримов
+func StreamRequestID() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &contextStream{ServerStream: ss, ctx: withRequestID(ss.Context())})
+	}
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	id := incomingRequestID(ctx)
+	if id == "" {
+		id = uuid.NewString()
+	}
+
+	// Заголовок уйдет клиенту вместе с первым ответом; ошибка возможна,
+	// только если заголовки уже отправлены, а здесь обработчик еще не запущен
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+	return context.WithValue(ctx, requestIDCtxKey{}, id)
+}
+
+// incomingRequestID возвращает присланный клиентом идентификатор, если он пригоден для логов
+func incomingRequestID(ctx context.Context) string {
+	values := metadata.ValueFromIncomingContext(ctx, RequestIDKey)
+	if len(values) == 0 {
+		return ""
+	}
+
+	id := values[0]
+	if len(id) > maxRequestIDLen {
+		return ""
+	}
+	for _, r := range id {
+		if r < 0x21 || r > 0x7e {
+			return ""
+		}
+	}
+	return id
+}
+
+// contextStream подменяет контекст стрима, чтобы обработчик видел значения,
+// добавленные интерцепторами
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
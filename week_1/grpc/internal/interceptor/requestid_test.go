@@ -0,0 +1,93 @@
+package interceptor_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/interceptor"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// headerStream транспортный стрим, который запоминает заголовки ответа
+type headerStream struct {
+	header metadata.MD
+}
+
+func (s *headerStream) Method() string { return "/ufo.v1.UFOService/Get" }
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *headerStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *headerStream) SetTrailer(metadata.MD) error { return nil }
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming []string
+		// keep клиентский идентификатор должен сохраниться
+		keep bool
+	}{
+		{name: "valid", incoming: []string{"req-42_ABC.xyz"}, keep: true},
+		{name: "max length", incoming: []string{strings.Repeat("a", 128)}, keep: true},
+		{name: "missing"},
+		{name: "empty", incoming: []string{""}},
+		{name: "oversize", incoming: []string{strings.Repeat("a", 129)}},
+		{name: "space", incoming: []string{"req 42"}},
+		{name: "newline", incoming: []string{"req\n42"}},
+		{name: "non-ascii", incoming: []string{"запрос"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &headerStream{}
+			ctx := grpc.NewContextWithServerTransportStream(context.Background(), transport)
+			if tt.incoming != nil {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(interceptor.RequestIDKey, tt.incoming[0]))
+			}
+
+			var got string
+			_, err := interceptor.UnaryRequestID()(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ any) (any, error) {
+				got = interceptor.RequestIDFromContext(ctx)
+				return nil, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.keep && got != tt.incoming[0] {
+				t.Errorf("request id = %q, want client's %q", got, tt.incoming[0])
+			}
+			if !tt.keep && uuid.Validate(got) != nil {
+				t.Errorf("request id = %q, want a generated UUID", got)
+			}
+			if echoed := transport.header.Get(interceptor.RequestIDKey); len(echoed) != 1 || echoed[0] != got {
+				t.Errorf("response header = %q, want %q", echoed, got)
+			}
+		})
+	}
+}
+
+func TestStreamRequestID(t *testing.T) {
+	transport := &headerStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), transport)
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(interceptor.RequestIDKey, "stream-1"))
+
+	var got string
+	err := interceptor.StreamRequestID()(nil, &fakeStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(_ any, ss grpc.ServerStream) error {
+		got = interceptor.RequestIDFromContext(ss.Context())
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "stream-1" || transport.header.Get(interceptor.RequestIDKey)[0] != "stream-1" {
+		t.Errorf("request id = %q, header = %v, want stream-1", got, transport.header)
+	}
+}
@@ -0,0 +1,58 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/validator"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// UnaryValidator отклоняет запросы, нарушающие правила validate.rules из .proto,
+// с кодом InvalidArgument и деталями google.rpc.BadRequest
+func UnaryValidator() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := validate(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamValidator проверяет запрос серверных стримов. Сообщения клиентских стримов
+// обработчик проверяет сам, чтобы одна ошибка не обрывала весь стрим
+func StreamValidator() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if info.IsClientStream {
+			return handler(srv, ss)
+		}
+		return handler(srv, &validatingStream{ServerStream: ss})
+	}
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validate(m)
+}
+
+func validate(req any) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	violations := validator.Validate(msg)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return validator.BadRequest(fmt.Sprintf("invalid %s: %s: %s",
+		msg.ProtoReflect().Descriptor().Name(), violations[0].GetField(), violations[0].GetDescription()), violations)
+}
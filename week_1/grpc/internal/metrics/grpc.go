@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+)
+
+// Типы вызовов для метки grpc_type
+const (
+	Unary        = "unary"
+	ClientStream = "client_stream"
+	ServerStream = "server_stream"
+	BidiStream   = "bidi_stream"
+)
+
+// GRPC метрики вызовов gRPC-сервера. Имена и метки совпадают с привычными
+// по go-grpc-prometheus, чтобы подошли готовые дашборды
+type GRPC struct {
+	started  *prometheus.CounterVec
+	handled  *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+}
+
+// NewGRPC создает метрики и регистрирует их в reg
+func NewGRPC(reg prometheus.Registerer) *GRPC {
+	m := &GRPC{
+		started: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_started_total",
+			Help: "Total number of RPCs started on the server.",
+		}, []string{"grpc_type", "grpc_service", "grpc_method"}),
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "Total number of RPCs completed on the server, regardless of success or failure.",
+		}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "Histogram of response latency of RPCs handled by the server.",
+			Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}, []string{"grpc_type", "grpc_service", "grpc_method"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "grpc_server_in_flight",
+			Help: "Number of RPCs currently being handled by the server.",
+		}, []string{"grpc_type", "grpc_service", "grpc_method"}),
+	}
+
+	reg.MustRegister(m.started, m.handled, m.latency, m.inFlight)
+	return m
+}
+
+// Start отмечает начало вызова. Возвращенную функцию нужно вызвать по его завершении
+func (m *GRPC) Start(callType, fullMethod string) func(code codes.Code) {
+	service, method := splitMethod(fullMethod)
+	start := time.Now()
+
+	m.started.WithLabelValues(callType, service, method).Inc()
+	inFlight := m.inFlight.WithLabelValues(callType, service, method)
+	inFlight.Inc()
+
+	return func(code codes.Code) {
+		inFlight.Dec()
+		m.handled.WithLabelValues(callType, service, method, code.String()).Inc()
+		m.latency.WithLabelValues(callType, service, method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// splitMethod делит "/ufo.v1.UFOService/Create" на сервис и метод
+func splitMethod(fullMethod string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "unknown", "unknown"
+	}
+	return service, method
+}
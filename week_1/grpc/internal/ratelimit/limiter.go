@@ -0,0 +1,115 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Limit параметры корзины токенов: Rate запросов в секунду в среднем
+// и до Burst запросов подряд после простоя
+type Limit struct {
+	Rate  rate.Limit
+	Burst int
+}
+
+// ParseLimit разбирает лимит в формате "rate:burst", например "5:10"
+func ParseLimit(spec string) (Limit, error) {
+	rawRate, rawBurst, ok := strings.Cut(spec, ":")
+	if !ok {
+		return Limit{}, fmt.Errorf("limit %q must look like rate:burst", spec)
+	}
+
+	r, err := strconv.ParseFloat(rawRate, 64)
+	if err != nil || r <= 0 {
+		return Limit{}, fmt.Errorf("limit %q: rate must be a positive number", spec)
+	}
+	burst, err := strconv.Atoi(rawBurst)
+	if err != nil || burst <= 0 {
+		return Limit{}, fmt.Errorf("limit %q: burst must be a positive integer", spec)
+	}
+
+	return Limit{Rate: rate.Limit(r), Burst: burst}, nil
+}
+
+// Limiter ограничивает частоту вызовов: у каждого клиента своя корзина токенов
+// на каждый метод. Методы без лимита не ограничиваются
+type Limiter struct {
+	limits map[string]Limit
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*bucket
+}
+
+type bucketKey struct {
+	method string
+	client string
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewLimiter создает ограничитель с лимитами по полному имени метода
+func NewLimiter(limits map[string]Limit) *Limiter {
+	return &Limiter{
+		limits:  limits,
+		buckets: make(map[bucketKey]*bucket),
+	}
+}
+
+// Allow списывает токен клиента для метода. Если токенов нет, возвращает false
+// и через сколько появится следующий
+func (l *Limiter) Allow(method, client string, now time.Time) (bool, time.Duration) {
+	limit, ok := l.limits[method]
+	if !ok {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := bucketKey{method: method, client: client}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(limit.Rate, limit.Burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+
+	reservation := b.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		// Запрос отклоняем, поэтому токен возвращаем в корзину
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// Run удаляет корзины клиентов, которые не обращались дольше idle: к этому
+// моменту корзина все равно полна, и новая ничем от нее не отличается
+func (l *Limiter) Run(ctx context.Context, idle time.Duration) {
+	ticker := time.NewTicker(idle)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			l.mu.Lock()
+			for key, b := range l.buckets {
+				if now.Sub(b.lastSeen) > idle {
+					delete(l.buckets, key)
+				}
+			}
+			l.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// DailyQuota ограничивает число вызовов клиента за сутки по UTC
+type DailyQuota struct {
+	limit int
+
+	mu   sync.Mutex
+	day  time.Time
+	used map[string]int
+}
+
+// NewDailyQuota создает квоту на limit вызовов в сутки
+func NewDailyQuota(limit int) *DailyQuota {
+	return &DailyQuota{
+		limit: limit,
+		used:  make(map[string]int),
+	}
+}
+
+// Limit возвращает размер суточной квоты
+func (q *DailyQuota) Limit() int {
+	return q.limit
+}
+
+// Take списывает вызов из квоты клиента. Если квота исчерпана, возвращает false
+// и время до начала следующих суток, когда квота обновится
+func (q *DailyQuota) Take(client string, now time.Time) (bool, time.Duration) {
+	return q.TakeN(client, 1, now)
+}
+
+// TakeN списывает n вызовов разом: либо все, либо, если квоты на все не хватает, ни одного
+func (q *DailyQuota) TakeN(client string, n int, now time.Time) (bool, time.Duration) {
+	now = now.UTC()
+	day := now.Truncate(24 * time.Hour)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.rollover(day)
+	if q.used[client]+n > q.limit {
+		return false, day.Add(24 * time.Hour).Sub(now)
+	}
+	q.used[client] += n
+	return true, 0
+}
+
+// Refund возвращает в квоту n списанных вызовов, которые ничего не сделали.
+// Вызовы, списанные в прошлые сутки, уже сгорели и не возвращаются
+func (q *DailyQuota) Refund(client string, n int, now time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.rollover(day)
+	q.used[client] = max(0, q.used[client]-n)
+	if q.used[client] == 0 {
+		delete(q.used, client)
+	}
+}
+
+// rollover обнуляет счетчики всех клиентов с началом новых суток, вызывается под q.mu
+func (q *DailyQuota) rollover(day time.Time) {
+	if !day.Equal(q.day) {
+		q.day = day
+		clear(q.used)
+	}
+}
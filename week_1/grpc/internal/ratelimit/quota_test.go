@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailyQuota(t *testing.T) {
+	q := NewDailyQuota(3)
+	now := time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC)
+
+	for i := range 3 {
+		if ok, _ := q.Take("alice", now); !ok {
+			t.Fatalf("take %d: quota exhausted too early", i+1)
+		}
+	}
+	ok, resetIn := q.Take("alice", now)
+	if ok {
+		t.Fatal("take over the limit succeeded")
+	}
+	if resetIn != time.Hour {
+		t.Errorf("reset in %s, want 1h until UTC midnight", resetIn)
+	}
+	if ok, _ = q.Take("bob", now); !ok {
+		t.Error("quota of one client affected another")
+	}
+
+	if ok, _ = q.Take("alice", now.Add(time.Hour)); !ok {
+		t.Error("quota was not renewed on the next day")
+	}
+}
+
+func TestDailyQuotaTakeN(t *testing.T) {
+	q := NewDailyQuota(5)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	if ok, _ := q.TakeN("alice", 4, now); !ok {
+		t.Fatal("take 4 of 5 failed")
+	}
+	// Не хватает на все - не списывается ничего
+	if ok, _ := q.TakeN("alice", 2, now); ok {
+		t.Fatal("take 2 with 1 left succeeded")
+	}
+	if ok, _ := q.Take("alice", now); !ok {
+		t.Fatal("partial take consumed the quota")
+	}
+}
+
+func TestDailyQuotaRefund(t *testing.T) {
+	q := NewDailyQuota(2)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	q.TakeN("alice", 2, now)
+	q.Refund("alice", 1, now)
+	if ok, _ := q.Take("alice", now); !ok {
+		t.Fatal("refunded call is not available")
+	}
+	if ok, _ := q.Take("alice", now); ok {
+		t.Fatal("refund returned more than it was asked to")
+	}
+
+	// Возврат не уводит счетчик ниже нуля и не переносит вызовы между сутками
+	q.Refund("alice", 10, now)
+	q.Refund("bob", 1, now.Add(24*time.Hour))
+	if ok, _ := q.TakeN("alice", 3, now); ok {
+		t.Fatal("refund made quota larger than the limit")
+	}
+}
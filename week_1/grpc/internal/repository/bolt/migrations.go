@@ -0,0 +1,138 @@
+package bolt
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	metaBucket       = []byte("meta")
+	schemaVersionKey = []byte("schema_version")
+)
+
+// migration переводит схему базы на версию version.
+// Миграции только добавляются в конец списка, уже выпущенные не меняются
+type migration struct {
+	version uint64
+	name    string
+	up      func(tx *bbolt.Tx) error
+}
+
+var migrations = []migration{
+	{
+		version: 1,
+		name:    "create sightings bucket",
+		up: func(tx *bbolt.Tx) error {
+			_, err := tx.CreateBucketIfNotExists(sightingsBucket)
+			return err
+		},
+	},
+	{
+		version: 2,
+		name:    "backfill sighting versions",
+		up: func(tx *bbolt.Tx) error {
+			return rewriteSightings(tx, func(sighting *ufoV1.Sighting) bool {
+				if sighting.GetVersion() != 0 {
+					return false
+				}
+				sighting.Version = 1
+				return true
+			})
+		},
+	},
+	{
+		version: 3,
+		name:    "create attachments bucket",
+		up: func(tx *bbolt.Tx) error {
+			_, err := tx.CreateBucketIfNotExists(attachmentsBucket)
+			return err
+		},
+	},
+	{
+		version: 4,
+		name:    "create revisions bucket",
+		up: func(tx *bbolt.Tx) error {
+			_, err := tx.CreateBucketIfNotExists(revisionsBucket)
+			return err
+		},
+	},
+	{
+		version: 5,
+		name:    "backfill sighting statuses",
+		// Наблюдения, созданные до модерации, ждут проверки наравне с новыми
+		up: func(tx *bbolt.Tx) error {
+			return rewriteSightings(tx, func(sighting *ufoV1.Sighting) bool {
+				if sighting.GetStatus() != ufoV1.SightingStatus_SIGHTING_STATUS_UNSPECIFIED {
+					return false
+				}
+				sighting.Status = ufoV1.SightingStatus_SIGHTING_STATUS_SUBMITTED
+				return true
+			})
+		},
+	},
+}
+
+// rewriteSightings обходит все наблюдения и сохраняет те, которые изменила fn.
+// fn меняет наблюдение на месте и сообщает, было ли что менять
+func rewriteSightings(tx *bbolt.Tx, fn func(sighting *ufoV1.Sighting) bool) error {
+	bucket := tx.Bucket(sightingsBucket)
+	var updated []*ufoV1.Sighting
+
+	err := bucket.ForEach(func(_, raw []byte) error {
+		sighting, err := unmarshal(raw)
+		if err != nil {
+			return err
+		}
+		if fn(sighting) {
+			updated = append(updated, sighting)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Менять бакет внутри ForEach нельзя, поэтому пишем после обхода
+	for _, sighting := range updated {
+		if err = put(bucket, sighting); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrate применяет недостающие миграции в одной транзакции:
+// либо база переходит на последнюю версию схемы целиком, либо не меняется
+func migrate(db *bbolt.DB) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		meta, err := tx.CreateBucketIfNotExists(metaBucket)
+		if err != nil {
+			return fmt.Errorf("create meta bucket: %w", err)
+		}
+
+		var current uint64
+		if raw := meta.Get(schemaVersionKey); raw != nil {
+			current = binary.BigEndian.Uint64(raw)
+		}
+
+		latest := migrations[len(migrations)-1].version
+		if current > latest {
+			return fmt.Errorf("database schema version %d is newer than supported %d", current, latest)
+		}
+
+		for _, m := range migrations {
+			if m.version <= current {
+				continue
+			}
+			if err = m.up(tx); err != nil {
+				return fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+			}
+			current = m.version
+		}
+
+		return meta.Put(schemaVersionKey, binary.BigEndian.AppendUint64(nil, current))
+	})
+}
@@ -0,0 +1,311 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"go.etcd.io/bbolt"
+	bbolterrors "go.etcd.io/bbolt/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// openTimeout сколько ждать снятия блокировки файла другим процессом
+const openTimeout = time.Second
+
+var (
+	sightingsBucket   = []byte("sightings")
+	attachmentsBucket = []byte("attachments")
+	// revisionsBucket содержит по вложенному бакету на наблюдение,
+	// ключи в нем - версии в big-endian, поэтому обход идет по возрастанию версии
+	revisionsBucket = []byte("revisions")
+)
+
+var _ repository.SightingRepository = (*Repository)(nil)
+
+// Repository хранит наблюдения во встроенной базе bbolt: каждая запись
+// фиксируется на диске до возврата из метода и переживает перезапуск
+type Repository struct {
+	db *bbolt.DB
+}
+
+// NewRepository открывает (или создает) файл базы и применяет миграции схемы
+func NewRepository(path string) (*Repository, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("open bolt database %s: %w", path, err)
+	}
+
+	if err = migrate(db); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("migrate bolt database %s: %w", path, err)
+	}
+
+	return &Repository{db: db}, nil
+}
+
+func (r *Repository) Create(ctx context.Context, sighting *ufoV1.Sighting) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(sightingsBucket)
+		if bucket.Get([]byte(sighting.GetUuid())) != nil {
+			return repository.ErrAlreadyExists
+		}
+		return put(bucket, sighting)
+	})
+}
+
+func (r *Repository) Get(ctx context.Context, uuid string) (*ufoV1.Sighting, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var sighting *ufoV1.Sighting
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		raw := tx.Bucket(sightingsBucket).Get([]byte(uuid))
+		if raw == nil {
+			return repository.ErrNotFound
+		}
+
+		var err error
+		sighting, err = unmarshal(raw)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sighting, nil
+}
+
+func (r *Repository) Update(ctx context.Context, sighting *ufoV1.Sighting) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(sightingsBucket)
+		if bucket.Get([]byte(sighting.GetUuid())) == nil {
+			return repository.ErrNotFound
+		}
+		return put(bucket, sighting)
+	})
+}
+
+func (r *Repository) Delete(ctx context.Context, uuid string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(sightingsBucket)
+		if bucket.Get([]byte(uuid)) == nil {
+			return repository.ErrNotFound
+		}
+		return bucket.Delete([]byte(uuid))
+	})
+}
+
+func (r *Repository) List(ctx context.Context) ([]*ufoV1.Sighting, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var sightings []*ufoV1.Sighting
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(sightingsBucket)
+		sightings = make([]*ufoV1.Sighting, 0, bucket.Stats().KeyN)
+
+		return bucket.ForEach(func(_, raw []byte) error {
+			sighting, err := unmarshal(raw)
+			if err != nil {
+				return err
+			}
+			sightings = append(sightings, sighting)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sightings, nil
+}
+
+func (r *Repository) Scan(ctx context.Context, fn func(*ufoV1.Sighting) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return r.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(sightingsBucket).ForEach(func(_, raw []byte) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			sighting, err := unmarshal(raw)
+			if err != nil {
+				return err
+			}
+			return fn(sighting)
+		})
+	})
+}
+
+func (r *Repository) PutAttachment(ctx context.Context, id string, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(attachmentsBucket)
+		if bucket.Get([]byte(id)) != nil {
+			return repository.ErrAlreadyExists
+		}
+		return bucket.Put([]byte(id), data)
+	})
+}
+
+func (r *Repository) GetAttachment(ctx context.Context, id string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		raw := tx.Bucket(attachmentsBucket).Get([]byte(id))
+		if raw == nil {
+			return repository.ErrNotFound
+		}
+		// Срез от bbolt действителен только внутри транзакции
+		data = bytes.Clone(raw)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (r *Repository) DeleteAttachment(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(attachmentsBucket)
+		if bucket.Get([]byte(id)) == nil {
+			return repository.ErrNotFound
+		}
+		return bucket.Delete([]byte(id))
+	})
+}
+
+func (r *Repository) AppendRevision(ctx context.Context, revision *ufoV1.Revision) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	raw, err := proto.Marshal(revision)
+	if err != nil {
+		return fmt.Errorf("marshal revision %d of sighting %s: %w", revision.GetVersion(), revision.GetSightingUuid(), err)
+	}
+
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		history, err := tx.Bucket(revisionsBucket).CreateBucketIfNotExists([]byte(revision.GetSightingUuid()))
+		if err != nil {
+			return err
+		}
+
+		key := binary.BigEndian.AppendUint64(nil, uint64(revision.GetVersion()))
+		if history.Get(key) != nil {
+			return repository.ErrAlreadyExists
+		}
+		return history.Put(key, raw)
+	})
+}
+
+func (r *Repository) ListRevisions(ctx context.Context, uuid string) ([]*ufoV1.Revision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var revisions []*ufoV1.Revision
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		history := tx.Bucket(revisionsBucket).Bucket([]byte(uuid))
+		if history == nil {
+			return nil
+		}
+
+		return history.ForEach(func(_, raw []byte) error {
+			revision := &ufoV1.Revision{}
+			if err := proto.Unmarshal(raw, revision); err != nil {
+				return fmt.Errorf("unmarshal revision: %w", err)
+			}
+			revisions = append(revisions, revision)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (r *Repository) DeleteRevisions(ctx context.Context, uuid string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		err := tx.Bucket(revisionsBucket).DeleteBucket([]byte(uuid))
+		if errors.Is(err, bbolterrors.ErrBucketNotFound) {
+			return nil
+		}
+		return err
+	})
+}
+
+// Ping открывает читающую транзакцию: она не пройдет, если файл базы закрыт или поврежден
+func (r *Repository) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return r.db.View(func(tx *bbolt.Tx) error {
+		if tx.Bucket(sightingsBucket) == nil {
+			return fmt.Errorf("bucket %q is missing", sightingsBucket)
+		}
+		return nil
+	})
+}
+
+func (r *Repository) Close() error {
+	return r.db.Close()
+}
+
+func put(bucket *bbolt.Bucket, sighting *ufoV1.Sighting) error {
+	raw, err := proto.Marshal(sighting)
+	if err != nil {
+		return fmt.Errorf("marshal sighting %s: %w", sighting.GetUuid(), err)
+	}
+	return bucket.Put([]byte(sighting.GetUuid()), raw)
+}
+
+// unmarshal копирует данные: срез от bbolt действителен только внутри транзакции
+func unmarshal(raw []byte) (*ufoV1.Sighting, error) {
+	sighting := &ufoV1.Sighting{}
+	if err := proto.Unmarshal(raw, sighting); err != nil {
+		return nil, fmt.Errorf("unmarshal sighting: %w", err)
+	}
+	return sighting, nil
+}
@@ -0,0 +1,188 @@
+package bolt
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository/repositorytest"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"go.etcd.io/bbolt"
+)
+
+func TestRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.SightingRepository {
+		repo, err := NewRepository(filepath.Join(t.TempDir(), "ufo.db"))
+		if err != nil {
+			t.Fatalf("open repository: %v", err)
+		}
+		return repo
+	})
+}
+
+func TestDataSurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ufo.db")
+	sighting := repositorytest.NewSighting()
+
+	repo, err := NewRepository(path)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	if err = repo.Create(context.Background(), sighting); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err = repo.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	repo = openRepository(t, path)
+	got, err := repo.Get(context.Background(), sighting.GetUuid())
+	if err != nil {
+		t.Fatalf("get after reopen: %v", err)
+	}
+	if got.GetInfo().GetLocation() != sighting.GetInfo().GetLocation() {
+		t.Errorf("location = %q, want %q", got.GetInfo().GetLocation(), sighting.GetInfo().GetLocation())
+	}
+}
+
+func TestMigrations(t *testing.T) {
+	latest := migrations[len(migrations)-1].version
+
+	for _, from := range []uint64{0, 1, 2, 3, 4} {
+		t.Run(fmt.Sprintf("from %d", from), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "ufo.db")
+			// Сборки до миграции 2 не знали о версиях, до миграции 5 - о статусах
+			legacy := repositorytest.NewSighting()
+			if from >= 2 {
+				legacy.Version = 1
+			}
+			seed(t, path, from, legacy)
+
+			repo := openRepository(t, path)
+			ctx := context.Background()
+
+			got, err := repo.Get(ctx, legacy.GetUuid())
+			if err != nil {
+				t.Fatalf("get legacy sighting: %v", err)
+			}
+			if got.GetVersion() != 1 {
+				t.Errorf("version = %d, want 1", got.GetVersion())
+			}
+			if got.GetStatus() != ufoV1.SightingStatus_SIGHTING_STATUS_SUBMITTED {
+				t.Errorf("status = %s, want SUBMITTED", got.GetStatus())
+			}
+
+			// Бакеты поздних миграций должны появиться и работать
+			if err = repo.PutAttachment(ctx, "a", []byte("data")); err != nil {
+				t.Errorf("put attachment: %v", err)
+			}
+			err = repo.AppendRevision(ctx, &ufoV1.Revision{SightingUuid: legacy.GetUuid(), Version: 1})
+			if err != nil {
+				t.Errorf("append revision: %v", err)
+			}
+
+			if version := schemaVersion(t, repo.db); version != latest {
+				t.Errorf("schema version = %d, want %d", version, latest)
+			}
+		})
+	}
+}
+
+func TestMigrationsKeepMigratedValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ufo.db")
+	sighting := repositorytest.NewSighting()
+	sighting.Version = 7
+	sighting.Status = ufoV1.SightingStatus_SIGHTING_STATUS_VERIFIED
+	seed(t, path, 1, sighting)
+
+	got, err := openRepository(t, path).Get(context.Background(), sighting.GetUuid())
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.GetVersion() != 7 || got.GetStatus() != ufoV1.SightingStatus_SIGHTING_STATUS_VERIFIED {
+		t.Errorf("got version %d status %s, want 7 VERIFIED", got.GetVersion(), got.GetStatus())
+	}
+}
+
+func TestNewerSchemaRejected(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ufo.db")
+	seed(t, path, migrations[len(migrations)-1].version+1, repositorytest.NewSighting())
+
+	_, err := NewRepository(path)
+	if err == nil || !strings.Contains(err.Error(), "newer than supported") {
+		t.Fatalf("open newer schema: err = %v, want newer than supported", err)
+	}
+}
+
+// seed создает базу в том виде, в каком ее оставила сборка со схемой version:
+// применены только миграции до version включительно, sighting записано как есть
+func seed(t *testing.T, path string, version uint64, sighting *ufoV1.Sighting) {
+	t.Helper()
+
+	db, err := bbolt.Open(path, 0o600, nil)
+	if err != nil {
+		t.Fatalf("open seed database: %v", err)
+	}
+	defer db.Close()
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, m := range migrations {
+			if m.version > version {
+				break
+			}
+			if err := m.up(tx); err != nil {
+				return err
+			}
+		}
+
+		// До первой миграции бакет наблюдений создавался при старте
+		bucket, err := tx.CreateBucketIfNotExists(sightingsBucket)
+		if err != nil {
+			return err
+		}
+		if err = put(bucket, sighting); err != nil {
+			return err
+		}
+		if version == 0 {
+			return nil
+		}
+
+		meta, err := tx.CreateBucket(metaBucket)
+		if err != nil {
+			return err
+		}
+		return meta.Put(schemaVersionKey, binary.BigEndian.AppendUint64(nil, version))
+	})
+	if err != nil {
+		t.Fatalf("seed database: %v", err)
+	}
+}
+
+func openRepository(t *testing.T, path string) *Repository {
+	t.Helper()
+
+	repo, err := NewRepository(path)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+	return repo
+}
+
+func schemaVersion(t *testing.T, db *bbolt.DB) uint64 {
+	t.Helper()
+
+	var version uint64
+	err := db.View(func(tx *bbolt.Tx) error {
+		version = binary.BigEndian.Uint64(tx.Bucket(metaBucket).Get(schemaVersionKey))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("read schema version: %v", err)
+	}
+	return version
+}
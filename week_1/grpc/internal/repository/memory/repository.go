@@ -0,0 +1,246 @@
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ repository.SightingRepository = (*Repository)(nil)
+
+// Repository хранит наблюдения в памяти процесса, данные теряются при перезапуске
+type Repository struct {
+	mu          sync.RWMutex
+	sightings   map[string]*ufoV1.Sighting
+	attachments map[string][]byte
+	// revisions истории наблюдений по UUID, каждая по возрастанию версии
+	revisions map[string][]*ufoV1.Revision
+}
+
+func NewRepository() *Repository {
+	return &Repository{
+		sightings:   make(map[string]*ufoV1.Sighting),
+		attachments: make(map[string][]byte),
+		revisions:   make(map[string][]*ufoV1.Revision),
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, sighting *ufoV1.Sighting) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sightings[sighting.GetUuid()]; ok {
+		return repository.ErrAlreadyExists
+	}
+
+	r.sightings[sighting.GetUuid()] = clone(sighting)
+	return nil
+}
+
+func (r *Repository) Get(ctx context.Context, uuid string) (*ufoV1.Sighting, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sighting, ok := r.sightings[uuid]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+
+	return clone(sighting), nil
+}
+
+func (r *Repository) Update(ctx context.Context, sighting *ufoV1.Sighting) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sightings[sighting.GetUuid()]; !ok {
+		return repository.ErrNotFound
+	}
+
+	r.sightings[sighting.GetUuid()] = clone(sighting)
+	return nil
+}
+
+func (r *Repository) Delete(ctx context.Context, uuid string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sightings[uuid]; !ok {
+		return repository.ErrNotFound
+	}
+
+	delete(r.sightings, uuid)
+	return nil
+}
+
+func (r *Repository) List(ctx context.Context) ([]*ufoV1.Sighting, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sightings := make([]*ufoV1.Sighting, 0, len(r.sightings))
+	for _, sighting := range r.sightings {
+		sightings = append(sightings, clone(sighting))
+	}
+
+	return sightings, nil
+}
+
+func (r *Repository) Scan(ctx context.Context, fn func(*ufoV1.Sighting) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Сохраненные наблюдения не меняются на месте, а заменяются копиями, поэтому
+	// их можно копировать для fn по одному уже без блокировки
+	r.mu.RLock()
+	stored := make([]*ufoV1.Sighting, 0, len(r.sightings))
+	for _, sighting := range r.sightings {
+		stored = append(stored, sighting)
+	}
+	r.mu.RUnlock()
+
+	for _, sighting := range stored {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(clone(sighting)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Repository) PutAttachment(ctx context.Context, id string, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.attachments[id]; ok {
+		return repository.ErrAlreadyExists
+	}
+
+	r.attachments[id] = bytes.Clone(data)
+	return nil
+}
+
+func (r *Repository) GetAttachment(ctx context.Context, id string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	data, ok := r.attachments[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+
+	return bytes.Clone(data), nil
+}
+
+func (r *Repository) DeleteAttachment(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.attachments[id]; !ok {
+		return repository.ErrNotFound
+	}
+
+	delete(r.attachments, id)
+	return nil
+}
+
+func (r *Repository) AppendRevision(ctx context.Context, revision *ufoV1.Revision) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	history := r.revisions[revision.GetSightingUuid()]
+	i, found := slices.BinarySearchFunc(history, revision.GetVersion(), func(rev *ufoV1.Revision, version int64) int {
+		return cmp.Compare(rev.GetVersion(), version)
+	})
+	if found {
+		return repository.ErrAlreadyExists
+	}
+
+	r.revisions[revision.GetSightingUuid()] = slices.Insert(history, i, proto.Clone(revision).(*ufoV1.Revision))
+	return nil
+}
+
+func (r *Repository) ListRevisions(ctx context.Context, uuid string) ([]*ufoV1.Revision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	history := make([]*ufoV1.Revision, 0, len(r.revisions[uuid]))
+	for _, revision := range r.revisions[uuid] {
+		history = append(history, proto.Clone(revision).(*ufoV1.Revision))
+	}
+
+	return history, nil
+}
+
+func (r *Repository) DeleteRevisions(ctx context.Context, uuid string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.revisions, uuid)
+	return nil
+}
+
+// Ping всегда успешен: данные в памяти процесса недоступными не становятся
+func (r *Repository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (r *Repository) Close() error {
+	return nil
+}
+
+func clone(sighting *ufoV1.Sighting) *ufoV1.Sighting {
+	return proto.Clone(sighting).(*ufoV1.Sighting)
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+)
+
+var (
+	// ErrNotFound наблюдение с таким UUID отсутствует в хранилище
+	ErrNotFound = errors.New("sighting not found")
+	// ErrAlreadyExists наблюдение с таким UUID уже сохранено
+	ErrAlreadyExists = errors.New("sighting already exists")
+)
+
+// SightingRepository хранилище наблюдений НЛО.
+// Реализации безопасны для конкурентного использования и никогда не отдают
+// наружу свои внутренние объекты: на вход и на выход передаются копии
+type SightingRepository interface {
+	// Create сохраняет новое наблюдение, ErrAlreadyExists если UUID занят
+	Create(ctx context.Context, sighting *ufoV1.Sighting) error
+	// Get возвращает наблюдение по UUID, в том числе удаленное, или ErrNotFound
+	Get(ctx context.Context, uuid string) (*ufoV1.Sighting, error)
+	// Update целиком заменяет сохраненное наблюдение, ErrNotFound если его нет
+	Update(ctx context.Context, sighting *ufoV1.Sighting) error
+	// Delete безвозвратно удаляет наблюдение, ErrNotFound если его нет
+	Delete(ctx context.Context, uuid string) error
+	// List возвращает все наблюдения в произвольном порядке
+	List(ctx context.Context) ([]*ufoV1.Sighting, error)
+	// Scan передает fn все наблюдения по одному в произвольном порядке, не собирая
+	// их в память целиком. Ошибка fn прерывает обход и возвращается из Scan.
+	// fn не должен обращаться к хранилищу
+	Scan(ctx context.Context, fn func(*ufoV1.Sighting) error) error
+	// PutAttachment сохраняет содержимое вложения под новым id, ErrAlreadyExists если id занят.
+	// Метаданные вложения хранятся в самом наблюдении
+	PutAttachment(ctx context.Context, id string, data []byte) error
+	// GetAttachment возвращает содержимое вложения или ErrNotFound
+	GetAttachment(ctx context.Context, id string) ([]byte, error)
+	// DeleteAttachment удаляет содержимое вложения, ErrNotFound если его нет
+	DeleteAttachment(ctx context.Context, id string) error
+	// AppendRevision добавляет ревизию в историю наблюдения, ErrAlreadyExists если
+	// ревизия с такой версией уже записана. Записанные ревизии не меняются
+	AppendRevision(ctx context.Context, revision *ufoV1.Revision) error
+	// ListRevisions возвращает историю наблюдения по возрастанию версии, пустую если ее нет
+	ListRevisions(ctx context.Context, uuid string) ([]*ufoV1.Revision, error)
+	// DeleteRevisions удаляет всю историю наблюдения, отсутствие истории не ошибка
+	DeleteRevisions(ctx context.Context, uuid string) error
+	// Ping проверяет, что хранилище доступно и готово обслуживать запросы
+	Ping(ctx context.Context) error
+	// Close освобождает ресурсы хранилища
+	Close() error
+}
@@ -0,0 +1,384 @@
+// Package repositorytest содержит общий набор проверок, которому должна
+// соответствовать любая реализация repository.SightingRepository
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Factory создает пустое хранилище для одной проверки, закрывать его не нужно
+type Factory func(t *testing.T) repository.SightingRepository
+
+// Run прогоняет все проверки на хранилищах из factory. Пример использования:
+//
+//	func TestRepository(t *testing.T) {
+//		repositorytest.Run(t, func(t *testing.T) repository.SightingRepository {
+//			return memory.NewRepository()
+//		})
+//	}
+func Run(t *testing.T, factory Factory) {
+	t.Helper()
+
+	tests := map[string]func(t *testing.T, repo repository.SightingRepository){
+		"CreateAndGet":            testCreateAndGet,
+		"CreateDuplicate":         testCreateDuplicate,
+		"GetMissing":              testGetMissing,
+		"Update":                  testUpdate,
+		"UpdateMissing":           testUpdateMissing,
+		"Delete":                  testDelete,
+		"DeleteMissing":           testDeleteMissing,
+		"List":                    testList,
+		"Scan":                    testScan,
+		"ReturnedCopyIsDetached":  testReturnedCopyIsDetached,
+		"StoredCopyIsDetached":    testStoredCopyIsDetached,
+		"CanceledContextRejected": testCanceledContext,
+		"Ping":                    testPing,
+		"Attachments":             testAttachments,
+		"Revisions":               testRevisions,
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo := factory(t)
+			t.Cleanup(func() {
+				if err := repo.Close(); err != nil {
+					t.Errorf("close repository: %v", err)
+				}
+			})
+			test(t, repo)
+		})
+	}
+}
+
+// NewSighting возвращает заполненное наблюдение со случайным UUID
+func NewSighting() *ufoV1.Sighting {
+	now := time.Now().UTC()
+	return &ufoV1.Sighting{
+		Uuid: uuid.NewString(),
+		Info: &ufoV1.SightingInfo{
+			ObservedAt:      timestamppb.New(now.Add(-time.Hour)),
+			Location:        "Roswell, New Mexico",
+			Description:     "Bright disc hovering over the ranch",
+			Color:           wrapperspb.String("silver"),
+			DurationSeconds: wrapperspb.Int32(90),
+		},
+		CreatedAt: timestamppb.New(now),
+	}
+}
+
+func mustCreate(t *testing.T, repo repository.SightingRepository, sighting *ufoV1.Sighting) {
+	t.Helper()
+
+	if err := repo.Create(context.Background(), sighting); err != nil {
+		t.Fatalf("Create(%s): %v", sighting.GetUuid(), err)
+	}
+}
+
+func assertEqual(t *testing.T, want, got *ufoV1.Sighting) {
+	t.Helper()
+
+	if !proto.Equal(want, got) {
+		t.Fatalf("sighting mismatch:\nwant: %v\ngot:  %v", want, got)
+	}
+}
+
+func testCreateAndGet(t *testing.T, repo repository.SightingRepository) {
+	sighting := NewSighting()
+	mustCreate(t, repo, sighting)
+
+	got, err := repo.Get(context.Background(), sighting.GetUuid())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	assertEqual(t, sighting, got)
+}
+
+func testCreateDuplicate(t *testing.T, repo repository.SightingRepository) {
+	sighting := NewSighting()
+	mustCreate(t, repo, sighting)
+
+	err := repo.Create(context.Background(), sighting)
+	if !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Fatalf("Create duplicate: want ErrAlreadyExists, got %v", err)
+	}
+}
+
+func testGetMissing(t *testing.T, repo repository.SightingRepository) {
+	_, err := repo.Get(context.Background(), uuid.NewString())
+	if !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("Get missing: want ErrNotFound, got %v", err)
+	}
+}
+
+func testUpdate(t *testing.T, repo repository.SightingRepository) {
+	sighting := NewSighting()
+	mustCreate(t, repo, sighting)
+
+	sighting.Info.Location = "Area 51"
+	sighting.Info.Color = nil
+	sighting.UpdatedAt = timestamppb.Now()
+	sighting.DeletedAt = timestamppb.Now()
+	if err := repo.Update(context.Background(), sighting); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := repo.Get(context.Background(), sighting.GetUuid())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	assertEqual(t, sighting, got)
+}
+
+func testUpdateMissing(t *testing.T, repo repository.SightingRepository) {
+	err := repo.Update(context.Background(), NewSighting())
+	if !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("Update missing: want ErrNotFound, got %v", err)
+	}
+}
+
+func testDelete(t *testing.T, repo repository.SightingRepository) {
+	sighting := NewSighting()
+	mustCreate(t, repo, sighting)
+
+	if err := repo.Delete(context.Background(), sighting.GetUuid()); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	_, err := repo.Get(context.Background(), sighting.GetUuid())
+	if !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("Get after Delete: want ErrNotFound, got %v", err)
+	}
+}
+
+func testDeleteMissing(t *testing.T, repo repository.SightingRepository) {
+	err := repo.Delete(context.Background(), uuid.NewString())
+	if !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("Delete missing: want ErrNotFound, got %v", err)
+	}
+}
+
+func testList(t *testing.T, repo repository.SightingRepository) {
+	sightings, err := repo.List(context.Background())
+	if err != nil {
+		t.Fatalf("List empty: %v", err)
+	}
+	if len(sightings) != 0 {
+		t.Fatalf("List empty: want 0 sightings, got %d", len(sightings))
+	}
+
+	want := make(map[string]*ufoV1.Sighting)
+	for range 3 {
+		sighting := NewSighting()
+		mustCreate(t, repo, sighting)
+		want[sighting.GetUuid()] = sighting
+	}
+
+	sightings, err = repo.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(sightings) != len(want) {
+		t.Fatalf("List: want %d sightings, got %d", len(want), len(sightings))
+	}
+	for _, got := range sightings {
+		assertEqual(t, want[got.GetUuid()], got)
+	}
+}
+
+func testScan(t *testing.T, repo repository.SightingRepository) {
+	want := make(map[string]*ufoV1.Sighting)
+	for range 3 {
+		sighting := NewSighting()
+		mustCreate(t, repo, sighting)
+		want[sighting.GetUuid()] = sighting
+	}
+
+	seen := make(map[string]bool)
+	err := repo.Scan(context.Background(), func(got *ufoV1.Sighting) error {
+		if seen[got.GetUuid()] {
+			t.Errorf("Scan: %s visited twice", got.GetUuid())
+		}
+		seen[got.GetUuid()] = true
+		assertEqual(t, want[got.GetUuid()], got)
+		// Изменения переданной копии не попадают в хранилище
+		got.Info.Location = "changed by caller"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(seen) != len(want) {
+		t.Fatalf("Scan: want %d sightings, got %d", len(want), len(seen))
+	}
+	for id, sighting := range want {
+		got, err := repo.Get(context.Background(), id)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		assertEqual(t, sighting, got)
+	}
+
+	// Ошибка fn останавливает обход
+	stop := errors.New("stop")
+	visited := 0
+	err = repo.Scan(context.Background(), func(*ufoV1.Sighting) error {
+		visited++
+		return stop
+	})
+	if !errors.Is(err, stop) || visited != 1 {
+		t.Fatalf("Scan with failing fn: err = %v after %d sightings, want stop after 1", err, visited)
+	}
+}
+
+func testReturnedCopyIsDetached(t *testing.T, repo repository.SightingRepository) {
+	sighting := NewSighting()
+	mustCreate(t, repo, sighting)
+
+	got, err := repo.Get(context.Background(), sighting.GetUuid())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got.Info.Location = "changed by caller"
+
+	again, err := repo.Get(context.Background(), sighting.GetUuid())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	assertEqual(t, sighting, again)
+}
+
+func testStoredCopyIsDetached(t *testing.T, repo repository.SightingRepository) {
+	sighting := NewSighting()
+	want := proto.Clone(sighting).(*ufoV1.Sighting)
+	mustCreate(t, repo, sighting)
+
+	sighting.Info.Location = "changed by caller"
+
+	got, err := repo.Get(context.Background(), sighting.GetUuid())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	assertEqual(t, want, got)
+}
+
+func testCanceledContext(t *testing.T, repo repository.SightingRepository) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := repo.Create(ctx, NewSighting()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Create with canceled context: want context.Canceled, got %v", err)
+	}
+	err := repo.Scan(ctx, func(*ufoV1.Sighting) error {
+		t.Error("Scan with canceled context called fn")
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Scan with canceled context: want context.Canceled, got %v", err)
+	}
+}
+
+func testPing(t *testing.T, repo repository.SightingRepository) {
+	if err := repo.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+}
+
+func testAttachments(t *testing.T, repo repository.SightingRepository) {
+	ctx := context.Background()
+	id := uuid.NewString()
+	data := []byte("\x89PNG\r\n\x1a\n fake image")
+
+	if err := repo.PutAttachment(ctx, id, data); err != nil {
+		t.Fatalf("PutAttachment: %v", err)
+	}
+	if err := repo.PutAttachment(ctx, id, data); !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Fatalf("PutAttachment duplicate: want ErrAlreadyExists, got %v", err)
+	}
+
+	// Хранилище держит свою копию: изменение исходного среза ее не трогает
+	data[0] = 0
+	got, err := repo.GetAttachment(ctx, id)
+	if err != nil {
+		t.Fatalf("GetAttachment: %v", err)
+	}
+	if string(got) != "\x89PNG\r\n\x1a\n fake image" {
+		t.Fatalf("GetAttachment: got %q", got)
+	}
+
+	if err = repo.DeleteAttachment(ctx, id); err != nil {
+		t.Fatalf("DeleteAttachment: %v", err)
+	}
+	if _, err = repo.GetAttachment(ctx, id); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("GetAttachment after delete: want ErrNotFound, got %v", err)
+	}
+	if err = repo.DeleteAttachment(ctx, id); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("DeleteAttachment missing: want ErrNotFound, got %v", err)
+	}
+}
+
+func testRevisions(t *testing.T, repo repository.SightingRepository) {
+	ctx := context.Background()
+	sighting := NewSighting()
+
+	history, err := repo.ListRevisions(ctx, sighting.GetUuid())
+	if err != nil || len(history) != 0 {
+		t.Fatalf("ListRevisions empty: want no revisions, got %d, %v", len(history), err)
+	}
+
+	// Версии дописываются не по порядку: история все равно отдается по возрастанию
+	want := make([]*ufoV1.Revision, 0, 3)
+	for _, version := range []int64{2, 1, 3} {
+		revision := &ufoV1.Revision{
+			SightingUuid: sighting.GetUuid(),
+			Version:      version,
+			Type:         ufoV1.SightingEventType_SIGHTING_EVENT_TYPE_UPDATED,
+			Actor:        "tester",
+			Info:         sighting.GetInfo(),
+		}
+		if err = repo.AppendRevision(ctx, revision); err != nil {
+			t.Fatalf("AppendRevision(%d): %v", version, err)
+		}
+		want = append(want, revision)
+	}
+	want[0], want[1] = want[1], want[0]
+
+	if err = repo.AppendRevision(ctx, want[0]); !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Fatalf("AppendRevision duplicate: want ErrAlreadyExists, got %v", err)
+	}
+	if err = repo.AppendRevision(ctx, &ufoV1.Revision{SightingUuid: uuid.NewString(), Version: 1}); err != nil {
+		t.Fatalf("AppendRevision other sighting: %v", err)
+	}
+
+	history, err = repo.ListRevisions(ctx, sighting.GetUuid())
+	if err != nil {
+		t.Fatalf("ListRevisions: %v", err)
+	}
+	if len(history) != len(want) {
+		t.Fatalf("ListRevisions: want %d revisions, got %d", len(want), len(history))
+	}
+	for i := range want {
+		if !proto.Equal(want[i], history[i]) {
+			t.Fatalf("revision #%d mismatch:\nwant: %v\ngot:  %v", i, want[i], history[i])
+		}
+	}
+
+	if err = repo.DeleteRevisions(ctx, sighting.GetUuid()); err != nil {
+		t.Fatalf("DeleteRevisions: %v", err)
+	}
+	if history, err = repo.ListRevisions(ctx, sighting.GetUuid()); err != nil || len(history) != 0 {
+		t.Fatalf("ListRevisions after delete: want no revisions, got %d, %v", len(history), err)
+	}
+	if err = repo.DeleteRevisions(ctx, sighting.GetUuid()); err != nil {
+		t.Fatalf("DeleteRevisions missing: %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package traced
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/repository/traced"
+
+const (
+	// uuidKey атрибут спана с UUID наблюдения
+	uuidKey = attribute.Key("ufo.sighting.uuid")
+	// attachmentKey атрибут спана с id вложения
+	attachmentKey = attribute.Key("ufo.attachment.id")
+	// versionKey атрибут спана с версией наблюдения
+	versionKey = attribute.Key("ufo.sighting.version")
+)
+
+// Repository оборачивает хранилище и пишет спан на каждый вызов,
+// чтобы в трейсе запроса было видно время, проведенное в хранилище
+type Repository struct {
+	next    repository.SightingRepository
+	tracer  trace.Tracer
+	backend attribute.KeyValue
+}
+
+// NewRepository оборачивает next, backend - название хранилища для атрибута db.system
+func NewRepository(next repository.SightingRepository, backend string) *Repository {
+	return &Repository{
+		next:    next,
+		tracer:  otel.Tracer(tracerName),
+		backend: attribute.String("db.system", backend),
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, sighting *ufoV1.Sighting) error {
+	ctx, span := r.start(ctx, "Create", uuidKey.String(sighting.GetUuid()))
+	err := r.next.Create(ctx, sighting)
+	end(span, err)
+	return err
+}
+
+func (r *Repository) Get(ctx context.Context, uuid string) (*ufoV1.Sighting, error) {
+	ctx, span := r.start(ctx, "Get", uuidKey.String(uuid))
+	sighting, err := r.next.Get(ctx, uuid)
+	end(span, err)
+	return sighting, err
+}
+
+func (r *Repository) Update(ctx context.Context, sighting *ufoV1.Sighting) error {
+	ctx, span := r.start(ctx, "Update", uuidKey.String(sighting.GetUuid()))
+	err := r.next.Update(ctx, sighting)
+	end(span, err)
+	return err
+}
+
+func (r *Repository) Delete(ctx context.Context, uuid string) error {
+	ctx, span := r.start(ctx, "Delete", uuidKey.String(uuid))
+	err := r.next.Delete(ctx, uuid)
+	end(span, err)
+	return err
+}
+
+func (r *Repository) List(ctx context.Context) ([]*ufoV1.Sighting, error) {
+	ctx, span := r.start(ctx, "List")
+	sightings, err := r.next.List(ctx)
+	span.SetAttributes(attribute.Int("ufo.sightings.count", len(sightings)))
+	end(span, err)
+	return sightings, err
+}
+
+func (r *Repository) Scan(ctx context.Context, fn func(*ufoV1.Sighting) error) error {
+	ctx, span := r.start(ctx, "Scan")
+	count := 0
+	err := r.next.Scan(ctx, func(sighting *ufoV1.Sighting) error {
+		count++
+		return fn(sighting)
+	})
+	span.SetAttributes(attribute.Int("ufo.sightings.count", count))
+	end(span, err)
+	return err
+}
+
+func (r *Repository) PutAttachment(ctx context.Context, id string, data []byte) error {
+	ctx, span := r.start(ctx, "PutAttachment", attachmentKey.String(id), attribute.Int("ufo.attachment.size", len(data)))
+	err := r.next.PutAttachment(ctx, id, data)
+	end(span, err)
+	return err
+}
+
+func (r *Repository) GetAttachment(ctx context.Context, id string) ([]byte, error) {
+	ctx, span := r.start(ctx, "GetAttachment", attachmentKey.String(id))
+	data, err := r.next.GetAttachment(ctx, id)
+	end(span, err)
+	return data, err
+}
+
+func (r *Repository) DeleteAttachment(ctx context.Context, id string) error {
+	ctx, span := r.start(ctx, "DeleteAttachment", attachmentKey.String(id))
+	err := r.next.DeleteAttachment(ctx, id)
+	end(span, err)
+	return err
+}
+
+func (r *Repository) AppendRevision(ctx context.Context, revision *ufoV1.Revision) error {
+	ctx, span := r.start(ctx, "AppendRevision", uuidKey.String(revision.GetSightingUuid()), versionKey.Int64(revision.GetVersion()))
+	err := r.next.AppendRevision(ctx, revision)
+	end(span, err)
+	return err
+}
+
+func (r *Repository) ListRevisions(ctx context.Context, uuid string) ([]*ufoV1.Revision, error) {
+	ctx, span := r.start(ctx, "ListRevisions", uuidKey.String(uuid))
+	revisions, err := r.next.ListRevisions(ctx, uuid)
+	span.SetAttributes(attribute.Int("ufo.revisions.count", len(revisions)))
+	end(span, err)
+	return revisions, err
+}
+
+func (r *Repository) DeleteRevisions(ctx context.Context, uuid string) error {
+	ctx, span := r.start(ctx, "DeleteRevisions", uuidKey.String(uuid))
+	err := r.next.DeleteRevisions(ctx, uuid)
+	end(span, err)
+	return err
+}
+
+func (r *Repository) Ping(ctx context.Context) error {
+	ctx, span := r.start(ctx, "Ping")
+	err := r.next.Ping(ctx)
+	end(span, err)
+	return err
+}
+
+func (r *Repository) Close() error {
+	return r.next.Close()
+}
+
+// start открывает спан только внутри уже идущего трейса: фоновые обращения
+// вроде health-проверок и сбора метрик иначе засыпали бы экспортер корневыми спанами
+func (r *Repository) start(ctx context.Context, op string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return r.tracer.Start(ctx, "SightingRepository."+op,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(append(attrs, r.backend, attribute.String("db.operation.name", op))...),
+	)
+}
+
+// end закрывает спан. ErrNotFound и ErrAlreadyExists - ожидаемые ответы,
+// а не сбои хранилища, поэтому спан ими не помечается как ошибочный
+func end(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, repository.ErrNotFound) && !errors.Is(err, repository.ErrAlreadyExists) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
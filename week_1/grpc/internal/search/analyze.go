@@ -0,0 +1,76 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopWords частые слова, которые встречаются почти в каждом описании и не помогают ранжированию
+var stopWords = wordSet(`
+	a an and are as at be by for from in into is it its of on or over the then there
+	this to under was were with
+	а без в во где да до и из или к как ко на над не но о об около от по под при про
+	с со там то у что это`)
+
+func wordSet(words string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, word := range strings.Fields(words) {
+		set[word] = struct{}{}
+	}
+	return set
+}
+
+// Analyze разбивает текст на слова, приводит их к нижнему регистру и к основе
+// по правилам английского или русского языка. Стоп-слова отбрасываются,
+// слова со смешанными алфавитами и числа сохраняются как есть
+func Analyze(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		word = strings.ReplaceAll(word, "ё", "е")
+		if _, ok := stopWords[word]; ok {
+			continue
+		}
+
+		switch scriptOf(word) {
+		case scriptLatin:
+			word = stemEnglish(word)
+		case scriptCyrillic:
+			word = stemRussian(word)
+		}
+		terms = append(terms, word)
+	}
+	return terms
+}
+
+type script int
+
+const (
+	scriptOther script = iota
+	scriptLatin
+	scriptCyrillic
+)
+
+// scriptOf определяет алфавит слова: стеммер применяется, только если все буквы из одного алфавита
+func scriptOf(word string) script {
+	result := scriptOther
+	for _, r := range word {
+		var current script
+		switch {
+		case r >= 'a' && r <= 'z':
+			current = scriptLatin
+		case r >= 'а' && r <= 'я':
+			current = scriptCyrillic
+		default:
+			return scriptOther
+		}
+		if result != scriptOther && result != current {
+			return scriptOther
+		}
+		result = current
+	}
+	return result
+}
@@ -0,0 +1,69 @@
+package search
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"The lights over Roswell", []string{"light", "roswel"}},
+		{"HOVERING, hovering... hovered!", []string{"hover", "hover", "hover"}},
+		{"Огни над Ёлкино", []string{"огн", "елкин"}},
+		{"Зелёные огни и жёлтая тарелка", []string{"зелен", "огн", "желт", "тарелк"}},
+		// Числа и слова со смешанными алфавитами не стеммятся
+		{"Boeing 747 and UFOs", []string{"boe", "747", "ufo"}},
+		{"Объект X-15 и F-117", []string{"объект", "x", "15", "f", "117"}},
+		{"тарелкаsaucers", []string{"тарелкаsaucers"}},
+		{"", nil},
+		{"the and of в на", nil},
+	}
+
+	for _, tt := range tests {
+		got := Analyze(tt.text)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Analyze(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func FuzzAnalyze(f *testing.F) {
+	for _, seed := range []string{
+		"The lights over Roswell",
+		"Огни над Ёлкино, зелёные",
+		"generalizations oscillators",
+		"ies eed y yy sses",
+		"ввв ааа ыы ь ъ",
+		"İSTANBUL ǅ ß ﬁ",
+		"\xff\xfe broken utf8",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		terms := Analyze(text)
+		words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		// Основа может совпасть со стоп-словом ("оа" -> "о"), поэтому проверяем
+		// только, что каждое слово дает не больше одного термина
+		if len(terms) > len(words) {
+			t.Fatalf("Analyze(%q) = %q, more terms than words %q", text, terms, words)
+		}
+		for _, term := range terms {
+			if term == "" {
+				t.Fatalf("Analyze(%q) returned an empty term", text)
+			}
+			for _, r := range term {
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					t.Fatalf("Analyze(%q) term %q contains separator %q", text, term, r)
+				}
+			}
+		}
+	})
+}
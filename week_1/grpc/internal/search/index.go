@@ -0,0 +1,206 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// Field поле документа, по которому ведется поиск
+type Field int
+
+const (
+	FieldDescription Field = iota
+	FieldLocation
+
+	fieldCount
+)
+
+// Параметры ранжирования BM25F: совпадение в месте наблюдения весит больше,
+// чем в описании, потому что место короче и точнее
+var fieldWeights = [fieldCount]float64{
+	FieldDescription: 1,
+	FieldLocation:    2,
+}
+
+const (
+	// bm25K1 насколько быстро насыщается вклад повторов слова
+	bm25K1 = 1.2
+	// bm25B насколько длинные поля штрафуются относительно средних
+	bm25B = 0.75
+)
+
+// Hit найденный документ и его релевантность
+type Hit struct {
+	ID    string
+	Score float64
+}
+
+// posting сколько раз слово встречается в каждом поле документа
+type posting [fieldCount]int
+
+// document проиндексированный документ: его слова нужны, чтобы убрать документ из индекса
+type document struct {
+	terms  []string
+	length [fieldCount]int
+}
+
+// Index инвертированный индекс документов с ранжированием BM25F.
+// Безопасен для конкурентного использования и обновляется по одному документу
+type Index struct {
+	mu       sync.RWMutex
+	postings map[string]map[string]posting
+	docs     map[string]document
+	// totalLength суммарная длина каждого поля по всем документам для средней длины
+	totalLength [fieldCount]int
+}
+
+// NewIndex создает пустой индекс
+func NewIndex() *Index {
+	return &Index{
+		postings: make(map[string]map[string]posting),
+		docs:     make(map[string]document),
+	}
+}
+
+// Put индексирует документ, заменяя прежнюю версию с тем же id
+func (idx *Index) Put(id string, fields map[Field]string) {
+	var (
+		doc       document
+		frequency = make(map[string]posting)
+	)
+	for field, text := range fields {
+		terms := Analyze(text)
+		doc.length[field] = len(terms)
+		for _, term := range terms {
+			p := frequency[term]
+			p[field]++
+			frequency[term] = p
+		}
+	}
+	doc.terms = make([]string, 0, len(frequency))
+	for term := range frequency {
+		doc.terms = append(doc.terms, term)
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.removeLocked(id)
+	for term, p := range frequency {
+		docs, ok := idx.postings[term]
+		if !ok {
+			docs = make(map[string]posting)
+			idx.postings[term] = docs
+		}
+		docs[id] = p
+	}
+	for field, length := range doc.length {
+		idx.totalLength[field] += length
+	}
+	idx.docs[id] = doc
+}
+
+// Remove убирает документ из индекса, отсутствующий документ игнорируется
+func (idx *Index) Remove(id string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.removeLocked(id)
+}
+
+func (idx *Index) removeLocked(id string) {
+	doc, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+
+	for _, term := range doc.terms {
+		docs := idx.postings[term]
+		delete(docs, id)
+		if len(docs) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	for field, length := range doc.length {
+		idx.totalLength[field] -= length
+	}
+	delete(idx.docs, id)
+}
+
+// Len возвращает число проиндексированных документов
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return len(idx.docs)
+}
+
+// Search находит документы, содержащие хотя бы одно слово запроса, и упорядочивает
+// их по убыванию релевантности, при равной релевантности - по id.
+// Возвращает nil, если в запросе нет слов, по которым можно искать
+func (idx *Index) Search(query string) []Hit {
+	terms := uniqueTerms(Analyze(query))
+	if len(terms) == 0 {
+		return nil
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	n := float64(len(idx.docs))
+	var avgLength [fieldCount]float64
+	for field, total := range idx.totalLength {
+		if n > 0 && total > 0 {
+			avgLength[field] = float64(total) / n
+		}
+	}
+
+	scores := make(map[string]float64)
+	for _, term := range terms {
+		docs := idx.postings[term]
+		if len(docs) == 0 {
+			continue
+		}
+
+		df := float64(len(docs))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, p := range docs {
+			doc := idx.docs[id]
+			tf := 0.0
+			for field, count := range p {
+				if count == 0 {
+					continue
+				}
+				norm := 1 - bm25B + bm25B*float64(doc.length[field])/avgLength[field]
+				tf += fieldWeights[field] * float64(count) / norm
+			}
+			scores[id] += idf * tf / (bm25K1 + tf)
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{ID: id, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	return hits
+}
+
+func uniqueTerms(terms []string) []string {
+	seen := make(map[string]struct{}, len(terms))
+	unique := terms[:0]
+	for _, term := range terms {
+		if _, ok := seen[term]; ok {
+			continue
+		}
+		seen[term] = struct{}{}
+		unique = append(unique, term)
+	}
+	return unique
+}
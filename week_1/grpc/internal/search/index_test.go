@@ -0,0 +1,88 @@
+package search
+
+import (
+	"slices"
+	"testing"
+)
+
+func hitIDs(hits []Hit) []string {
+	ids := make([]string, 0, len(hits))
+	for _, h := range hits {
+		ids = append(ids, h.ID)
+	}
+	return ids
+}
+
+func TestSearchRanking(t *testing.T) {
+	idx := NewIndex()
+	idx.Put("location", map[Field]string{FieldLocation: "Roswell", FieldDescription: "bright disc"})
+	idx.Put("description", map[Field]string{FieldLocation: "Phoenix", FieldDescription: "disc seen from Roswell"})
+	idx.Put("long", map[Field]string{FieldLocation: "Nevada", FieldDescription: "a very long story about many lights, a disc and Roswell rumours told at night"})
+	idx.Put("unrelated", map[Field]string{FieldLocation: "Moscow", FieldDescription: "green lights"})
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		// Место весит вдвое больше описания, короткое описание - больше длинного
+		{"roswell", []string{"location", "description", "long"}},
+		// Редкое слово важнее частого: bright есть только у одного документа
+		{"bright disc", []string{"location", "description", "long"}},
+		// Словоформы сводятся к одной основе
+		{"Lighting", []string{"unrelated", "long"}},
+		{"saucer", nil},
+	}
+	for _, tt := range tests {
+		if got := hitIDs(idx.Search(tt.query)); !slices.Equal(got, tt.want) {
+			t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSearchTermFrequencySaturates(t *testing.T) {
+	idx := NewIndex()
+	idx.Put("once", map[Field]string{FieldDescription: "orb hovering"})
+	idx.Put("twice", map[Field]string{FieldDescription: "orb orb"})
+	idx.Put("many", map[Field]string{FieldDescription: "orb orb orb orb orb orb orb orb"})
+	idx.Put("none", map[Field]string{FieldDescription: "nothing here"})
+
+	hits := idx.Search("orb")
+	if got := hitIDs(hits); !slices.Equal(got, []string{"many", "twice", "once"}) {
+		t.Fatalf("Search(orb) = %v", got)
+	}
+
+	// BM25 насыщается: восемь повторов не дают восьмикратного прироста
+	if ratio := hits[0].Score / hits[2].Score; ratio >= 2 {
+		t.Errorf("score of 8 repeats / 1 occurrence = %.2f, want < 2", ratio)
+	}
+}
+
+func TestSearchTiesOrderedByID(t *testing.T) {
+	idx := NewIndex()
+	for _, id := range []string{"c", "a", "b"} {
+		idx.Put(id, map[Field]string{FieldLocation: "Roswell"})
+	}
+
+	if got := hitIDs(idx.Search("roswell")); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("equal scores ordered as %v, want by id", got)
+	}
+}
+
+func TestIndexPutReplacesAndRemove(t *testing.T) {
+	idx := NewIndex()
+	idx.Put("1", map[Field]string{FieldDescription: "silver disc"})
+	idx.Put("1", map[Field]string{FieldDescription: "green orb"})
+
+	if hits := idx.Search("disc"); len(hits) != 0 {
+		t.Errorf("old version still found: %v", hits)
+	}
+	if got := hitIDs(idx.Search("orb")); !slices.Equal(got, []string{"1"}) {
+		t.Errorf("new version not found: %v", got)
+	}
+
+	idx.Remove("1")
+	idx.Remove("missing")
+	if idx.Len() != 0 || len(idx.postings) != 0 || idx.totalLength != [fieldCount]int{} {
+		t.Errorf("index not empty after remove: len=%d postings=%d total=%v", idx.Len(), len(idx.postings), idx.totalLength)
+	}
+}
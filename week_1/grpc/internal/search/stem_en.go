@@ -0,0 +1,247 @@
+package search
+
+// Стеммер Портера для английского языка (M. F. Porter, 1980).
+// Работает со строчными латинскими словами, остальные слова не трогает
+
+// porterStemmer состояние разбора: b[:k+1] текущая основа, j граница, найденная ends
+type porterStemmer struct {
+	b    []byte
+	k, j int
+}
+
+// stemEnglish возвращает основу английского слова из строчных латинских букв
+func stemEnglish(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+
+	p := &porterStemmer{b: []byte(word), k: len(word) - 1}
+	p.step1ab()
+	if p.k > 0 {
+		p.step1c()
+		p.step2()
+		p.step3()
+		p.step4()
+		p.step5()
+	}
+	return string(p.b[:p.k+1])
+}
+
+// cons сообщает, является ли b[i] согласной. y согласная в начале слова и после гласной
+func (p *porterStemmer) cons(i int) bool {
+	switch p.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !p.cons(i-1)
+	}
+	return true
+}
+
+// m считает число последовательностей гласная-согласная в b[:j+1]: [C](VC){m}[V]
+func (p *porterStemmer) m() int {
+	n, i := 0, 0
+	for ; i <= p.j && p.cons(i); i++ {
+	}
+	for i <= p.j {
+		for ; i <= p.j && !p.cons(i); i++ {
+		}
+		if i > p.j {
+			break
+		}
+		n++
+		for ; i <= p.j && p.cons(i); i++ {
+		}
+	}
+	return n
+}
+
+// vowelInStem сообщает, есть ли гласная в b[:j+1]
+func (p *porterStemmer) vowelInStem() bool {
+	for i := 0; i <= p.j; i++ {
+		if !p.cons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// doubleCons сообщает, заканчивается ли b[:i+1] двойной согласной
+func (p *porterStemmer) doubleCons(i int) bool {
+	return i >= 1 && p.b[i] == p.b[i-1] && p.cons(i)
+}
+
+// cvc сообщает, заканчивается ли b[:i+1] на согласную-гласную-согласную,
+// где последняя согласная не w, x или y: так выглядят короткие основы вроде hop
+func (p *porterStemmer) cvc(i int) bool {
+	if i < 2 || !p.cons(i) || p.cons(i-1) || !p.cons(i-2) {
+		return false
+	}
+	switch p.b[i] {
+	case 'w', 'x', 'y':
+		return false
+	}
+	return true
+}
+
+// ends проверяет окончание основы и при совпадении ставит j перед ним
+func (p *porterStemmer) ends(suffix string) bool {
+	n := len(suffix)
+	if n > p.k+1 || string(p.b[p.k-n+1:p.k+1]) != suffix {
+		return false
+	}
+	p.j = p.k - n
+	return true
+}
+
+// setTo заменяет b[j+1:k+1] на s
+func (p *porterStemmer) setTo(s string) {
+	p.b = append(p.b[:p.j+1], s...)
+	p.k = p.j + len(s)
+}
+
+// replace заменяет окончание, если перед ним осталась непустая основа (m > 0)
+func (p *porterStemmer) replace(s string) {
+	if p.m() > 0 {
+		p.setTo(s)
+	}
+}
+
+// step1ab убирает множественное число и окончания -ed, -ing
+func (p *porterStemmer) step1ab() {
+	if p.b[p.k] == 's' {
+		switch {
+		case p.ends("sses"):
+			p.k -= 2
+		case p.ends("ies"):
+			p.setTo("i")
+		case p.b[p.k-1] != 's':
+			p.k--
+		}
+	}
+
+	if p.ends("eed") {
+		if p.m() > 0 {
+			p.k--
+		}
+		return
+	}
+	if !(p.ends("ed") || p.ends("ing")) || !p.vowelInStem() {
+		return
+	}
+
+	p.k = p.j
+	switch {
+	case p.ends("at"):
+		p.setTo("ate")
+	case p.ends("bl"):
+		p.setTo("ble")
+	case p.ends("iz"):
+		p.setTo("ize")
+	case p.doubleCons(p.k):
+		switch p.b[p.k] {
+		case 'l', 's', 'z':
+		default:
+			p.k--
+		}
+	default:
+		p.j = p.k
+		if p.m() == 1 && p.cvc(p.k) {
+			p.setTo("e")
+		}
+	}
+}
+
+// step1c меняет конечную y на i, если в основе есть гласная
+func (p *porterStemmer) step1c() {
+	if p.ends("y") && p.vowelInStem() {
+		p.b[p.k] = 'i'
+	}
+}
+
+// porterRules правила замены окончаний, сгруппированные по предпоследней
+// или последней букве слова, как в исходной реализации алгоритма
+type porterRules map[byte][][2]string
+
+var step2Rules = porterRules{
+	'a': {{"ational", "ate"}, {"tional", "tion"}},
+	'c': {{"enci", "ence"}, {"anci", "ance"}},
+	'e': {{"izer", "ize"}},
+	'l': {{"bli", "ble"}, {"alli", "al"}, {"entli", "ent"}, {"eli", "e"}, {"ousli", "ous"}},
+	'o': {{"ization", "ize"}, {"ation", "ate"}, {"ator", "ate"}},
+	's': {{"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"}, {"ousness", "ous"}},
+	't': {{"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"}},
+	'g': {{"logi", "log"}},
+}
+
+var step3Rules = porterRules{
+	'e': {{"icate", "ic"}, {"ative", ""}, {"alize", "al"}},
+	'i': {{"iciti", "ic"}},
+	'l': {{"ical", "ic"}, {"ful", ""}},
+	's': {{"ness", ""}},
+}
+
+// apply применяет первое совпавшее правило из группы буквы c
+func (p *porterStemmer) apply(rules porterRules, c byte) {
+	for _, rule := range rules[c] {
+		if p.ends(rule[0]) {
+			p.replace(rule[1])
+			return
+		}
+	}
+}
+
+// step2 сводит двойные суффиксы к одинарным: -ization -> -ize
+func (p *porterStemmer) step2() {
+	p.apply(step2Rules, p.b[p.k-1])
+}
+
+// step3 убирает суффиксы -ic-, -full, -ness и подобные
+func (p *porterStemmer) step3() {
+	p.apply(step3Rules, p.b[p.k])
+}
+
+var step4Suffixes = map[byte][]string{
+	'a': {"al"},
+	'c': {"ance", "ence"},
+	'e': {"er"},
+	'i': {"ic"},
+	'l': {"able", "ible"},
+	'n': {"ant", "ement", "ment", "ent"},
+	'o': {"ion", "ou"},
+	's': {"ism"},
+	't': {"ate", "iti"},
+	'u': {"ous"},
+	'v': {"ive"},
+	'z': {"ize"},
+}
+
+// step4 убирает суффиксы -ant, -ence и подобные у основ с m > 1
+func (p *porterStemmer) step4() {
+	for _, suffix := range step4Suffixes[p.b[p.k-1]] {
+		if !p.ends(suffix) {
+			continue
+		}
+		// -ion отрезается только после s или t: adoption, но не lion
+		if suffix == "ion" && (p.j < 0 || (p.b[p.j] != 's' && p.b[p.j] != 't')) {
+			continue
+		}
+		if p.m() > 1 {
+			p.k = p.j
+		}
+		return
+	}
+}
+
+// step5 убирает конечную -e и сводит -ll к -l у длинных основ
+func (p *porterStemmer) step5() {
+	p.j = p.k
+	if p.b[p.k] == 'e' {
+		if m := p.m(); m > 1 || (m == 1 && !p.cvc(p.k-1)) {
+			p.k--
+		}
+	}
+	if p.b[p.k] == 'l' && p.doubleCons(p.k) && p.m() > 1 {
+		p.k--
+	}
+}
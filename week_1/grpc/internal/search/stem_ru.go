@@ -0,0 +1,141 @@
+package search
+
+import "strings"
+
+// Стеммер для русского языка по алгоритму Snowball (M. F. Porter).
+// Окончания ищутся только в области RV - после первой гласной слова,
+// словообразовательные суффиксы - только в области R2
+
+var (
+	// Окончания первой группы отрезаются только после а или я: прочитавши -> прочита
+	perfectiveGerund1 = []string{"в", "вши", "вшись"}
+	perfectiveGerund2 = []string{"ив", "ивши", "ившись", "ыв", "ывши", "ывшись"}
+
+	adjective = []string{
+		"ее", "ие", "ые", "ое", "ими", "ыми", "ей", "ий", "ый", "ой", "ем", "им", "ым", "ом",
+		"его", "ого", "ему", "ому", "их", "ых", "ую", "юю", "ая", "яя", "ою", "ею",
+	}
+
+	participle1 = []string{"ем", "нн", "вш", "ющ", "щ"}
+	participle2 = []string{"ивш", "ывш", "ующ"}
+
+	reflexive = []string{"ся", "сь"}
+
+	verb1 = []string{"ла", "на", "ете", "йте", "ли", "й", "л", "ем", "н", "ло", "но", "ет", "ют", "ны", "ть", "ешь", "нно"}
+	verb2 = []string{
+		"ила", "ыла", "ена", "ейте", "уйте", "ите", "или", "ыли", "ей", "уй", "ил", "ыл", "им", "ым", "ен",
+		"ило", "ыло", "ено", "ят", "ует", "уют", "ит", "ыт", "ены", "ить", "ыть", "ишь", "ую", "ю",
+	}
+
+	noun = []string{
+		"а", "ев", "ов", "ие", "ье", "е", "иями", "ями", "ами", "еи", "ии", "и", "ией", "ей", "ой", "ий", "й",
+		"иям", "ям", "ием", "ем", "ам", "ом", "о", "у", "ах", "иях", "ях", "ы", "ь", "ию", "ью", "ю", "ия", "ья", "я",
+	}
+
+	superlative  = []string{"ейш", "ейше"}
+	derivational = []string{"ост", "ость"}
+)
+
+func isRussianVowel(r rune) bool {
+	return strings.ContainsRune("аеиоуыэюя", r)
+}
+
+// stemRussian возвращает основу русского слова из строчных букв, ё заменена на е
+func stemRussian(word string) string {
+	w := []rune(word)
+	rv, r2 := russianRegions(w)
+
+	// Шаг 1: деепричастие, иначе возвратная частица и затем прилагательное, глагол или существительное
+	if n := russianEnding(w, rv, perfectiveGerund1, perfectiveGerund2); n > 0 {
+		w = w[:len(w)-n]
+	} else {
+		if n = russianEnding(w, rv, nil, reflexive); n > 0 {
+			w = w[:len(w)-n]
+		}
+		if n = russianEnding(w, rv, nil, adjective); n > 0 {
+			w = w[:len(w)-n]
+			// Причастие перед окончанием прилагательного тоже отрезается: летающий -> лета
+			if n = russianEnding(w, rv, participle1, participle2); n > 0 {
+				w = w[:len(w)-n]
+			}
+		} else if n = russianEnding(w, rv, verb1, verb2); n > 0 {
+			w = w[:len(w)-n]
+		} else if n = russianEnding(w, rv, nil, noun); n > 0 {
+			w = w[:len(w)-n]
+		}
+	}
+
+	// Шаг 2: конечная и
+	if russianEnding(w, rv, nil, []string{"и"}) > 0 {
+		w = w[:len(w)-1]
+	}
+
+	// Шаг 3: словообразовательный суффикс в R2
+	if n := russianEnding(w, r2, nil, derivational); n > 0 {
+		w = w[:len(w)-n]
+	}
+
+	// Шаг 4: двойная н, превосходная степень или мягкий знак
+	if n := russianEnding(w, rv, nil, superlative); n > 0 {
+		w = w[:len(w)-n]
+	}
+	if russianEnding(w, rv, nil, []string{"нн"}) > 0 || russianEnding(w, rv, nil, []string{"ь"}) > 0 {
+		w = w[:len(w)-1]
+	}
+
+	return string(w)
+}
+
+// russianRegions возвращает начало RV (после первой гласной) и R2.
+// R1 начинается после первой согласной, идущей за гласной, R2 - так же внутри R1
+func russianRegions(w []rune) (rv, r2 int) {
+	rv = len(w)
+	for i, r := range w {
+		if isRussianVowel(r) {
+			rv = i + 1
+			break
+		}
+	}
+
+	next := func(from int) int {
+		for i := from + 1; i < len(w); i++ {
+			if !isRussianVowel(w[i]) && isRussianVowel(w[i-1]) {
+				return i + 1
+			}
+		}
+		return len(w)
+	}
+	r1 := next(0)
+	if r1 < len(w) {
+		r2 = next(r1)
+	} else {
+		r2 = len(w)
+	}
+	return rv, r2
+}
+
+// russianEnding ищет самое длинное окончание слова из групп, целиком лежащее
+// не раньше позиции limit, и возвращает его длину в буквах или 0.
+// Окончания первой группы засчитываются только после а или я, тоже лежащей в области
+func russianEnding(w []rune, limit int, afterA, plain []string) int {
+	best := 0
+	try := func(suffix string, needA bool) {
+		s := []rune(suffix)
+		start := len(w) - len(s)
+		if len(s) <= best || start < limit || string(w[start:]) != suffix {
+			return
+		}
+		if needA && (start-1 < limit || (w[start-1] != 'а' && w[start-1] != 'я')) {
+			return
+		}
+		best = len(s)
+	}
+
+	for _, suffix := range afterA {
+		try(suffix, true)
+	}
+	for _, suffix := range plain {
+		try(suffix, false)
+	}
+	return best
+}
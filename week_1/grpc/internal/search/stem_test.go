@@ -0,0 +1,71 @@
+package search
+
+import "testing"
+
+func TestStemEnglish(t *testing.T) {
+	// Примеры из статьи Портера и его эталонного словаря
+	tests := map[string]string{
+		// Шаг 1a: множественное число
+		"caresses": "caress", "ponies": "poni", "ties": "ti", "caress": "caress", "cats": "cat",
+		// Шаг 1b: -eed, -ed, -ing и восстановление -e
+		"feed": "feed", "agreed": "agre", "plastered": "plaster", "bled": "bled",
+		"motoring": "motor", "sing": "sing", "conflated": "conflat", "troubled": "troubl",
+		"sized": "size", "hopping": "hop", "tanned": "tan", "falling": "fall",
+		"hissing": "hiss", "fizzed": "fizz", "failing": "fail", "filing": "file",
+		// Шаг 1c: y -> i
+		"happy": "happi", "sky": "sky",
+		// Шаги 2-4: суффиксы
+		"relational": "relat", "conditional": "condit", "rational": "ration",
+		"digitizer": "digit", "vietnamization": "vietnam", "predication": "predic",
+		"operator": "oper", "feudalism": "feudal", "decisiveness": "decis",
+		"hopefulness": "hope", "callousness": "callous", "sensibiliti": "sensibl",
+		"triplicate": "triplic", "formative": "form", "formalize": "formal",
+		"electrical": "electr", "goodness": "good", "revival": "reviv",
+		"allowance": "allow", "inference": "infer", "airliner": "airlin",
+		"gyroscopic": "gyroscop", "adjustable": "adjust", "defensible": "defens",
+		"irritant": "irrit", "replacement": "replac", "adjustment": "adjust",
+		"dependent": "depend", "adoption": "adopt", "communism": "commun",
+		"activate": "activ", "homologous": "homolog", "effective": "effect",
+		"bowdlerize": "bowdler",
+		// Шаг 5: конечная -e и -ll
+		"probate": "probat", "rate": "rate", "cease": "ceas", "controll": "control", "roll": "roll",
+		// Несколько шагов подряд
+		"generalizations": "gener", "oscillators": "oscil", "sightings": "sight",
+		// Короткие слова не меняются
+		"a": "a", "is": "is",
+	}
+
+	for word, want := range tests {
+		if got := stemEnglish(word); got != want {
+			t.Errorf("stemEnglish(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestStemRussian(t *testing.T) {
+	tests := map[string]string{
+		// Падежи существительных сводятся к одной основе
+		"вагон": "вагон", "вагона": "вагон", "вагоне": "вагон", "вагонов": "вагон", "вагонами": "вагон",
+		"тарелка": "тарелк", "тарелки": "тарелк", "тарелкой": "тарелк",
+		"наблюдение": "наблюден", "наблюдения": "наблюден", "наблюдений": "наблюден",
+		"огни": "огн", "огней": "огн", "небо": "неб", "небе": "неб",
+		// Прилагательные, в том числе превосходная степень
+		"важная": "важн", "важнее": "важн", "важнейшие": "важн", "важный": "важн",
+		"яркий": "ярк", "яркие": "ярк", "красного": "красн", "красивейший": "красив",
+		// Причастия и глаголы, в том числе возвратные
+		"летающая": "лета", "летающие": "лета", "светящийся": "светя",
+		"бегают": "бега", "бегали": "бега", "двигался": "двига", "двигались": "двига",
+		// Окончание -л засчитывается только после а или я
+		"пролетел": "пролетел", "пролетела": "пролетел",
+		// Словообразовательный суффикс -ость в R2 и -нн -> -н
+		"подлинность": "подлин",
+		// Без гласной до окончания основа не меняется
+		"я": "я", "он": "он",
+	}
+
+	for word, want := range tests {
+		if got := stemRussian(word); got != want {
+			t.Errorf("stemRussian(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
@@ -0,0 +1,404 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCA корневой сертификат, которым тесты подписывают сертификаты сервера и клиентов
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+var serial int64
+
+func nextSerial() *big.Int {
+	serial++
+	return big.NewInt(serial)
+}
+
+func newCA(t *testing.T, name string) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate CA key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          nextSerial(),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create CA certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(raw)
+	if err != nil {
+		t.Fatalf("parse CA certificate: %v", err)
+	}
+
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw})}
+}
+
+// issue выпускает сертификат с назначением usage и возвращает PEM сертификата и ключа
+func (ca *testCA) issue(t *testing.T, name string, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: nextSerial(),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+// writeFiles записывает PEM в dir и возвращает пути к ним. Пустые данные не пишутся
+func writeFiles(t *testing.T, dir string, certPEM, keyPEM, caPEM []byte) Files {
+	t.Helper()
+
+	var files Files
+	for _, f := range []struct {
+		path *string
+		name string
+		data []byte
+	}{
+		{&files.CertFile, "cert.pem", certPEM},
+		{&files.KeyFile, "key.pem", keyPEM},
+		{&files.CAFile, "ca.pem", caPEM},
+	} {
+		if f.data == nil {
+			continue
+		}
+		*f.path = filepath.Join(dir, f.name)
+		if err := os.WriteFile(*f.path, f.data, 0o600); err != nil {
+			t.Fatalf("write %s: %v", f.name, err)
+		}
+	}
+	return files
+}
+
+func mustReloader(t *testing.T, files Files) *Reloader {
+	t.Helper()
+
+	r, err := NewReloader(files)
+	if err != nil {
+		t.Fatalf("new reloader: %v", err)
+	}
+	return r
+}
+
+// testPKI сервер с CA и клиент, подписанный тем же CA
+type testPKI struct {
+	ca     *testCA
+	server *Reloader
+	client *Reloader
+}
+
+func newPKI(t *testing.T) testPKI {
+	t.Helper()
+
+	ca := newCA(t, "test CA")
+	serverCert, serverKey := ca.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := ca.issue(t, "client", x509.ExtKeyUsageClientAuth)
+
+	return testPKI{
+		ca:     ca,
+		server: mustReloader(t, writeFiles(t, t.TempDir(), serverCert, serverKey, ca.pem)),
+		client: mustReloader(t, writeFiles(t, t.TempDir(), clientCert, clientKey, ca.pem)),
+	}
+}
+
+// handshake проводит TLS-рукопожатие через loopback и возвращает ошибки сервера и клиента.
+// В TLS 1.3 клиент узнает об отклоненном сертификате только при чтении, поэтому
+// решение сервера - в serverErr
+func handshake(t *testing.T, serverCfg, clientCfg *tls.Config) (serverErr, clientErr error) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			done <- err
+			return
+		}
+		defer conn.Close()
+		done <- tls.Server(conn, serverCfg).Handshake()
+	}()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	clientErr = tls.Client(conn, clientCfg).Handshake()
+	if clientErr != nil {
+		conn.Close()
+	}
+	return <-done, clientErr
+}
+
+func serverConfig(t *testing.T, r *Reloader, requireClientCert bool) *tls.Config {
+	t.Helper()
+
+	cfg, err := r.ServerConfig(requireClientCert)
+	if err != nil {
+		t.Fatalf("server config: %v", err)
+	}
+	return cfg
+}
+
+func TestNewReloader(t *testing.T) {
+	ca := newCA(t, "test CA")
+	certPEM, keyPEM := ca.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+
+	r := mustReloader(t, writeFiles(t, t.TempDir(), certPEM, keyPEM, ca.pem))
+	if r.Certificate() == nil {
+		t.Error("certificate is not loaded")
+	}
+	if r.CertPool() == nil {
+		t.Error("CA pool is not loaded")
+	}
+
+	caOnly := mustReloader(t, writeFiles(t, t.TempDir(), nil, nil, ca.pem))
+	if caOnly.Certificate() != nil {
+		t.Error("certificate is loaded without files")
+	}
+	if _, err := caOnly.ServerConfig(false); err == nil {
+		t.Error("server config without key pair: want error")
+	}
+
+	if _, err := NewReloader(writeFiles(t, t.TempDir(), certPEM, nil, nil)); err == nil {
+		t.Error("certificate without key: want error")
+	}
+	if _, err := NewReloader(writeFiles(t, t.TempDir(), nil, nil, []byte("not a pem"))); err == nil {
+		t.Error("CA without certificates: want error")
+	}
+
+	noCA := mustReloader(t, writeFiles(t, t.TempDir(), certPEM, keyPEM, nil))
+	if _, err := noCA.ServerConfig(true); err == nil {
+		t.Error("client certificate required without CA: want error")
+	}
+}
+
+func TestHotReload(t *testing.T) {
+	ca := newCA(t, "test CA")
+	dir := t.TempDir()
+	certPEM, keyPEM := ca.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+	files := writeFiles(t, dir, certPEM, keyPEM, ca.pem)
+	r := mustReloader(t, files)
+	before := r.Certificate()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Run(ctx, 10*time.Millisecond)
+
+	// Время изменения сдвигаем явно: на быстрой файловой системе оно может совпасть
+	rotated, rotatedKey := ca.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+	writeFiles(t, dir, rotated, rotatedKey, nil)
+	future := time.Now().Add(time.Minute)
+	for _, path := range []string{files.CertFile, files.KeyFile} {
+		if err := os.Chtimes(path, future, future); err != nil {
+			t.Fatalf("touch %s: %v", path, err)
+		}
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for r.Certificate() == before {
+		if time.Now().After(deadline) {
+			t.Fatal("certificate was not reloaded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Новые рукопожатия получают новый сертификат
+	var presented *x509.Certificate
+	clientCfg := &tls.Config{
+		RootCAs:    r.CertPool(),
+		ServerName: "localhost",
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			presented = cs.PeerCertificates[0]
+			return nil
+		},
+	}
+	if serverErr, clientErr := handshake(t, serverConfig(t, r, false), clientCfg); serverErr != nil || clientErr != nil {
+		t.Fatalf("handshake after reload: server %v, client %v", serverErr, clientErr)
+	}
+	block, _ := pem.Decode(rotated)
+	if presented == nil || string(presented.Raw) != string(block.Bytes) {
+		t.Error("server presented the old certificate after reload")
+	}
+}
+
+func TestReloadKeepsPreviousFilesOnError(t *testing.T) {
+	ca := newCA(t, "test CA")
+	certPEM, keyPEM := ca.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+	files := writeFiles(t, t.TempDir(), certPEM, keyPEM, nil)
+	r := mustReloader(t, files)
+	before := r.Certificate()
+
+	// Ротация записала сертификат без подходящего ключа
+	rotated, _ := ca.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+	if err := os.WriteFile(files.CertFile, rotated, 0o600); err != nil {
+		t.Fatalf("write certificate: %v", err)
+	}
+	if err := r.reload(); err == nil {
+		t.Fatal("reload mismatched key pair: want error")
+	}
+	if r.Certificate() != before {
+		t.Error("certificate changed after failed reload")
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	pki := newPKI(t)
+	serverCfg := serverConfig(t, pki.server, true)
+
+	t.Run("valid client certificate", func(t *testing.T) {
+		serverErr, clientErr := handshake(t, serverCfg, pki.client.ClientConfig("localhost"))
+		if serverErr != nil || clientErr != nil {
+			t.Fatalf("handshake: server %v, client %v", serverErr, clientErr)
+		}
+	})
+
+	t.Run("no client certificate", func(t *testing.T) {
+		anonymous := mustReloader(t, writeFiles(t, t.TempDir(), nil, nil, pki.ca.pem))
+		if serverErr, _ := handshake(t, serverCfg, anonymous.ClientConfig("localhost")); serverErr == nil {
+			t.Fatal("server accepted client without certificate")
+		}
+	})
+
+	t.Run("untrusted client certificate", func(t *testing.T) {
+		rogue := newCA(t, "rogue CA")
+		certPEM, keyPEM := rogue.issue(t, "client", x509.ExtKeyUsageClientAuth)
+		client := mustReloader(t, writeFiles(t, t.TempDir(), certPEM, keyPEM, pki.ca.pem))
+		if serverErr, _ := handshake(t, serverCfg, client.ClientConfig("localhost")); serverErr == nil {
+			t.Fatal("server accepted client certificate from untrusted CA")
+		}
+	})
+
+	t.Run("server name mismatch", func(t *testing.T) {
+		if _, clientErr := handshake(t, serverCfg, pki.client.ClientConfig("ufo.example.com")); clientErr == nil {
+			t.Fatal("client accepted certificate issued for another host")
+		}
+	})
+
+	t.Run("certificate optional", func(t *testing.T) {
+		anonymous := mustReloader(t, writeFiles(t, t.TempDir(), nil, nil, pki.ca.pem))
+		serverErr, clientErr := handshake(t, serverConfig(t, pki.server, false), anonymous.ClientConfig("localhost"))
+		if serverErr != nil || clientErr != nil {
+			t.Fatalf("handshake: server %v, client %v", serverErr, clientErr)
+		}
+	})
+}
+
+func TestClientPicksUpRotatedCA(t *testing.T) {
+	pki := newPKI(t)
+
+	// Сервер перешел на новый CA, а клиент пока знает только старый
+	next := newCA(t, "next CA")
+	certPEM, keyPEM := next.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+	server := mustReloader(t, writeFiles(t, t.TempDir(), certPEM, keyPEM, nil))
+	serverCfg := serverConfig(t, server, false)
+
+	clientCfg := pki.client.ClientConfig("localhost")
+	if _, clientErr := handshake(t, serverCfg, clientCfg); clientErr == nil {
+		t.Fatal("client trusted server from unknown CA")
+	}
+
+	if err := os.WriteFile(pki.client.files.CAFile, next.pem, 0o600); err != nil {
+		t.Fatalf("write CA: %v", err)
+	}
+	if err := pki.client.reload(); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+
+	// Тот же конфиг, собранный до ротации, должен увидеть новый CA
+	if serverErr, clientErr := handshake(t, serverCfg, clientCfg); serverErr != nil || clientErr != nil {
+		t.Fatalf("handshake after CA rotation: server %v, client %v", serverErr, clientErr)
+	}
+}
+
+func TestLoopbackConfig(t *testing.T) {
+	pki := newPKI(t)
+	gatewayCert, gatewayKey := pki.ca.issue(t, "gateway", x509.ExtKeyUsageClientAuth)
+	gateway := mustReloader(t, writeFiles(t, t.TempDir(), gatewayCert, gatewayKey, nil))
+
+	t.Run("gateway certificate", func(t *testing.T) {
+		serverErr, clientErr := handshake(t, serverConfig(t, pki.server, true), pki.server.LoopbackConfig(gateway))
+		if serverErr != nil || clientErr != nil {
+			t.Fatalf("handshake: server %v, client %v", serverErr, clientErr)
+		}
+	})
+
+	t.Run("server certificate is not a client certificate", func(t *testing.T) {
+		// Раньше шлюз предъявлял сертификат сервера, а он выпущен только для serverAuth
+		if serverErr, _ := handshake(t, serverConfig(t, pki.server, true), pki.server.LoopbackConfig(pki.server)); serverErr == nil {
+			t.Fatal("server accepted its own serverAuth certificate as client certificate")
+		}
+	})
+
+	t.Run("no gateway certificate", func(t *testing.T) {
+		if serverErr, _ := handshake(t, serverConfig(t, pki.server, true), pki.server.LoopbackConfig(nil)); serverErr == nil {
+			t.Fatal("server accepted gateway without certificate")
+		}
+		serverErr, clientErr := handshake(t, serverConfig(t, pki.server, false), pki.server.LoopbackConfig(nil))
+		if serverErr != nil || clientErr != nil {
+			t.Fatalf("handshake without mTLS: server %v, client %v", serverErr, clientErr)
+		}
+	})
+
+	t.Run("foreign server", func(t *testing.T) {
+		// Сертификат подписан тем же CA, но это не собственный сертификат процесса
+		certPEM, keyPEM := pki.ca.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+		other := mustReloader(t, writeFiles(t, t.TempDir(), certPEM, keyPEM, pki.ca.pem))
+		if _, clientErr := handshake(t, serverConfig(t, other, false), pki.server.LoopbackConfig(gateway)); clientErr == nil {
+			t.Fatal("loopback trusted a certificate other than its own")
+		}
+	})
+}
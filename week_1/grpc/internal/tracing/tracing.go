@@ -0,0 +1,73 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// Экспортеры трейсов
+const (
+	// ExporterNone трейсы не собираются
+	ExporterNone = "none"
+	// ExporterStdout трейсы печатаются в stdout, удобно смотреть локально
+	ExporterStdout = "stdout"
+	// ExporterOTLP трейсы отправляются коллектору по OTLP/gRPC. Адрес и прочие
+	// параметры берутся из стандартных переменных OTEL_EXPORTER_OTLP_*
+	ExporterOTLP = "otlp"
+)
+
+// Setup настраивает глобальный TracerProvider и распространение контекста
+// в формате W3C Trace Context. Возвращает функцию, которая отправляет
+// накопленные спаны и останавливает экспортер; ее нужно вызвать перед выходом
+func Setup(ctx context.Context, serviceName, exporter string) (func(context.Context) error, error) {
+	// Контекст распространяем даже без экспорта, чтобы не рвать трейс соседних сервисов
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var spanExporter sdktrace.SpanExporter
+	switch exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		e, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, fmt.Errorf("create stdout exporter: %w", err)
+		}
+		spanExporter = e
+	case ExporterOTLP:
+		e, err := otlptracegrpc.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("create otlp exporter: %w", err)
+		}
+		spanExporter = e
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q, want %q, %q or %q", exporter, ExporterNone, ExporterStdout, ExporterOTLP)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("create resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,157 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/envoyproxy/protoc-gen-validate/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var numberRules = []protoreflect.Name{"const", "lt", "lte", "gt", "gte", "in", "not_in"}
+
+// supportedRules правила, которые умеет проверять Validate, по полю FieldRules
+var supportedRules = map[protoreflect.Name][]protoreflect.Name{
+	"message":   {"required", "skip"},
+	"string":    {"const", "len", "min_len", "max_len", "pattern", "prefix", "suffix", "contains", "in", "not_in", "uuid"},
+	"int32":     numberRules,
+	"int64":     numberRules,
+	"uint32":    numberRules,
+	"uint64":    numberRules,
+	"double":    numberRules,
+	"enum":      {"const", "defined_only", "in", "not_in"},
+	"repeated":  {"min_items", "max_items", "unique", "items"},
+	"timestamp": {"required", "lt", "lte", "gt", "gte", "lt_now", "gt_now"},
+}
+
+// scalarRules поле FieldRules, правила из которого применяются к значению такого вида
+var scalarRules = map[protoreflect.Kind]protoreflect.Name{
+	protoreflect.StringKind: "string",
+	protoreflect.Int32Kind:  "int32",
+	protoreflect.Int64Kind:  "int64",
+	protoreflect.Uint32Kind: "uint32",
+	protoreflect.Uint64Kind: "uint64",
+	protoreflect.DoubleKind: "double",
+	protoreflect.EnumKind:   "enum",
+}
+
+// CheckRules проверяет, что все правила validate.rules в файлах поддерживаются Validate
+// и подходят к типам полей. Ошибка перечисляет все найденные проблемы
+func CheckRules(files ...protoreflect.FileDescriptor) error {
+	var problems []error
+	for _, file := range files {
+		problems = append(problems, checkMessages(file.Messages())...)
+	}
+	return errors.Join(problems...)
+}
+
+func checkMessages(messages protoreflect.MessageDescriptors) []error {
+	var problems []error
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+
+		if opts, ok := md.Options().(*descriptorpb.MessageOptions); ok && opts != nil {
+			for _, ext := range []protoreflect.ExtensionType{validate.E_Disabled, validate.E_Ignored} {
+				if proto.HasExtension(opts, ext) {
+					problems = append(problems, fmt.Errorf("%s: option %s is not supported", md.FullName(), ext.TypeDescriptor().FullName()))
+				}
+			}
+		}
+
+		fields := md.Fields()
+		for j := 0; j < fields.Len(); j++ {
+			fd := fields.Get(j)
+			rules := fieldRules(fd)
+			if rules == nil {
+				continue
+			}
+			for _, problem := range checkFieldRules(fd, rules, false) {
+				problems = append(problems, fmt.Errorf("%s: %s", fd.FullName(), problem))
+			}
+		}
+
+		problems = append(problems, checkMessages(md.Messages())...)
+	}
+	return problems
+}
+
+// checkFieldRules возвращает проблемы правил поля fd, item - правила элемента repeated
+func checkFieldRules(fd protoreflect.FieldDescriptor, rules *validate.FieldRules, item bool) []string {
+	var problems []string
+	r := rules.ProtoReflect()
+	isMessage := fd.Kind() == protoreflect.MessageKind
+
+	if msgRules := rules.GetMessage(); msgRules != nil {
+		switch {
+		case !isMessage || fd.IsMap():
+			problems = append(problems, "message rules apply only to message fields")
+		case item && msgRules.GetRequired():
+			problems = append(problems, "rule message.required is not supported for repeated items")
+		default:
+			problems = append(problems, unsupported("message", msgRules.ProtoReflect())...)
+		}
+	}
+
+	typ := r.WhichOneof(r.Descriptor().Oneofs().ByName("type"))
+	if typ == nil {
+		return problems
+	}
+
+	want := expectedRules(fd, item)
+	if typ.Name() != want {
+		if want == "" {
+			return append(problems, fmt.Sprintf("%s rules are not supported for this field", typ.Name()))
+		}
+		return append(problems, fmt.Sprintf("%s rules do not apply to a field that takes %s rules", typ.Name(), want))
+	}
+
+	problems = append(problems, unsupported(want, r.Get(typ).Message())...)
+	if items := rules.GetRepeated().GetItems(); want == "repeated" && items != nil {
+		for _, problem := range checkFieldRules(fd, items, true) {
+			problems = append(problems, "items: "+problem)
+		}
+	}
+	return problems
+}
+
+// expectedRules поле FieldRules, которое Validate применяет к полю fd, или пустое имя,
+// если правила типа к полю не применяются
+func expectedRules(fd protoreflect.FieldDescriptor, item bool) protoreflect.Name {
+	switch {
+	case fd.IsMap():
+		return ""
+	case fd.IsList() && !item:
+		return "repeated"
+	case fd.Kind() != protoreflect.MessageKind:
+		return scalarRules[fd.Kind()]
+	case item:
+		// Элементы-сообщения проверяются только своими собственными правилами
+		return ""
+	}
+
+	switch md := fd.Message(); {
+	case md.FullName() == timestampFullName:
+		return "timestamp"
+	case isWrapper(md):
+		return scalarRules[md.Fields().ByName("value").Kind()]
+	default:
+		return ""
+	}
+}
+
+// unsupported возвращает заданные в rules правила, которых нет в supportedRules[name]
+func unsupported(name protoreflect.Name, rules protoreflect.Message) []string {
+	var problems []string
+	rules.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if !slices.Contains(supportedRules[name], fd.Name()) {
+			problems = append(problems, fmt.Sprintf("rule %s.%s is not supported", name, fd.Name()))
+		}
+		return true
+	})
+	// Порядок Range не определен, а ошибка должна быть одинаковой от запуска к запуску
+	slices.Sort(problems)
+	return problems
+}
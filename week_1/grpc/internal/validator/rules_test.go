@@ -0,0 +1,182 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/protoc-gen-validate/validate"
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/validator"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestCheckRulesAcceptsContracts(t *testing.T) {
+	if err := validator.CheckRules(ufoV1.File_ufo_v1_ufo_proto); err != nil {
+		t.Fatalf("ufo.proto uses unsupported rules: %v", err)
+	}
+}
+
+// ruleField описывает поле сообщения test.M с правилами rules
+func ruleField(kind descriptorpb.FieldDescriptorProto_Type, typeName string, repeated bool, rules *validate.FieldRules) *descriptorpb.FieldDescriptorProto {
+	opts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(opts, validate.E_Rules, rules)
+
+	label := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	if repeated {
+		label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	}
+	field := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("f"),
+		JsonName: proto.String("f"),
+		Number:   proto.Int32(1),
+		Label:    label.Enum(),
+		Type:     kind.Enum(),
+		Options:  opts,
+	}
+	if typeName != "" {
+		field.TypeName = proto.String(typeName)
+	}
+	return field
+}
+
+// ruleFile собирает файл с сообщением test.M из одного поля field
+func ruleFile(t *testing.T, field *descriptorpb.FieldDescriptorProto, msgOpts *descriptorpb.MessageOptions) protoreflect.FileDescriptor {
+	t.Helper()
+
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("test/rules.proto"),
+		Package:    proto.String("test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"validate/validate.proto", "google/protobuf/timestamp.proto", "google/protobuf/wrappers.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name:    proto.String("M"),
+			Field:   []*descriptorpb.FieldDescriptorProto{field},
+			Options: msgOpts,
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("FEntry"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("key"), JsonName: proto.String("key"), Number: proto.Int32(1), Label: optional, Type: str},
+					{Name: proto.String("value"), JsonName: proto.String("value"), Number: proto.Int32(2), Label: optional, Type: str},
+				},
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			}},
+		}},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("build descriptor: %v", err)
+	}
+	return file
+}
+
+func TestCheckRules(t *testing.T) {
+	const (
+		stringKind  = descriptorpb.FieldDescriptorProto_TYPE_STRING
+		int32Kind   = descriptorpb.FieldDescriptorProto_TYPE_INT32
+		boolKind    = descriptorpb.FieldDescriptorProto_TYPE_BOOL
+		messageKind = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+	)
+	stringRules := func(r *validate.StringRules) *validate.FieldRules {
+		return &validate.FieldRules{Type: &validate.FieldRules_String_{String_: r}}
+	}
+
+	tests := []struct {
+		name  string
+		field *descriptorpb.FieldDescriptorProto
+		// want часть текста ошибки, пустая строка - правила поддерживаются
+		want string
+	}{
+		{
+			name:  "supported string rules",
+			field: ruleField(stringKind, "", false, stringRules(&validate.StringRules{MinLen: proto.Uint64(1), WellKnown: &validate.StringRules_Uuid{Uuid: true}})),
+		},
+		{
+			name:  "rules on the wrapped value",
+			field: ruleField(messageKind, ".google.protobuf.StringValue", false, stringRules(&validate.StringRules{MaxLen: proto.Uint64(10)})),
+		},
+		{
+			name: "skip for message items",
+			field: ruleField(messageKind, ".google.protobuf.Timestamp", true, &validate.FieldRules{Type: &validate.FieldRules_Repeated{
+				Repeated: &validate.RepeatedRules{Items: &validate.FieldRules{Message: &validate.MessageRules{Skip: proto.Bool(true)}}},
+			}}),
+		},
+		{
+			name:  "unsupported string rule",
+			field: ruleField(stringKind, "", false, stringRules(&validate.StringRules{WellKnown: &validate.StringRules_Email{Email: true}})),
+			want:  "test.M.f: rule string.email is not supported",
+		},
+		{
+			name:  "rules of another type",
+			field: ruleField(stringKind, "", false, &validate.FieldRules{Type: &validate.FieldRules_Int32{Int32: &validate.Int32Rules{Gt: proto.Int32(0)}}}),
+			want:  "int32 rules do not apply to a field that takes string rules",
+		},
+		{
+			name:  "unsupported field type",
+			field: ruleField(boolKind, "", false, &validate.FieldRules{Type: &validate.FieldRules_Bool{Bool: &validate.BoolRules{Const: proto.Bool(true)}}}),
+			want:  "bool rules are not supported for this field",
+		},
+		{
+			name: "map rules",
+			field: ruleField(messageKind, ".test.M.FEntry", true, &validate.FieldRules{Type: &validate.FieldRules_Map{
+				Map: &validate.MapRules{MinPairs: proto.Uint64(1)},
+			}}),
+			want: "map rules are not supported for this field",
+		},
+		{
+			name: "unsupported timestamp rule",
+			field: ruleField(messageKind, ".google.protobuf.Timestamp", false, &validate.FieldRules{Type: &validate.FieldRules_Timestamp{
+				Timestamp: &validate.TimestampRules{Required: proto.Bool(true), Within: durationpb.New(time.Hour)},
+			}}),
+			want: "rule timestamp.within is not supported",
+		},
+		{
+			name: "unsupported rule of repeated items",
+			field: ruleField(stringKind, "", true, &validate.FieldRules{Type: &validate.FieldRules_Repeated{
+				Repeated: &validate.RepeatedRules{Items: stringRules(&validate.StringRules{WellKnown: &validate.StringRules_Hostname{Hostname: true}})},
+			}}),
+			want: "items: rule string.hostname is not supported",
+		},
+		{
+			name: "unsupported repeated rule",
+			field: ruleField(stringKind, "", true, &validate.FieldRules{Type: &validate.FieldRules_Repeated{
+				Repeated: &validate.RepeatedRules{IgnoreEmpty: proto.Bool(true)},
+			}}),
+			want: "rule repeated.ignore_empty is not supported",
+		},
+		{
+			name:  "message rules on a scalar",
+			field: ruleField(int32Kind, "", false, &validate.FieldRules{Message: &validate.MessageRules{Required: proto.Bool(true)}}),
+			want:  "message rules apply only to message fields",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.CheckRules(ruleFile(t, tt.field, nil))
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("CheckRules = %v, want nil", err)
+			case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+				t.Errorf("CheckRules = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckRulesRejectsMessageOptions(t *testing.T) {
+	opts := &descriptorpb.MessageOptions{}
+	proto.SetExtension(opts, validate.E_Disabled, true)
+	field := ruleField(descriptorpb.FieldDescriptorProto_TYPE_STRING, "", false, &validate.FieldRules{})
+
+	err := validator.CheckRules(ruleFile(t, field, opts))
+	if err == nil || !strings.Contains(err.Error(), "option validate.disabled is not supported") {
+		t.Errorf("CheckRules = %v, want disabled option rejected", err)
+	}
+}
@@ -0,0 +1,365 @@
+// Package validator проверяет сообщения по правилам (validate.rules), объявленным в .proto.
+//
+// Правила читаются из дескрипторов во время выполнения, поэтому отдельная генерация
+// кода не нужна. Поддерживается подмножество правил protoc-gen-validate, которое
+// используется в наших контрактах:
+//   - message: required, skip;
+//   - string: const, len, min_len, max_len, pattern, prefix, suffix, contains, in, not_in, uuid;
+//   - int32, int64, uint32, uint64, double: const, lt, lte, gt, gte, in, not_in;
+//     NaN не проходит ни одно правило double;
+//   - enum: const, defined_only, in, not_in;
+//   - repeated: min_items, max_items, unique (кроме сообщений и bytes), items;
+//   - timestamp: required, lt, lte, gt, gte, lt_now, gt_now;
+//   - oneof: required.
+//
+// Правила для google.protobuf.*Value применяются к обернутому значению, если оно задано.
+// Остальные правила, в том числе любые правила для map, не поддерживаются: CheckRules
+// находит их при старте сервера, чтобы правило не выглядело действующим, не проверяясь.
+package validator
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/envoyproxy/protoc-gen-validate/validate"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const timestampFullName = "google.protobuf.Timestamp"
+
+// patterns кэш скомпилированных регулярных выражений из правил string.pattern
+var patterns sync.Map
+
+// Validate проверяет сообщение и возвращает все найденные нарушения.
+// Пустой результат означает, что сообщение корректно
+func Validate(msg proto.Message) []*errdetails.BadRequest_FieldViolation {
+	v := &walker{now: time.Now()}
+	v.message("", msg.ProtoReflect())
+	return v.violations
+}
+
+// BadRequest собирает ошибку InvalidArgument с деталями google.rpc.BadRequest
+func BadRequest(message string, violations []*errdetails.BadRequest_FieldViolation) error {
+	st := status.New(codes.InvalidArgument, message)
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+type walker struct {
+	now        time.Time
+	violations []*errdetails.BadRequest_FieldViolation
+}
+
+func (w *walker) add(path, format string, args ...any) {
+	w.violations = append(w.violations, &errdetails.BadRequest_FieldViolation{
+		Field:       path,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+func (w *walker) message(prefix string, msg protoreflect.Message) {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+
+		// Невыбранные варианты oneof не проверяем
+		if fd.ContainingOneof() != nil && !msg.Has(fd) {
+			continue
+		}
+
+		path := fieldPath(prefix, fd)
+		rules := fieldRules(fd)
+
+		switch {
+		case fd.IsList():
+			w.list(path, fd, msg.Get(fd).List(), rules)
+		case fd.IsMap():
+			// Правила для map отклоняет CheckRules, проверять здесь нечего
+		case fd.Kind() == protoreflect.MessageKind:
+			w.messageField(path, fd, msg, rules)
+		default:
+			w.scalar(path, fd, msg.Get(fd), rules)
+		}
+	}
+
+	oneofs := msg.Descriptor().Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		od := oneofs.Get(i)
+		if !oneofRequired(od) || msg.WhichOneof(od) != nil {
+			continue
+		}
+
+		names := make([]string, 0, od.Fields().Len())
+		for j := 0; j < od.Fields().Len(); j++ {
+			names = append(names, string(od.Fields().Get(j).Name()))
+		}
+		path := string(od.Name())
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		w.add(path, "exactly one of %s is required", strings.Join(names, ", "))
+	}
+}
+
+func (w *walker) list(path string, fd protoreflect.FieldDescriptor, list protoreflect.List, rules *validate.FieldRules) {
+	repeated := rules.GetRepeated()
+	if repeated.GetMinItems() > 0 && uint64(list.Len()) < repeated.GetMinItems() {
+		w.add(path, "must contain at least %d items", repeated.GetMinItems())
+	}
+	if repeated != nil && repeated.MaxItems != nil && uint64(list.Len()) > repeated.GetMaxItems() {
+		w.add(path, "must contain at most %d items", repeated.GetMaxItems())
+	}
+
+	var seen map[any]struct{}
+	// Значения bytes - срезы, ключом карты они быть не могут
+	if repeated.GetUnique() && fd.Kind() != protoreflect.MessageKind && fd.Kind() != protoreflect.BytesKind {
+		seen = make(map[any]struct{}, list.Len())
+	}
+
+	for i := 0; i < list.Len(); i++ {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if seen != nil {
+			if _, dup := seen[list.Get(i).Interface()]; dup {
+				w.add(itemPath, "must not repeat an earlier item")
+			}
+			seen[list.Get(i).Interface()] = struct{}{}
+		}
+		if fd.Kind() == protoreflect.MessageKind {
+			if !repeated.GetItems().GetMessage().GetSkip() {
+				w.message(itemPath, list.Get(i).Message())
+			}
+			continue
+		}
+		w.scalar(itemPath, fd, list.Get(i), repeated.GetItems())
+	}
+}
+
+func (w *walker) messageField(path string, fd protoreflect.FieldDescriptor, parent protoreflect.Message, rules *validate.FieldRules) {
+	if !parent.Has(fd) {
+		if rules.GetMessage().GetRequired() || rules.GetTimestamp().GetRequired() {
+			w.add(path, "value is required")
+		}
+		return
+	}
+
+	msg := parent.Get(fd).Message()
+	switch md := msg.Descriptor(); {
+	case md.FullName() == timestampFullName:
+		w.timestamp(path, msg, rules.GetTimestamp())
+	case isWrapper(md):
+		w.scalar(path, md.Fields().ByName("value"), msg.Get(md.Fields().ByName("value")), rules)
+	case !rules.GetMessage().GetSkip():
+		w.message(path, msg)
+	}
+}
+
+func (w *walker) timestamp(path string, msg protoreflect.Message, rules *validate.TimestampRules) {
+	if rules == nil {
+		return
+	}
+
+	ts := &timestamppb.Timestamp{}
+	proto.Merge(ts, msg.Interface())
+	if err := ts.CheckValid(); err != nil {
+		w.add(path, "invalid timestamp: %v", err)
+		return
+	}
+	t := ts.AsTime()
+
+	if rules.GetLtNow() && !t.Before(w.now) {
+		w.add(path, "must be in the past")
+	}
+	if rules.GetGtNow() && !t.After(w.now) {
+		w.add(path, "must be in the future")
+	}
+	if rules.Lt != nil && !t.Before(rules.GetLt().AsTime()) {
+		w.add(path, "must be before %s", rules.GetLt().AsTime().Format(time.RFC3339))
+	}
+	if rules.Lte != nil && t.After(rules.GetLte().AsTime()) {
+		w.add(path, "must be at or before %s", rules.GetLte().AsTime().Format(time.RFC3339))
+	}
+	if rules.Gt != nil && !t.After(rules.GetGt().AsTime()) {
+		w.add(path, "must be after %s", rules.GetGt().AsTime().Format(time.RFC3339))
+	}
+	if rules.Gte != nil && t.Before(rules.GetGte().AsTime()) {
+		w.add(path, "must be at or after %s", rules.GetGte().AsTime().Format(time.RFC3339))
+	}
+}
+
+func (w *walker) scalar(path string, fd protoreflect.FieldDescriptor, value protoreflect.Value, rules *validate.FieldRules) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		w.string(path, value.String(), rules.GetString_())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if r := rules.GetInt32(); r != nil {
+			checkNumber(w, path, int32(value.Int()), r.Const, r.Lt, r.Lte, r.Gt, r.Gte, r.GetIn(), r.GetNotIn())
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if r := rules.GetInt64(); r != nil {
+			checkNumber(w, path, value.Int(), r.Const, r.Lt, r.Lte, r.Gt, r.Gte, r.GetIn(), r.GetNotIn())
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if r := rules.GetUint32(); r != nil {
+			checkNumber(w, path, uint32(value.Uint()), r.Const, r.Lt, r.Lte, r.Gt, r.Gte, r.GetIn(), r.GetNotIn())
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if r := rules.GetUint64(); r != nil {
+			checkNumber(w, path, value.Uint(), r.Const, r.Lt, r.Lte, r.Gt, r.Gte, r.GetIn(), r.GetNotIn())
+		}
+	case protoreflect.DoubleKind:
+		if r := rules.GetDouble(); r != nil {
+			if math.IsNaN(value.Float()) {
+				w.add(path, "must be a number")
+				return
+			}
+			checkNumber(w, path, value.Float(), r.Const, r.Lt, r.Lte, r.Gt, r.Gte, r.GetIn(), r.GetNotIn())
+		}
+	case protoreflect.EnumKind:
+		w.enum(path, fd, value.Enum(), rules.GetEnum())
+	}
+}
+
+func (w *walker) string(path, value string, rules *validate.StringRules) {
+	if rules == nil {
+		return
+	}
+
+	length := uint64(utf8.RuneCountInString(value))
+	if rules.Const != nil && value != rules.GetConst() {
+		w.add(path, "must equal %q", rules.GetConst())
+	}
+	if rules.Len != nil && length != rules.GetLen() {
+		w.add(path, "length must be %d characters", rules.GetLen())
+	}
+	if rules.MinLen != nil && length < rules.GetMinLen() {
+		w.add(path, "length must be at least %d characters", rules.GetMinLen())
+	}
+	if rules.MaxLen != nil && length > rules.GetMaxLen() {
+		w.add(path, "length must be at most %d characters", rules.GetMaxLen())
+	}
+	if rules.Prefix != nil && !strings.HasPrefix(value, rules.GetPrefix()) {
+		w.add(path, "must start with %q", rules.GetPrefix())
+	}
+	if rules.Suffix != nil && !strings.HasSuffix(value, rules.GetSuffix()) {
+		w.add(path, "must end with %q", rules.GetSuffix())
+	}
+	if rules.Contains != nil && !strings.Contains(value, rules.GetContains()) {
+		w.add(path, "must contain %q", rules.GetContains())
+	}
+	if len(rules.GetIn()) > 0 && !slices.Contains(rules.GetIn(), value) {
+		w.add(path, "must be one of %q", rules.GetIn())
+	}
+	if len(rules.GetNotIn()) > 0 && slices.Contains(rules.GetNotIn(), value) {
+		w.add(path, "must not be one of %q", rules.GetNotIn())
+	}
+	if rules.Pattern != nil && !compiledPattern(rules.GetPattern()).MatchString(value) {
+		w.add(path, "must match pattern %q", rules.GetPattern())
+	}
+	if rules.GetUuid() {
+		if err := uuid.Validate(value); err != nil {
+			w.add(path, "must be a valid UUID")
+		}
+	}
+}
+
+func (w *walker) enum(path string, fd protoreflect.FieldDescriptor, value protoreflect.EnumNumber, rules *validate.EnumRules) {
+	if rules == nil {
+		return
+	}
+
+	number := int32(value)
+	if rules.Const != nil && number != rules.GetConst() {
+		w.add(path, "must equal %d", rules.GetConst())
+	}
+	if rules.GetDefinedOnly() && fd.Enum().Values().ByNumber(value) == nil {
+		w.add(path, "must be a defined enum value")
+	}
+	if len(rules.GetIn()) > 0 && !slices.Contains(rules.GetIn(), number) {
+		w.add(path, "must be one of %v", rules.GetIn())
+	}
+	if len(rules.GetNotIn()) > 0 && slices.Contains(rules.GetNotIn(), number) {
+		w.add(path, "must not be one of %v", rules.GetNotIn())
+	}
+}
+
+type number interface {
+	~int32 | ~int64 | ~uint32 | ~uint64 | ~float64
+}
+
+func checkNumber[T number](w *walker, path string, value T, eq, lt, lte, gt, gte *T, in, notIn []T) {
+	if eq != nil && value != *eq {
+		w.add(path, "must equal %v", *eq)
+	}
+	if lt != nil && value >= *lt {
+		w.add(path, "must be less than %v", *lt)
+	}
+	if lte != nil && value > *lte {
+		w.add(path, "must be less than or equal to %v", *lte)
+	}
+	if gt != nil && value <= *gt {
+		w.add(path, "must be greater than %v", *gt)
+	}
+	if gte != nil && value < *gte {
+		w.add(path, "must be greater than or equal to %v", *gte)
+	}
+	if len(in) > 0 && !slices.Contains(in, value) {
+		w.add(path, "must be one of %v", in)
+	}
+	if len(notIn) > 0 && slices.Contains(notIn, value) {
+		w.add(path, "must not be one of %v", notIn)
+	}
+}
+
+func fieldRules(fd protoreflect.FieldDescriptor) *validate.FieldRules {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil {
+		return nil
+	}
+	rules, _ := proto.GetExtension(opts, validate.E_Rules).(*validate.FieldRules)
+	return rules
+}
+
+func oneofRequired(od protoreflect.OneofDescriptor) bool {
+	opts, ok := od.Options().(*descriptorpb.OneofOptions)
+	if !ok || opts == nil {
+		return false
+	}
+	required, _ := proto.GetExtension(opts, validate.E_Required).(bool)
+	return required
+}
+
+func fieldPath(prefix string, fd protoreflect.FieldDescriptor) string {
+	if prefix == "" {
+		return string(fd.Name())
+	}
+	return prefix + "." + string(fd.Name())
+}
+
+func isWrapper(md protoreflect.MessageDescriptor) bool {
+	return md.ParentFile().Path() == "google/protobuf/wrappers.proto"
+}
+
+func compiledPattern(pattern string) *regexp.Regexp {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re := regexp.MustCompile(pattern)
+	patterns.Store(pattern, re)
+	return re
+}
@@ -0,0 +1,170 @@
+package validator_test
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yyunoshev/yyunoshev_go/week_1/grpc/internal/validator"
+	ufoV1 "github.com/yyunoshev/yyunoshev_go/week_1/grpc/pkg/proto/ufo/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const validUUID = "6f1c2b8e-3a4d-4f5e-9b6a-7c8d9e0f1a2b"
+
+func validInfo() *ufoV1.SightingInfo {
+	return &ufoV1.SightingInfo{
+		ObservedAt:  timestamppb.New(time.Now().Add(-time.Hour)),
+		Location:    "Roswell",
+		Description: "Silver disc",
+	}
+}
+
+// withInfo возвращает CreateRequest с info, измененным change
+func withInfo(change func(info *ufoV1.SightingInfo)) *ufoV1.CreateRequest {
+	info := validInfo()
+	change(info)
+	return &ufoV1.CreateRequest{Info: info}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  proto.Message
+		// want пути нарушений в порядке обхода, пусто - сообщение корректно
+		want []string
+	}{
+		{name: "valid create", msg: &ufoV1.CreateRequest{Info: validInfo()}},
+
+		// message.required
+		{name: "missing info", msg: &ufoV1.CreateRequest{}, want: []string{"info"}},
+
+		// timestamp: required, lt_now
+		{name: "missing observed_at", msg: withInfo(func(i *ufoV1.SightingInfo) { i.ObservedAt = nil }), want: []string{"info.observed_at"}},
+		{name: "observed_at in the future", msg: withInfo(func(i *ufoV1.SightingInfo) {
+			i.ObservedAt = timestamppb.New(time.Now().Add(time.Minute))
+		}), want: []string{"info.observed_at"}},
+		{name: "invalid observed_at", msg: withInfo(func(i *ufoV1.SightingInfo) {
+			i.ObservedAt = &timestamppb.Timestamp{Nanos: -1}
+		}), want: []string{"info.observed_at"}},
+		{name: "update observed_at is optional", msg: &ufoV1.SightingUpdateInfo{}},
+		{name: "update observed_at in the future", msg: &ufoV1.SightingUpdateInfo{
+			ObservedAt: timestamppb.New(time.Now().Add(time.Hour)),
+		}, want: []string{"observed_at"}},
+
+		// string: min_len, max_len в символах, а не байтах
+		{name: "empty location", msg: withInfo(func(i *ufoV1.SightingInfo) { i.Location = "" }), want: []string{"info.location"}},
+		{name: "location too long", msg: withInfo(func(i *ufoV1.SightingInfo) { i.Location = strings.Repeat("a", 257) }), want: []string{"info.location"}},
+		{name: "cyrillic location at the limit", msg: withInfo(func(i *ufoV1.SightingInfo) { i.Location = strings.Repeat("ж", 256) })},
+		{name: "several violations", msg: withInfo(func(i *ufoV1.SightingInfo) {
+			i.Location = ""
+			i.Description = ""
+		}), want: []string{"info.location", "info.description"}},
+
+		// Правила обертки применяются к значению, если оно задано
+		{name: "color too long", msg: withInfo(func(i *ufoV1.SightingInfo) { i.Color = wrapperspb.String(strings.Repeat("c", 65)) }), want: []string{"info.color"}},
+		{name: "empty update location", msg: &ufoV1.SightingUpdateInfo{Location: wrapperspb.String("")}, want: []string{"location"}},
+
+		// int32.gt
+		{name: "zero duration", msg: withInfo(func(i *ufoV1.SightingInfo) { i.DurationSeconds = wrapperspb.Int32(0) }), want: []string{"info.duration_seconds"}},
+		{name: "negative duration", msg: withInfo(func(i *ufoV1.SightingInfo) { i.DurationSeconds = wrapperspb.Int32(-5) }), want: []string{"info.duration_seconds"}},
+		{name: "positive duration", msg: withInfo(func(i *ufoV1.SightingInfo) { i.DurationSeconds = wrapperspb.Int32(1) })},
+
+		// double: gte, lte и NaN во вложенном сообщении
+		{name: "latitude out of range", msg: withInfo(func(i *ufoV1.SightingInfo) {
+			i.Coordinates = &ufoV1.GeoPoint{Latitude: 90.5, Longitude: 0}
+		}), want: []string{"info.coordinates.latitude"}},
+		{name: "longitude at the bound", msg: withInfo(func(i *ufoV1.SightingInfo) {
+			i.Coordinates = &ufoV1.GeoPoint{Latitude: -90, Longitude: -180}
+		})},
+		{name: "NaN latitude", msg: withInfo(func(i *ufoV1.SightingInfo) {
+			i.Coordinates = &ufoV1.GeoPoint{Latitude: math.NaN()}
+		}), want: []string{"info.coordinates.latitude"}},
+		{name: "zero radius", msg: &ufoV1.GeoCircle{Center: &ufoV1.GeoPoint{}, RadiusKm: 0}, want: []string{"radius_km"}},
+
+		// int32.gte, int32.lte
+		{name: "negative page size", msg: &ufoV1.ListRequest{PageSize: -1}, want: []string{"page_size"}},
+		{name: "negative history page size", msg: &ufoV1.GetHistoryRequest{Uuid: validUUID, PageSize: -1}, want: []string{"page_size"}},
+		{name: "group limit too large", msg: &ufoV1.GetStatsRequest{GroupLimit: 1001}, want: []string{"group_limit"}},
+
+		// int64.gt в обертке
+		{name: "zero expected version", msg: &ufoV1.DeleteRequest{Uuid: validUUID, ExpectedVersion: wrapperspb.Int64(0)}, want: []string{"expected_version"}},
+
+		// string.uuid
+		{name: "invalid uuid", msg: &ufoV1.GetRequest{Uuid: "not-a-uuid"}, want: []string{"uuid"}},
+		{name: "valid uuid", msg: &ufoV1.GetRequest{Uuid: validUUID}},
+
+		// string.pattern
+		{name: "sha256 pattern", msg: &ufoV1.UploadAttachmentHeader{
+			SightingUuid: validUUID, FileName: "a.png", ContentType: "image/png", SizeBytes: 1, Sha256: "ABC",
+		}, want: []string{"sha256"}},
+
+		// oneof required
+		{name: "missing area", msg: &ufoV1.FindNearbyRequest{}, want: []string{"area"}},
+		{name: "area in oneof is validated", msg: &ufoV1.FindNearbyRequest{
+			Area: &ufoV1.FindNearbyRequest_Circle{Circle: &ufoV1.GeoCircle{RadiusKm: 10}},
+		}, want: []string{"circle.center"}},
+
+		// enum: defined_only, not_in
+		{name: "unspecified status", msg: &ufoV1.TransitionStatusRequest{Uuid: validUUID}, want: []string{"status"}},
+		{name: "undefined status", msg: &ufoV1.TransitionStatusRequest{Uuid: validUUID, Status: 42}, want: []string{"status"}},
+
+		// repeated: min_items, max_items, unique, items
+		{name: "no duplicates to merge", msg: &ufoV1.MergeSightingsRequest{CanonicalUuid: validUUID}, want: []string{"duplicate_uuids"}},
+		{name: "repeated duplicate uuid", msg: &ufoV1.MergeSightingsRequest{
+			CanonicalUuid: validUUID, DuplicateUuids: []string{validUUID, "bad", validUUID},
+		}, want: []string{"duplicate_uuids[1]", "duplicate_uuids[2]"}},
+		{name: "too many statuses", msg: &ufoV1.ListFilter{Statuses: []ufoV1.SightingStatus{1, 2, 3, 4, 5, 1}}, want: []string{"statuses", "statuses[5]"}},
+		{name: "unspecified status in filter", msg: &ufoV1.ListFilter{Statuses: []ufoV1.SightingStatus{0}}, want: []string{"statuses[0]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range validator.Validate(tt.msg) {
+				got = append(got, v.GetField())
+				if v.GetDescription() == "" {
+					t.Errorf("violation of %s has no description", v.GetField())
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBadRequest(t *testing.T) {
+	violations := validator.Validate(withInfo(func(i *ufoV1.SightingInfo) {
+		i.ObservedAt = timestamppb.New(time.Now().Add(time.Hour))
+		i.DurationSeconds = wrapperspb.Int32(0)
+	}))
+
+	st := status.Convert(validator.BadRequest("invalid CreateRequest", violations))
+	if st.Code() != codes.InvalidArgument || st.Message() != "invalid CreateRequest" {
+		t.Fatalf("status = %s %q, want InvalidArgument", st.Code(), st.Message())
+	}
+
+	var got []string
+	for _, detail := range st.Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				got = append(got, v.GetField()+": "+v.GetDescription())
+			}
+		}
+	}
+	want := []string{
+		"info.observed_at: must be in the past",
+		"info.duration_seconds: must be greater than 0",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("BadRequest violations = %q, want %q", got, want)
+	}
+}
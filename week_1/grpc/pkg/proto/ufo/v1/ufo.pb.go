@@ -7,9 +7,13 @@
 package ufo_v1
 
 import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
@@ -24,15 +28,141 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// SightingStatus этап модерации наблюдения
+type SightingStatus int32
+
+const (
+	SightingStatus_SIGHTING_STATUS_UNSPECIFIED SightingStatus = 0
+	// SIGHTING_STATUS_SUBMITTED сообщение принято и ждет проверки
+	SightingStatus_SIGHTING_STATUS_SUBMITTED SightingStatus = 1
+	// SIGHTING_STATUS_UNDER_REVIEW аналитик проверяет сообщение
+	SightingStatus_SIGHTING_STATUS_UNDER_REVIEW SightingStatus = 2
+	// SIGHTING_STATUS_VERIFIED наблюдение подтверждено
+	SightingStatus_SIGHTING_STATUS_VERIFIED SightingStatus = 3
+	// SIGHTING_STATUS_REJECTED сообщение отклонено: недостаточно данных или это не НЛО
+	SightingStatus_SIGHTING_STATUS_REJECTED SightingStatus = 4
+	// SIGHTING_STATUS_HOAX сообщение признано розыгрышем
+	SightingStatus_SIGHTING_STATUS_HOAX SightingStatus = 5
+)
+
+// Enum value maps for SightingStatus.
+var (
+	SightingStatus_name = map[int32]string{
+		0: "SIGHTING_STATUS_UNSPECIFIED",
+		1: "SIGHTING_STATUS_SUBMITTED",
+		2: "SIGHTING_STATUS_UNDER_REVIEW",
+		3: "SIGHTING_STATUS_VERIFIED",
+		4: "SIGHTING_STATUS_REJECTED",
+		5: "SIGHTING_STATUS_HOAX",
+	}
+	SightingStatus_value = map[string]int32{
+		"SIGHTING_STATUS_UNSPECIFIED":  0,
+		"SIGHTING_STATUS_SUBMITTED":    1,
+		"SIGHTING_STATUS_UNDER_REVIEW": 2,
+		"SIGHTING_STATUS_VERIFIED":     3,
+		"SIGHTING_STATUS_REJECTED":     4,
+		"SIGHTING_STATUS_HOAX":         5,
+	}
+)
+
+func (x SightingStatus) Enum() *SightingStatus {
+	p := new(SightingStatus)
+	*p = x
+	return p
+}
+
+func (x SightingStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SightingStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_ufo_v1_ufo_proto_enumTypes[0].Descriptor()
+}
+
+func (SightingStatus) Type() protoreflect.EnumType {
+	return &file_ufo_v1_ufo_proto_enumTypes[0]
+}
+
+func (x SightingStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SightingStatus.Descriptor instead.
+func (SightingStatus) EnumDescriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{0}
+}
+
+// SightingEventType тип изменения наблюдения
+type SightingEventType int32
+
+const (
+	SightingEventType_SIGHTING_EVENT_TYPE_UNSPECIFIED SightingEventType = 0
+	SightingEventType_SIGHTING_EVENT_TYPE_CREATED     SightingEventType = 1
+	SightingEventType_SIGHTING_EVENT_TYPE_UPDATED     SightingEventType = 2
+	SightingEventType_SIGHTING_EVENT_TYPE_DELETED     SightingEventType = 3
+	SightingEventType_SIGHTING_EVENT_TYPE_RESTORED    SightingEventType = 4
+	// SIGHTING_EVENT_TYPE_PURGED наблюдение удалено безвозвратно
+	SightingEventType_SIGHTING_EVENT_TYPE_PURGED SightingEventType = 5
+)
+
+// Enum value maps for SightingEventType.
+var (
+	SightingEventType_name = map[int32]string{
+		0: "SIGHTING_EVENT_TYPE_UNSPECIFIED",
+		1: "SIGHTING_EVENT_TYPE_CREATED",
+		2: "SIGHTING_EVENT_TYPE_UPDATED",
+		3: "SIGHTING_EVENT_TYPE_DELETED",
+		4: "SIGHTING_EVENT_TYPE_RESTORED",
+		5: "SIGHTING_EVENT_TYPE_PURGED",
+	}
+	SightingEventType_value = map[string]int32{
+		"SIGHTING_EVENT_TYPE_UNSPECIFIED": 0,
+		"SIGHTING_EVENT_TYPE_CREATED":     1,
+		"SIGHTING_EVENT_TYPE_UPDATED":     2,
+		"SIGHTING_EVENT_TYPE_DELETED":     3,
+		"SIGHTING_EVENT_TYPE_RESTORED":    4,
+		"SIGHTING_EVENT_TYPE_PURGED":      5,
+	}
+)
+
+func (x SightingEventType) Enum() *SightingEventType {
+	p := new(SightingEventType)
+	*p = x
+	return p
+}
+
+func (x SightingEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SightingEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_ufo_v1_ufo_proto_enumTypes[1].Descriptor()
+}
+
+func (SightingEventType) Type() protoreflect.EnumType {
+	return &file_ufo_v1_ufo_proto_enumTypes[1]
+}
+
+func (x SightingEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SightingEventType.Descriptor instead.
+func (SightingEventType) EnumDescriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{1}
+}
+
 // SightingInfo базовая информация о наблюдении НЛО
 type SightingInfo struct {
-	state           protoimpl.MessageState  `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// observed_at время наблюдения, не может быть в будущем
 	ObservedAt      *timestamppb.Timestamp  `protobuf:"bytes,1,opt,name=observed_at,json=observedAt,proto3" json:"observed_at,omitempty"`
 	Location        string                  `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`                                      // обязаловка
 	Description     string                  `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`                                // обязаловка
 	Color           *wrapperspb.StringValue `protobuf:"bytes,4,opt,name=color,proto3" json:"color,omitempty"`                                            // Опционально
 	Sound           *wrapperspb.StringValue `protobuf:"bytes,5,opt,name=sound,proto3" json:"sound,omitempty"`                                            // Опционально
 	DurationSeconds *wrapperspb.Int32Value  `protobuf:"bytes,6,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Продолжительность наблюдения в секундах (опционально)
+	Coordinates     *GeoPoint               `protobuf:"bytes,7,opt,name=coordinates,proto3" json:"coordinates,omitempty"`                                // Координаты места наблюдения (опционально)
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -109,6 +239,14 @@ func (x *SightingInfo) GetDurationSeconds() *wrapperspb.Int32Value {
 	return nil
 }
 
+func (x *SightingInfo) GetCoordinates() *GeoPoint {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+// SightingUpdateInfo новые значения полей наблюдения, незаданные поля не меняются
 type SightingUpdateInfo struct {
 	state           protoimpl.MessageState  `protogen:"open.v1"`
 	ObservedAt      *timestamppb.Timestamp  `protobuf:"bytes,1,opt,name=observed_at,json=observedAt,proto3" json:"observed_at,omitempty"`
@@ -117,6 +255,7 @@ type SightingUpdateInfo struct {
 	Color           *wrapperspb.StringValue `protobuf:"bytes,4,opt,name=color,proto3" json:"color,omitempty"`                                            // Опционально
 	Sound           *wrapperspb.StringValue `protobuf:"bytes,5,opt,name=sound,proto3" json:"sound,omitempty"`                                            // Опционально
 	DurationSeconds *wrapperspb.Int32Value  `protobuf:"bytes,6,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Продолжительность наблюдения в секундах (опционально)
+	Coordinates     *GeoPoint               `protobuf:"bytes,7,opt,name=coordinates,proto3" json:"coordinates,omitempty"`                                // Координаты места наблюдения (опционально)
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -193,6 +332,66 @@ func (x *SightingUpdateInfo) GetDurationSeconds() *wrapperspb.Int32Value {
 	return nil
 }
 
+func (x *SightingUpdateInfo) GetCoordinates() *GeoPoint {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+// GeoPoint точка на поверхности Земли в градусах WGS 84
+type GeoPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoPoint) Reset() {
+	*x = GeoPoint{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoPoint) ProtoMessage() {}
+
+func (x *GeoPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoPoint.ProtoReflect.Descriptor instead.
+func (*GeoPoint) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GeoPoint) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *GeoPoint) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
 type Sighting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// uuid уникальный идентификатор наблюдения
@@ -204,14 +403,33 @@ type Sighting struct {
 	// updated_at время последнего обновления записи
 	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	// deleted_at время удаления записи (опционально)
-	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// version версия записи: 1 при создании, растет на 1 при каждом изменении
+	Version int64 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	// attachments метаданные загруженных фото и видео, содержимое отдает DownloadAttachment
+	Attachments []*Attachment `protobuf:"bytes,7,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	// possible_duplicates UUID похожих наблюдений, найденных при создании: близкое время,
+	// место и описание. Это только подсказка для MergeSightings, сервер ничего не объединяет сам.
+	// Объединенные в другое наблюдение UUID из списка убираются
+	PossibleDuplicates []string `protobuf:"bytes,8,rep,name=possible_duplicates,json=possibleDuplicates,proto3" json:"possible_duplicates,omitempty"`
+	// merged_into UUID основного наблюдения, в которое объединили это. Объединенное
+	// наблюдение удаляется, но не очищается по сроку хранения и не восстанавливается
+	MergedInto string `protobuf:"bytes,9,opt,name=merged_into,json=mergedInto,proto3" json:"merged_into,omitempty"`
+	// merged_from UUID наблюдений, объединенных в это
+	MergedFrom []string `protobuf:"bytes,10,rep,name=merged_from,json=mergedFrom,proto3" json:"merged_from,omitempty"`
+	// status этап модерации, новые наблюдения получают SUBMITTED. Меняется только через TransitionStatus
+	Status SightingStatus `protobuf:"varint,11,opt,name=status,proto3,enum=ufo.v1.SightingStatus" json:"status,omitempty"`
+	// review_notes журнал модерации: по записи на каждую смену статуса
+	ReviewNotes []*ReviewNote `protobuf:"bytes,12,rep,name=review_notes,json=reviewNotes,proto3" json:"review_notes,omitempty"`
+	// witnesses очевидцы события, меняются через AddWitness и RemoveWitness
+	Witnesses     []*Witness `protobuf:"bytes,13,rep,name=witnesses,proto3" json:"witnesses,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Sighting) Reset() {
 	*x = Sighting{}
-	mi := &file_ufo_v1_ufo_proto_msgTypes[2]
+	mi := &file_ufo_v1_ufo_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -223,7 +441,7 @@ func (x *Sighting) String() string {
 func (*Sighting) ProtoMessage() {}
 
 func (x *Sighting) ProtoReflect() protoreflect.Message {
-	mi := &file_ufo_v1_ufo_proto_msgTypes[2]
+	mi := &file_ufo_v1_ufo_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -236,7 +454,7 @@ func (x *Sighting) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Sighting.ProtoReflect.Descriptor instead.
 func (*Sighting) Descriptor() ([]byte, []int) {
-	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{2}
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Sighting) GetUuid() string {
@@ -274,71 +492,90 @@ func (x *Sighting) GetDeletedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-type CreateRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Info          *SightingInfo          `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Sighting) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
 }
 
-func (x *CreateRequest) Reset() {
-	*x = CreateRequest{}
-	mi := &file_ufo_v1_ufo_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Sighting) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
 }
 
-func (x *CreateRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Sighting) GetPossibleDuplicates() []string {
+	if x != nil {
+		return x.PossibleDuplicates
+	}
+	return nil
 }
 
-func (*CreateRequest) ProtoMessage() {}
+func (x *Sighting) GetMergedInto() string {
+	if x != nil {
+		return x.MergedInto
+	}
+	return ""
+}
 
-func (x *CreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ufo_v1_ufo_proto_msgTypes[3]
+func (x *Sighting) GetMergedFrom() []string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.MergedFrom
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use CreateRequest.ProtoReflect.Descriptor instead.
-func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{3}
+func (x *Sighting) GetStatus() SightingStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SightingStatus_SIGHTING_STATUS_UNSPECIFIED
 }
 
-func (x *CreateRequest) GetInfo() *SightingInfo {
+func (x *Sighting) GetReviewNotes() []*ReviewNote {
 	if x != nil {
-		return x.Info
+		return x.ReviewNotes
 	}
 	return nil
 }
 
-type CreateResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+func (x *Sighting) GetWitnesses() []*Witness {
+	if x != nil {
+		return x.Witnesses
+	}
+	return nil
+}
+
+// WitnessInfo данные очевидца
+type WitnessInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// contact телефон или email для связи (опционально)
+	Contact string `protobuf:"bytes,2,opt,name=contact,proto3" json:"contact,omitempty"`
+	// credibility доверие к показаниям от 0 до 1 по оценке аналитика
+	Credibility float64 `protobuf:"fixed64,3,opt,name=credibility,proto3" json:"credibility,omitempty"`
+	// anonymous очевидец просил не раскрывать имя: его видят только роли с доступом к контактам
+	Anonymous     bool `protobuf:"varint,4,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateResponse) Reset() {
-	*x = CreateResponse{}
+func (x *WitnessInfo) Reset() {
+	*x = WitnessInfo{}
 	mi := &file_ufo_v1_ufo_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateResponse) String() string {
+func (x *WitnessInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateResponse) ProtoMessage() {}
+func (*WitnessInfo) ProtoMessage() {}
 
-func (x *CreateResponse) ProtoReflect() protoreflect.Message {
+func (x *WitnessInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_ufo_v1_ufo_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -350,39 +587,68 @@ func (x *CreateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateResponse.ProtoReflect.Descriptor instead.
-func (*CreateResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use WitnessInfo.ProtoReflect.Descriptor instead.
+func (*WitnessInfo) Descriptor() ([]byte, []int) {
 	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *CreateResponse) GetUuid() string {
+func (x *WitnessInfo) GetName() string {
 	if x != nil {
-		return x.Uuid
+		return x.Name
 	}
 	return ""
 }
 
-type GetRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+func (x *WitnessInfo) GetContact() string {
+	if x != nil {
+		return x.Contact
+	}
+	return ""
+}
+
+func (x *WitnessInfo) GetCredibility() float64 {
+	if x != nil {
+		return x.Credibility
+	}
+	return 0
+}
+
+func (x *WitnessInfo) GetAnonymous() bool {
+	if x != nil {
+		return x.Anonymous
+	}
+	return false
+}
+
+// Witness очевидец наблюдения
+type Witness struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id идентификатор записи об очевидце в этом наблюдении
+	Id   string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Info *WitnessInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	// added_by кто добавил очевидца
+	AddedBy string                 `protobuf:"bytes,3,opt,name=added_by,json=addedBy,proto3" json:"added_by,omitempty"`
+	AddedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	// redacted контакт (и имя анонимного очевидца) скрыты: у вызывающего нет роли analyst или admin
+	Redacted      bool `protobuf:"varint,5,opt,name=redacted,proto3" json:"redacted,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetRequest) Reset() {
-	*x = GetRequest{}
+func (x *Witness) Reset() {
+	*x = Witness{}
 	mi := &file_ufo_v1_ufo_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRequest) String() string {
+func (x *Witness) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRequest) ProtoMessage() {}
+func (*Witness) ProtoMessage() {}
 
-func (x *GetRequest) ProtoReflect() protoreflect.Message {
+func (x *Witness) ProtoReflect() protoreflect.Message {
 	mi := &file_ufo_v1_ufo_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -394,39 +660,74 @@ func (x *GetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
-func (*GetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Witness.ProtoReflect.Descriptor instead.
+func (*Witness) Descriptor() ([]byte, []int) {
 	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetRequest) GetUuid() string {
+func (x *Witness) GetId() string {
 	if x != nil {
-		return x.Uuid
+		return x.Id
 	}
 	return ""
 }
 
-type GetResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sighting      *Sighting              `protobuf:"bytes,1,opt,name=sighting,proto3" json:"sighting,omitempty"`
+func (x *Witness) GetInfo() *WitnessInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *Witness) GetAddedBy() string {
+	if x != nil {
+		return x.AddedBy
+	}
+	return ""
+}
+
+func (x *Witness) GetAddedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AddedAt
+	}
+	return nil
+}
+
+func (x *Witness) GetRedacted() bool {
+	if x != nil {
+		return x.Redacted
+	}
+	return false
+}
+
+// ReviewNote запись журнала модерации
+type ReviewNote struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// reviewer кто сменил статус
+	Reviewer   string         `protobuf:"bytes,1,opt,name=reviewer,proto3" json:"reviewer,omitempty"`
+	FromStatus SightingStatus `protobuf:"varint,2,opt,name=from_status,json=fromStatus,proto3,enum=ufo.v1.SightingStatus" json:"from_status,omitempty"`
+	ToStatus   SightingStatus `protobuf:"varint,3,opt,name=to_status,json=toStatus,proto3,enum=ufo.v1.SightingStatus" json:"to_status,omitempty"`
+	// note пояснение рецензента, обязательно для REJECTED и HOAX
+	Note          string                 `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetResponse) Reset() {
-	*x = GetResponse{}
+func (x *ReviewNote) Reset() {
+	*x = ReviewNote{}
 	mi := &file_ufo_v1_ufo_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetResponse) String() string {
+func (x *ReviewNote) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetResponse) ProtoMessage() {}
+func (*ReviewNote) ProtoMessage() {}
 
-func (x *GetResponse) ProtoReflect() protoreflect.Message {
+func (x *ReviewNote) ProtoReflect() protoreflect.Message {
 	mi := &file_ufo_v1_ufo_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -438,40 +739,75 @@ func (x *GetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
-func (*GetResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReviewNote.ProtoReflect.Descriptor instead.
+func (*ReviewNote) Descriptor() ([]byte, []int) {
 	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetResponse) GetSighting() *Sighting {
+func (x *ReviewNote) GetReviewer() string {
 	if x != nil {
-		return x.Sighting
+		return x.Reviewer
+	}
+	return ""
+}
+
+func (x *ReviewNote) GetFromStatus() SightingStatus {
+	if x != nil {
+		return x.FromStatus
+	}
+	return SightingStatus_SIGHTING_STATUS_UNSPECIFIED
+}
+
+func (x *ReviewNote) GetToStatus() SightingStatus {
+	if x != nil {
+		return x.ToStatus
+	}
+	return SightingStatus_SIGHTING_STATUS_UNSPECIFIED
+}
+
+func (x *ReviewNote) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *ReviewNote) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return nil
 }
 
-type UpdateRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	UpdateInfo    *SightingUpdateInfo    `protobuf:"bytes,2,opt,name=update_info,json=updateInfo,proto3" json:"update_info,omitempty"`
+// Attachment метаданные вложения наблюдения
+type Attachment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id идентификатор вложения
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FileName    string `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	SizeBytes   int64  `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// sha256 контрольная сумма содержимого в hex
+	Sha256        string                 `protobuf:"bytes,5,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	UploadedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateRequest) Reset() {
-	*x = UpdateRequest{}
+func (x *Attachment) Reset() {
+	*x = Attachment{}
 	mi := &file_ufo_v1_ufo_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateRequest) String() string {
+func (x *Attachment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateRequest) ProtoMessage() {}
+func (*Attachment) ProtoMessage() {}
 
-func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+func (x *Attachment) ProtoReflect() protoreflect.Message {
 	mi := &file_ufo_v1_ufo_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -483,47 +819,3444 @@ func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
-func (*UpdateRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
 	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *UpdateRequest) GetUuid() string {
+func (x *Attachment) GetId() string {
 	if x != nil {
-		return x.Uuid
+		return x.Id
 	}
 	return ""
 }
 
-func (x *UpdateRequest) GetUpdateInfo() *SightingUpdateInfo {
+func (x *Attachment) GetFileName() string {
 	if x != nil {
-		return x.UpdateInfo
+		return x.FileName
 	}
-	return nil
+	return ""
 }
 
-type DeleteRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *Attachment) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Attachment) GetUploadedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UploadedAt
+	}
+	return nil
+}
+
+type CreateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Info  *SightingInfo          `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	// idempotency_key ключ идемпотентности, сгенерированный клиентом (опционально).
+	// Повтор запроса с тем же ключом и теми же данными возвращает ранее созданное
+	// наблюдение, а с другими данными отклоняется с кодом FAILED_PRECONDITION.
+	// Ключи у каждого аутентифицированного клиента свои
+	IdempotencyKey string `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateRequest) Reset() {
+	*x = CreateRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRequest) ProtoMessage() {}
+
+func (x *CreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRequest.ProtoReflect.Descriptor instead.
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateRequest) GetInfo() *SightingInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *CreateRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type CreateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Uuid  string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// version версия созданной записи
+	Version int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// possible_duplicates UUID наблюдений, похожих на созданное, см. Sighting.possible_duplicates
+	PossibleDuplicates []string `protobuf:"bytes,3,rep,name=possible_duplicates,json=possibleDuplicates,proto3" json:"possible_duplicates,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CreateResponse) Reset() {
+	*x = CreateResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateResponse) ProtoMessage() {}
+
+func (x *CreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateResponse.ProtoReflect.Descriptor instead.
+func (*CreateResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateResponse) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *CreateResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *CreateResponse) GetPossibleDuplicates() []string {
+	if x != nil {
+		return x.PossibleDuplicates
+	}
+	return nil
+}
+
+type GetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Uuid  string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// include_deleted позволяет получить удаленное наблюдение
+	IncludeDeleted bool `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *GetRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type GetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sighting      *Sighting              `protobuf:"bytes,1,opt,name=sighting,proto3" json:"sighting,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResponse) Reset() {
+	*x = GetResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResponse) ProtoMessage() {}
+
+func (x *GetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetResponse) GetSighting() *Sighting {
+	if x != nil {
+		return x.Sighting
+	}
+	return nil
+}
+
+type UpdateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Uuid  string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// update_info новые значения полей. Без update_mask обязательно,
+	// и тогда меняются только заданные в нем поля
+	UpdateInfo *SightingUpdateInfo `protobuf:"bytes,2,opt,name=update_info,json=updateInfo,proto3" json:"update_info,omitempty"`
+	// update_mask пути полей SightingUpdateInfo, которые нужно изменить (опционально).
+	// Поле из маски, не заданное в update_info, сбрасывается; сбросить можно только
+	// необязательные поля color, sound, duration_seconds и coordinates. Путь "*" означает все поля
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// expected_version версия, которую видел клиент (опционально).
+	// Если запись уже изменилась, запрос отклоняется с кодом ABORTED
+	ExpectedVersion *wrapperspb.Int64Value `protobuf:"bytes,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateRequest) Reset() {
+	*x = UpdateRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRequest) ProtoMessage() {}
+
+func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UpdateRequest) GetUpdateInfo() *SightingUpdateInfo {
+	if x != nil {
+		return x.UpdateInfo
+	}
+	return nil
+}
+
+func (x *UpdateRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateRequest) GetExpectedVersion() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return nil
+}
+
+// UpdateResponse результат обновления наблюдения
+type UpdateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// version новая версия записи
+	Version       int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateResponse) Reset() {
+	*x = UpdateResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateResponse) ProtoMessage() {}
+
+func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateResponse.ProtoReflect.Descriptor instead.
+func (*UpdateResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Uuid  string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// expected_version версия, которую видел клиент (опционально), см. UpdateRequest
+	ExpectedVersion *wrapperspb.Int64Value `protobuf:"bytes,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetExpectedVersion() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return nil
+}
+
+// ListFilter условия отбора наблюдений, все заданные условия объединяются через И
+type ListFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// observed_from нижняя граница observed_at включительно (опционально)
+	ObservedFrom *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=observed_from,json=observedFrom,proto3" json:"observed_from,omitempty"`
+	// observed_to верхняя граница observed_at не включительно (опционально)
+	ObservedTo *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=observed_to,json=observedTo,proto3" json:"observed_to,omitempty"`
+	// location_contains подстрока места наблюдения без учета регистра (опционально)
+	LocationContains string `protobuf:"bytes,3,opt,name=location_contains,json=locationContains,proto3" json:"location_contains,omitempty"`
+	// color точное совпадение цвета без учета регистра (опционально)
+	Color *wrapperspb.StringValue `protobuf:"bytes,4,opt,name=color,proto3" json:"color,omitempty"`
+	// sound точное совпадение звука без учета регистра (опционально)
+	Sound *wrapperspb.StringValue `protobuf:"bytes,5,opt,name=sound,proto3" json:"sound,omitempty"`
+	// has_duration отбирает наблюдения с заданной (true) или не заданной (false) продолжительностью (опционально)
+	HasDuration *wrapperspb.BoolValue `protobuf:"bytes,6,opt,name=has_duration,json=hasDuration,proto3" json:"has_duration,omitempty"`
+	// include_deleted включает в выдачу удаленные наблюдения
+	IncludeDeleted bool `protobuf:"varint,7,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// statuses отбирает наблюдения с одним из статусов модерации (опционально)
+	Statuses      []SightingStatus `protobuf:"varint,8,rep,packed,name=statuses,proto3,enum=ufo.v1.SightingStatus" json:"statuses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFilter) Reset() {
+	*x = ListFilter{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilter) ProtoMessage() {}
+
+func (x *ListFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilter.ProtoReflect.Descriptor instead.
+func (*ListFilter) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListFilter) GetObservedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ObservedFrom
+	}
+	return nil
+}
+
+func (x *ListFilter) GetObservedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ObservedTo
+	}
+	return nil
+}
+
+func (x *ListFilter) GetLocationContains() string {
+	if x != nil {
+		return x.LocationContains
+	}
+	return ""
+}
+
+func (x *ListFilter) GetColor() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Color
+	}
+	return nil
+}
+
+func (x *ListFilter) GetSound() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Sound
+	}
+	return nil
+}
+
+func (x *ListFilter) GetHasDuration() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.HasDuration
+	}
+	return nil
+}
+
+func (x *ListFilter) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *ListFilter) GetStatuses() []SightingStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+// ListRequest запрос списка наблюдений
+type ListRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// page_size размер страницы, 0 - значение по умолчанию, больше 1000 - урезается до 1000
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token токен страницы из предыдущего ответа, пустой для первой страницы
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// filter условия отбора (опционально)
+	Filter        *ListFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListRequest) GetFilter() *ListFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// ListResponse страница наблюдений, упорядоченных по created_at и uuid
+type ListResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Sightings []*Sighting            `protobuf:"bytes,1,rep,name=sightings,proto3" json:"sightings,omitempty"`
+	// next_page_token токен следующей страницы, пустой если страниц больше нет
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListResponse) GetSightings() []*Sighting {
+	if x != nil {
+		return x.Sightings
+	}
+	return nil
+}
+
+func (x *ListResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// SearchRequest запрос полнотекстового поиска
+type SearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// query слова на русском или английском: форма слова и регистр не важны,
+	// подходит наблюдение, в котором есть хотя бы одно из слов
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// page_size размер страницы, 0 - значение по умолчанию, больше 1000 - урезается до 1000
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token токен страницы из предыдущего ответа на тот же query, пустой для первой страницы
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// SearchResult найденное наблюдение
+type SearchResult struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Sighting *Sighting              `protobuf:"bytes,1,opt,name=sighting,proto3" json:"sighting,omitempty"`
+	// score релевантность наблюдения запросу, больше - лучше
+	Score         float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SearchResult) GetSighting() *Sighting {
+	if x != nil {
+		return x.Sighting
+	}
+	return nil
+}
+
+func (x *SearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+// SearchResponse страница результатов поиска по убыванию релевантности.
+// Удаленные наблюдения не ищутся
+type SearchResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Results []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// next_page_token токен следующей страницы, пустой если страниц больше нет
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total_size сколько всего наблюдений нашлось
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SearchResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+// GeoCircle круг на поверхности Земли
+type GeoCircle struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Center *GeoPoint              `protobuf:"bytes,1,opt,name=center,proto3" json:"center,omitempty"`
+	// radius_km радиус в километрах, не больше половины окружности Земли
+	RadiusKm      float64 `protobuf:"fixed64,2,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoCircle) Reset() {
+	*x = GeoCircle{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoCircle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoCircle) ProtoMessage() {}
+
+func (x *GeoCircle) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoCircle.ProtoReflect.Descriptor instead.
+func (*GeoCircle) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GeoCircle) GetCenter() *GeoPoint {
+	if x != nil {
+		return x.Center
+	}
+	return nil
+}
+
+func (x *GeoCircle) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+// GeoBoundingBox прямоугольник широт и долгот. Если долгота south_west больше
+// долготы north_east, прямоугольник пересекает 180-й меридиан
+type GeoBoundingBox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SouthWest     *GeoPoint              `protobuf:"bytes,1,opt,name=south_west,json=southWest,proto3" json:"south_west,omitempty"`
+	NorthEast     *GeoPoint              `protobuf:"bytes,2,opt,name=north_east,json=northEast,proto3" json:"north_east,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoBoundingBox) Reset() {
+	*x = GeoBoundingBox{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoBoundingBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoBoundingBox) ProtoMessage() {}
+
+func (x *GeoBoundingBox) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoBoundingBox.ProtoReflect.Descriptor instead.
+func (*GeoBoundingBox) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GeoBoundingBox) GetSouthWest() *GeoPoint {
+	if x != nil {
+		return x.SouthWest
+	}
+	return nil
+}
+
+func (x *GeoBoundingBox) GetNorthEast() *GeoPoint {
+	if x != nil {
+		return x.NorthEast
+	}
+	return nil
+}
+
+// FindNearbyRequest запрос поиска наблюдений по координатам
+type FindNearbyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// area область поиска, обязательна
+	//
+	// Types that are valid to be assigned to Area:
+	//
+	//	*FindNearbyRequest_Circle
+	//	*FindNearbyRequest_Box
+	Area isFindNearbyRequest_Area `protobuf_oneof:"area"`
+	// page_size размер страницы, 0 - значение по умолчанию, больше 1000 - урезается до 1000
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token токен страницы из предыдущего ответа на ту же область, пустой для первой страницы
+	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindNearbyRequest) Reset() {
+	*x = FindNearbyRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindNearbyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindNearbyRequest) ProtoMessage() {}
+
+func (x *FindNearbyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindNearbyRequest.ProtoReflect.Descriptor instead.
+func (*FindNearbyRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *FindNearbyRequest) GetArea() isFindNearbyRequest_Area {
+	if x != nil {
+		return x.Area
+	}
+	return nil
+}
+
+func (x *FindNearbyRequest) GetCircle() *GeoCircle {
+	if x != nil {
+		if x, ok := x.Area.(*FindNearbyRequest_Circle); ok {
+			return x.Circle
+		}
+	}
+	return nil
+}
+
+func (x *FindNearbyRequest) GetBox() *GeoBoundingBox {
+	if x != nil {
+		if x, ok := x.Area.(*FindNearbyRequest_Box); ok {
+			return x.Box
+		}
+	}
+	return nil
+}
+
+func (x *FindNearbyRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *FindNearbyRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type isFindNearbyRequest_Area interface {
+	isFindNearbyRequest_Area()
+}
+
+type FindNearbyRequest_Circle struct {
+	Circle *GeoCircle `protobuf:"bytes,1,opt,name=circle,proto3,oneof"`
+}
+
+type FindNearbyRequest_Box struct {
+	Box *GeoBoundingBox `protobuf:"bytes,2,opt,name=box,proto3,oneof"`
+}
+
+func (*FindNearbyRequest_Circle) isFindNearbyRequest_Area() {}
+
+func (*FindNearbyRequest_Box) isFindNearbyRequest_Area() {}
+
+// NearbySighting найденное наблюдение
+type NearbySighting struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Sighting *Sighting              `protobuf:"bytes,1,opt,name=sighting,proto3" json:"sighting,omitempty"`
+	// distance_km расстояние до центра круга или прямоугольника
+	DistanceKm    float64 `protobuf:"fixed64,2,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearbySighting) Reset() {
+	*x = NearbySighting{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearbySighting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbySighting) ProtoMessage() {}
+
+func (x *NearbySighting) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbySighting.ProtoReflect.Descriptor instead.
+func (*NearbySighting) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *NearbySighting) GetSighting() *Sighting {
+	if x != nil {
+		return x.Sighting
+	}
+	return nil
+}
+
+func (x *NearbySighting) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+// FindNearbyResponse страница наблюдений по возрастанию расстояния.
+// Удаленные наблюдения и наблюдения без координат не ищутся
+type FindNearbyResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Sightings []*NearbySighting      `protobuf:"bytes,1,rep,name=sightings,proto3" json:"sightings,omitempty"`
+	// next_page_token токен следующей страницы, пустой если страниц больше нет
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total_size сколько всего наблюдений нашлось
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindNearbyResponse) Reset() {
+	*x = FindNearbyResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindNearbyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindNearbyResponse) ProtoMessage() {}
+
+func (x *FindNearbyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindNearbyResponse.ProtoReflect.Descriptor instead.
+func (*FindNearbyResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *FindNearbyResponse) GetSightings() []*NearbySighting {
+	if x != nil {
+		return x.Sightings
+	}
+	return nil
+}
+
+func (x *FindNearbyResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *FindNearbyResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+// SightingEvent событие изменения наблюдения
+type SightingEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// sequence порядковый номер события, строго возрастает в рамках запуска сервера
+	Sequence uint64            `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Type     SightingEventType `protobuf:"varint,2,opt,name=type,proto3,enum=ufo.v1.SightingEventType" json:"type,omitempty"`
+	// sighting состояние наблюдения сразу после изменения
+	Sighting *Sighting `protobuf:"bytes,3,opt,name=sighting,proto3" json:"sighting,omitempty"`
+	// occurred_at время изменения
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SightingEvent) Reset() {
+	*x = SightingEvent{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SightingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SightingEvent) ProtoMessage() {}
+
+func (x *SightingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SightingEvent.ProtoReflect.Descriptor instead.
+func (*SightingEvent) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SightingEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *SightingEvent) GetType() SightingEventType {
+	if x != nil {
+		return x.Type
+	}
+	return SightingEventType_SIGHTING_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *SightingEvent) GetSighting() *Sighting {
+	if x != nil {
+		return x.Sighting
+	}
+	return nil
+}
+
+func (x *SightingEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// WatchRequest запрос подписки на события
+type WatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// last_sequence номер последнего полученного клиентом события:
+	// сервер продолжит со следующего. 0 - только новые события
+	LastSequence  uint64 `protobuf:"varint,1,opt,name=last_sequence,json=lastSequence,proto3" json:"last_sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *WatchRequest) GetLastSequence() uint64 {
+	if x != nil {
+		return x.LastSequence
+	}
+	return 0
+}
+
+// WatchResponse очередное событие подписки
+type WatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *SightingEvent         `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchResponse) Reset() {
+	*x = WatchResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchResponse) ProtoMessage() {}
+
+func (x *WatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchResponse.ProtoReflect.Descriptor instead.
+func (*WatchResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *WatchResponse) GetEvent() *SightingEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+// ImportOptions параметры массовой загрузки
+type ImportOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// atomic создает наблюдения только если все элементы стрима прошли проверку
+	Atomic        bool `protobuf:"varint,1,opt,name=atomic,proto3" json:"atomic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportOptions) Reset() {
+	*x = ImportOptions{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportOptions) ProtoMessage() {}
+
+func (x *ImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportOptions.ProtoReflect.Descriptor instead.
+func (*ImportOptions) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ImportOptions) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+// ImportSightingsRequest элемент стрима загрузки: options допускаются только первым сообщением
+type ImportSightingsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ImportSightingsRequest_Options
+	//	*ImportSightingsRequest_Info
+	Payload       isImportSightingsRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportSightingsRequest) Reset() {
+	*x = ImportSightingsRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportSightingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportSightingsRequest) ProtoMessage() {}
+
+func (x *ImportSightingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportSightingsRequest.ProtoReflect.Descriptor instead.
+func (*ImportSightingsRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ImportSightingsRequest) GetPayload() isImportSightingsRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ImportSightingsRequest) GetOptions() *ImportOptions {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportSightingsRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportSightingsRequest) GetInfo() *SightingInfo {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportSightingsRequest_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+type isImportSightingsRequest_Payload interface {
+	isImportSightingsRequest_Payload()
+}
+
+type ImportSightingsRequest_Options struct {
+	Options *ImportOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportSightingsRequest_Info struct {
+	Info *SightingInfo `protobuf:"bytes,2,opt,name=info,proto3,oneof"`
+}
+
+func (*ImportSightingsRequest_Options) isImportSightingsRequest_Payload() {}
+
+func (*ImportSightingsRequest_Info) isImportSightingsRequest_Payload() {}
+
+// ImportedSighting созданное при загрузке наблюдение
+type ImportedSighting struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// index порядковый номер SightingInfo в стриме, начиная с 0
+	Index         int32  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Uuid          string `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportedSighting) Reset() {
+	*x = ImportedSighting{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportedSighting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportedSighting) ProtoMessage() {}
+
+func (x *ImportedSighting) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportedSighting.ProtoReflect.Descriptor instead.
+func (*ImportedSighting) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ImportedSighting) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ImportedSighting) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+// ImportItemError ошибка обработки элемента стрима
+type ImportItemError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// index порядковый номер SightingInfo в стриме, начиная с 0
+	Index         int32  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportItemError) Reset() {
+	*x = ImportItemError{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportItemError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportItemError) ProtoMessage() {}
+
+func (x *ImportItemError) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportItemError.ProtoReflect.Descriptor instead.
+func (*ImportItemError) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ImportItemError) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ImportItemError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ImportSightingsResponse итог загрузки
+type ImportSightingsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// received сколько SightingInfo получено
+	Received      int32               `protobuf:"varint,1,opt,name=received,proto3" json:"received,omitempty"`
+	Created       []*ImportedSighting `protobuf:"bytes,2,rep,name=created,proto3" json:"created,omitempty"`
+	Errors        []*ImportItemError  `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportSightingsResponse) Reset() {
+	*x = ImportSightingsResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportSightingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportSightingsResponse) ProtoMessage() {}
+
+func (x *ImportSightingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportSightingsResponse.ProtoReflect.Descriptor instead.
+func (*ImportSightingsResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ImportSightingsResponse) GetReceived() int32 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *ImportSightingsResponse) GetCreated() []*ImportedSighting {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *ImportSightingsResponse) GetErrors() []*ImportItemError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// UploadAttachmentHeader описание загружаемого файла
+type UploadAttachmentHeader struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SightingUuid string                 `protobuf:"bytes,1,opt,name=sighting_uuid,json=sightingUuid,proto3" json:"sighting_uuid,omitempty"`
+	FileName     string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// content_type MIME-тип файла, допустимые типы задает сервер
+	ContentType string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// size_bytes точный размер файла, лимит задает сервер
+	SizeBytes int64 `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// sha256 контрольная сумма содержимого в hex: сервер сверяет ее с полученными данными
+	Sha256        string `protobuf:"bytes,5,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentHeader) Reset() {
+	*x = UploadAttachmentHeader{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentHeader) ProtoMessage() {}
+
+func (x *UploadAttachmentHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentHeader.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentHeader) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UploadAttachmentHeader) GetSightingUuid() string {
+	if x != nil {
+		return x.SightingUuid
+	}
+	return ""
+}
+
+func (x *UploadAttachmentHeader) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *UploadAttachmentHeader) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UploadAttachmentHeader) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *UploadAttachmentHeader) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+// UploadAttachmentRequest элемент стрима загрузки: header допускается только первым сообщением
+type UploadAttachmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UploadAttachmentRequest_Header
+	//	*UploadAttachmentRequest_Chunk
+	Payload       isUploadAttachmentRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentRequest) ProtoMessage() {}
+
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UploadAttachmentRequest) GetPayload() isUploadAttachmentRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetHeader() *UploadAttachmentHeader {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadAttachmentRequest_Header); ok {
+			return x.Header
+		}
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadAttachmentRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadAttachmentRequest_Payload interface {
+	isUploadAttachmentRequest_Payload()
+}
+
+type UploadAttachmentRequest_Header struct {
+	Header *UploadAttachmentHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type UploadAttachmentRequest_Chunk struct {
+	// chunk очередная часть содержимого, не больше 1 МиБ
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadAttachmentRequest_Header) isUploadAttachmentRequest_Payload() {}
+
+func (*UploadAttachmentRequest_Chunk) isUploadAttachmentRequest_Payload() {}
+
+// UploadAttachmentResponse результат загрузки
+type UploadAttachmentResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Attachment *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	// version новая версия наблюдения
+	Version       int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentResponse) Reset() {
+	*x = UploadAttachmentResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentResponse) ProtoMessage() {}
+
+func (x *UploadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UploadAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+func (x *UploadAttachmentResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// DownloadAttachmentRequest запрос содержимого вложения
+type DownloadAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SightingUuid  string                 `protobuf:"bytes,1,opt,name=sighting_uuid,json=sightingUuid,proto3" json:"sighting_uuid,omitempty"`
+	AttachmentId  string                 `protobuf:"bytes,2,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentRequest) Reset() {
+	*x = DownloadAttachmentRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentRequest) ProtoMessage() {}
+
+func (x *DownloadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DownloadAttachmentRequest) GetSightingUuid() string {
+	if x != nil {
+		return x.SightingUuid
+	}
+	return ""
+}
+
+func (x *DownloadAttachmentRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+// DownloadAttachmentResponse элемент стрима: первым приходят метаданные, затем части содержимого
+type DownloadAttachmentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*DownloadAttachmentResponse_Attachment
+	//	*DownloadAttachmentResponse_Chunk
+	Payload       isDownloadAttachmentResponse_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentResponse) Reset() {
+	*x = DownloadAttachmentResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentResponse) ProtoMessage() {}
+
+func (x *DownloadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DownloadAttachmentResponse) GetPayload() isDownloadAttachmentResponse_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		if x, ok := x.Payload.(*DownloadAttachmentResponse_Attachment); ok {
+			return x.Attachment
+		}
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*DownloadAttachmentResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isDownloadAttachmentResponse_Payload interface {
+	isDownloadAttachmentResponse_Payload()
+}
+
+type DownloadAttachmentResponse_Attachment struct {
+	Attachment *Attachment `protobuf:"bytes,1,opt,name=attachment,proto3,oneof"`
+}
+
+type DownloadAttachmentResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*DownloadAttachmentResponse_Attachment) isDownloadAttachmentResponse_Payload() {}
+
+func (*DownloadAttachmentResponse_Chunk) isDownloadAttachmentResponse_Payload() {}
+
+// RestoreRequest запрос восстановления удаленного наблюдения
+type RestoreRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Uuid  string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// expected_version версия, которую видел клиент (опционально), см. UpdateRequest
+	ExpectedVersion *wrapperspb.Int64Value `protobuf:"bytes,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RestoreRequest) Reset() {
+	*x = RestoreRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreRequest) ProtoMessage() {}
+
+func (x *RestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreRequest.ProtoReflect.Descriptor instead.
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RestoreRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *RestoreRequest) GetExpectedVersion() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return nil
+}
+
+// PurgeRequest запрос безвозвратного удаления наблюдения
+type PurgeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeRequest) Reset() {
+	*x = PurgeRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeRequest) ProtoMessage() {}
+
+func (x *PurgeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeRequest.ProtoReflect.Descriptor instead.
+func (*PurgeRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PurgeRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+// FieldChange изменение одного поля наблюдения
+type FieldChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// field путь поля: имя поля info, как в update_mask, либо поля наблюдения вне info,
+	// например deleted_at или attachments
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// old_value и new_value значения в JSON-представлении, null если поле не задано
+	OldValue      *structpb.Value `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue      *structpb.Value `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetOldValue() *structpb.Value {
+	if x != nil {
+		return x.OldValue
+	}
+	return nil
+}
+
+func (x *FieldChange) GetNewValue() *structpb.Value {
+	if x != nil {
+		return x.NewValue
+	}
+	return nil
+}
+
+// Revision неизменяемая запись истории: одно изменение наблюдения
+type Revision struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SightingUuid string                 `protobuf:"bytes,1,opt,name=sighting_uuid,json=sightingUuid,proto3" json:"sighting_uuid,omitempty"`
+	// version версия наблюдения, которую создало изменение
+	Version int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// type вид изменения
+	Type SightingEventType `protobuf:"varint,3,opt,name=type,proto3,enum=ufo.v1.SightingEventType" json:"type,omitempty"`
+	// actor кто внес изменение: subject вызывающего или anonymous без аутентификации
+	Actor string `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	// created_at время изменения
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// changes измененные поля, при создании - все заданные поля
+	Changes []*FieldChange `protobuf:"bytes,6,rep,name=changes,proto3" json:"changes,omitempty"`
+	// info информация о наблюдении после изменения, к ней возвращает RevertSighting
+	Info *SightingInfo `protobuf:"bytes,7,opt,name=info,proto3" json:"info,omitempty"`
+	// reverted_from_version версия, к которой вернули наблюдение, 0 если это не возврат
+	RevertedFromVersion int64 `protobuf:"varint,8,opt,name=reverted_from_version,json=revertedFromVersion,proto3" json:"reverted_from_version,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Revision) Reset() {
+	*x = Revision{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Revision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Revision) ProtoMessage() {}
+
+func (x *Revision) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Revision.ProtoReflect.Descriptor instead.
+func (*Revision) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *Revision) GetSightingUuid() string {
+	if x != nil {
+		return x.SightingUuid
+	}
+	return ""
+}
+
+func (x *Revision) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Revision) GetType() SightingEventType {
+	if x != nil {
+		return x.Type
+	}
+	return SightingEventType_SIGHTING_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *Revision) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *Revision) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Revision) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *Revision) GetInfo() *SightingInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *Revision) GetRevertedFromVersion() int64 {
+	if x != nil {
+		return x.RevertedFromVersion
+	}
+	return 0
+}
+
+// GetHistoryRequest запрос истории наблюдения
+type GetHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Uuid  string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// page_size размер страницы: 0 - значение по умолчанию, больше максимума - обрезается до максимума
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryRequest) Reset() {
+	*x = GetHistoryRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryRequest) ProtoMessage() {}
+
+func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetHistoryRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *GetHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// GetHistoryResponse страница истории наблюдения
+type GetHistoryResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Revisions []*Revision            `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	// next_page_token токен следующей страницы, пустой если страниц больше нет
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryResponse) Reset() {
+	*x = GetHistoryResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryResponse) ProtoMessage() {}
+
+func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetHistoryResponse) GetRevisions() []*Revision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+func (x *GetHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// GetRevisionRequest запрос ревизии наблюдения
+type GetRevisionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRevisionRequest) Reset() {
+	*x = GetRevisionRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRevisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRevisionRequest) ProtoMessage() {}
+
+func (x *GetRevisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRevisionRequest.ProtoReflect.Descriptor instead.
+func (*GetRevisionRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetRevisionRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *GetRevisionRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// RevertSightingRequest запрос возврата наблюдения к ревизии
+type RevertSightingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Uuid  string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// version версия, к информации которой надо вернуться
+	Version int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// expected_version версия, которую видел клиент (опционально), см. UpdateRequest
+	ExpectedVersion *wrapperspb.Int64Value `protobuf:"bytes,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RevertSightingRequest) Reset() {
+	*x = RevertSightingRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertSightingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertSightingRequest) ProtoMessage() {}
+
+func (x *RevertSightingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertSightingRequest.ProtoReflect.Descriptor instead.
+func (*RevertSightingRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *RevertSightingRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *RevertSightingRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RevertSightingRequest) GetExpectedVersion() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return nil
+}
+
+// RevertSightingResponse результат возврата
+type RevertSightingResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// version новая версия наблюдения
+	Version       int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertSightingResponse) Reset() {
+	*x = RevertSightingResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertSightingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertSightingResponse) ProtoMessage() {}
+
+func (x *RevertSightingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertSightingResponse.ProtoReflect.Descriptor instead.
+func (*RevertSightingResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RevertSightingResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// GetStatsRequest запрос статистики
+type GetStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// filter какие наблюдения учитывать (опционально), по умолчанию все неудаленные
+	Filter *ListFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// group_limit сколько самых частых групп отдавать по цвету, звуку и месту, 0 - все
+	GroupLimit    int32 `protobuf:"varint,2,opt,name=group_limit,json=groupLimit,proto3" json:"group_limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetStatsRequest) GetFilter() *ListFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *GetStatsRequest) GetGroupLimit() int32 {
+	if x != nil {
+		return x.GroupLimit
+	}
+	return 0
+}
+
+// PeriodCount число наблюдений за календарный период по UTC
+type PeriodCount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// start начало периода: полночь дня, понедельник недели (ISO 8601) или первое число месяца
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeriodCount) Reset() {
+	*x = PeriodCount{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeriodCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeriodCount) ProtoMessage() {}
+
+func (x *PeriodCount) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeriodCount.ProtoReflect.Descriptor instead.
+func (*PeriodCount) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *PeriodCount) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *PeriodCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// GroupCount число наблюдений с одним значением поля
+type GroupCount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// value значение в нижнем регистре без крайних пробелов; пустое - поле не задано
+	Value         string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count         int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupCount) Reset() {
+	*x = GroupCount{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupCount) ProtoMessage() {}
+
+func (x *GroupCount) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupCount.ProtoReflect.Descriptor instead.
+func (*GroupCount) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GroupCount) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *GroupCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// DurationStats распределение продолжительности наблюдений, у которых она задана
+type DurationStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// count сколько наблюдений с заданной продолжительностью
+	Count       int32   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	MinSeconds  int32   `protobuf:"varint,2,opt,name=min_seconds,json=minSeconds,proto3" json:"min_seconds,omitempty"`
+	MaxSeconds  int32   `protobuf:"varint,3,opt,name=max_seconds,json=maxSeconds,proto3" json:"max_seconds,omitempty"`
+	MeanSeconds float64 `protobuf:"fixed64,4,opt,name=mean_seconds,json=meanSeconds,proto3" json:"mean_seconds,omitempty"`
+	// p50_seconds и другие перцентили считаются методом ближайшего ранга
+	P50Seconds    int32 `protobuf:"varint,5,opt,name=p50_seconds,json=p50Seconds,proto3" json:"p50_seconds,omitempty"`
+	P90Seconds    int32 `protobuf:"varint,6,opt,name=p90_seconds,json=p90Seconds,proto3" json:"p90_seconds,omitempty"`
+	P95Seconds    int32 `protobuf:"varint,7,opt,name=p95_seconds,json=p95Seconds,proto3" json:"p95_seconds,omitempty"`
+	P99Seconds    int32 `protobuf:"varint,8,opt,name=p99_seconds,json=p99Seconds,proto3" json:"p99_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DurationStats) Reset() {
+	*x = DurationStats{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DurationStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DurationStats) ProtoMessage() {}
+
+func (x *DurationStats) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DurationStats.ProtoReflect.Descriptor instead.
+func (*DurationStats) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DurationStats) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *DurationStats) GetMinSeconds() int32 {
+	if x != nil {
+		return x.MinSeconds
+	}
+	return 0
+}
+
+func (x *DurationStats) GetMaxSeconds() int32 {
+	if x != nil {
+		return x.MaxSeconds
+	}
+	return 0
+}
+
+func (x *DurationStats) GetMeanSeconds() float64 {
+	if x != nil {
+		return x.MeanSeconds
+	}
+	return 0
+}
+
+func (x *DurationStats) GetP50Seconds() int32 {
+	if x != nil {
+		return x.P50Seconds
+	}
+	return 0
+}
+
+func (x *DurationStats) GetP90Seconds() int32 {
+	if x != nil {
+		return x.P90Seconds
+	}
+	return 0
+}
+
+func (x *DurationStats) GetP95Seconds() int32 {
+	if x != nil {
+		return x.P95Seconds
+	}
+	return 0
+}
+
+func (x *DurationStats) GetP99Seconds() int32 {
+	if x != nil {
+		return x.P99Seconds
+	}
+	return 0
+}
+
+// GetStatsResponse статистика по наблюдениям
+type GetStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// total сколько наблюдений подошло под фильтр
+	Total int32 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	// by_day, by_week и by_month число наблюдений по observed_at, по возрастанию start,
+	// периоды без наблюдений пропускаются
+	ByDay   []*PeriodCount `protobuf:"bytes,2,rep,name=by_day,json=byDay,proto3" json:"by_day,omitempty"`
+	ByWeek  []*PeriodCount `protobuf:"bytes,3,rep,name=by_week,json=byWeek,proto3" json:"by_week,omitempty"`
+	ByMonth []*PeriodCount `protobuf:"bytes,4,rep,name=by_month,json=byMonth,proto3" json:"by_month,omitempty"`
+	// by_color, by_sound и by_location группы по убыванию числа наблюдений
+	ByColor    []*GroupCount `protobuf:"bytes,5,rep,name=by_color,json=byColor,proto3" json:"by_color,omitempty"`
+	BySound    []*GroupCount `protobuf:"bytes,6,rep,name=by_sound,json=bySound,proto3" json:"by_sound,omitempty"`
+	ByLocation []*GroupCount `protobuf:"bytes,7,rep,name=by_location,json=byLocation,proto3" json:"by_location,omitempty"`
+	// duration отсутствует, если ни у одного наблюдения продолжительность не задана
+	Duration      *DurationStats `protobuf:"bytes,8,opt,name=duration,proto3" json:"duration,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetStatsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetByDay() []*PeriodCount {
+	if x != nil {
+		return x.ByDay
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetByWeek() []*PeriodCount {
+	if x != nil {
+		return x.ByWeek
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetByMonth() []*PeriodCount {
+	if x != nil {
+		return x.ByMonth
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetByColor() []*GroupCount {
+	if x != nil {
+		return x.ByColor
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetBySound() []*GroupCount {
+	if x != nil {
+		return x.BySound
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetByLocation() []*GroupCount {
+	if x != nil {
+		return x.ByLocation
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetDuration() *DurationStats {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+// FindDuplicatesRequest запрос похожих наблюдений
+type FindDuplicatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Uuid  string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// min_score порог сходства от 0 до 1, 0 - порог сервера
+	MinScore      float64 `protobuf:"fixed64,2,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindDuplicatesRequest) Reset() {
+	*x = FindDuplicatesRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindDuplicatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDuplicatesRequest) ProtoMessage() {}
+
+func (x *FindDuplicatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDuplicatesRequest.ProtoReflect.Descriptor instead.
+func (*FindDuplicatesRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *FindDuplicatesRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *FindDuplicatesRequest) GetMinScore() float64 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
+}
+
+// DuplicateMatch похожее наблюдение
+type DuplicateMatch struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Sighting *Sighting              `protobuf:"bytes,1,opt,name=sighting,proto3" json:"sighting,omitempty"`
+	// score сходство от 0 до 1 по времени, месту и описанию
+	Score         float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicateMatch) Reset() {
+	*x = DuplicateMatch{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateMatch) ProtoMessage() {}
+
+func (x *DuplicateMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateMatch.ProtoReflect.Descriptor instead.
+func (*DuplicateMatch) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DuplicateMatch) GetSighting() *Sighting {
+	if x != nil {
+		return x.Sighting
+	}
+	return nil
+}
+
+func (x *DuplicateMatch) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+// FindDuplicatesResponse похожие наблюдения по убыванию сходства
+type FindDuplicatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*DuplicateMatch      `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindDuplicatesResponse) Reset() {
+	*x = FindDuplicatesResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindDuplicatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDuplicatesResponse) ProtoMessage() {}
+
+func (x *FindDuplicatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDuplicatesResponse.ProtoReflect.Descriptor instead.
+func (*FindDuplicatesResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *FindDuplicatesResponse) GetMatches() []*DuplicateMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+// MergeSightingsRequest запрос объединения наблюдений
+type MergeSightingsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// canonical_uuid основное наблюдение, которое останется после объединения
+	CanonicalUuid string `protobuf:"bytes,1,opt,name=canonical_uuid,json=canonicalUuid,proto3" json:"canonical_uuid,omitempty"`
+	// duplicate_uuids наблюдения, которые объединяются в основное и удаляются
+	DuplicateUuids []string `protobuf:"bytes,2,rep,name=duplicate_uuids,json=duplicateUuids,proto3" json:"duplicate_uuids,omitempty"`
+	// expected_version версия основного наблюдения, которую видел клиент (опционально), см. UpdateRequest
+	ExpectedVersion *wrapperspb.Int64Value `protobuf:"bytes,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MergeSightingsRequest) Reset() {
+	*x = MergeSightingsRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeSightingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeSightingsRequest) ProtoMessage() {}
+
+func (x *MergeSightingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeSightingsRequest.ProtoReflect.Descriptor instead.
+func (*MergeSightingsRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *MergeSightingsRequest) GetCanonicalUuid() string {
+	if x != nil {
+		return x.CanonicalUuid
+	}
+	return ""
+}
+
+func (x *MergeSightingsRequest) GetDuplicateUuids() []string {
+	if x != nil {
+		return x.DuplicateUuids
+	}
+	return nil
+}
+
+func (x *MergeSightingsRequest) GetExpectedVersion() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return nil
+}
+
+// MergeSightingsResponse результат объединения
+type MergeSightingsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// sighting основное наблюдение после объединения
+	Sighting      *Sighting `protobuf:"bytes,1,opt,name=sighting,proto3" json:"sighting,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeSightingsResponse) Reset() {
+	*x = MergeSightingsResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeSightingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeSightingsResponse) ProtoMessage() {}
+
+func (x *MergeSightingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeSightingsResponse.ProtoReflect.Descriptor instead.
+func (*MergeSightingsResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *MergeSightingsResponse) GetSighting() *Sighting {
+	if x != nil {
+		return x.Sighting
+	}
+	return nil
+}
+
+// TransitionStatusRequest запрос смены статуса модерации. Допустимые переходы:
+// SUBMITTED -> UNDER_REVIEW, REJECTED;
+// UNDER_REVIEW -> SUBMITTED, VERIFIED, REJECTED, HOAX;
+// VERIFIED, REJECTED, HOAX -> UNDER_REVIEW (повторная проверка), VERIFIED -> HOAX
+type TransitionStatusRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Uuid   string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Status SightingStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=ufo.v1.SightingStatus" json:"status,omitempty"`
+	// note пояснение рецензента, обязательно для REJECTED и HOAX
+	Note string `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	// expected_version версия, которую видел клиент (опционально), см. UpdateRequest
+	ExpectedVersion *wrapperspb.Int64Value `protobuf:"bytes,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TransitionStatusRequest) Reset() {
+	*x = TransitionStatusRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransitionStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransitionStatusRequest) ProtoMessage() {}
+
+func (x *TransitionStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransitionStatusRequest.ProtoReflect.Descriptor instead.
+func (*TransitionStatusRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *TransitionStatusRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *TransitionStatusRequest) GetStatus() SightingStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SightingStatus_SIGHTING_STATUS_UNSPECIFIED
+}
+
+func (x *TransitionStatusRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *TransitionStatusRequest) GetExpectedVersion() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return nil
+}
+
+// TransitionStatusResponse результат смены статуса
+type TransitionStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sighting      *Sighting              `protobuf:"bytes,1,opt,name=sighting,proto3" json:"sighting,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransitionStatusResponse) Reset() {
+	*x = TransitionStatusResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransitionStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransitionStatusResponse) ProtoMessage() {}
+
+func (x *TransitionStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransitionStatusResponse.ProtoReflect.Descriptor instead.
+func (*TransitionStatusResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *TransitionStatusResponse) GetSighting() *Sighting {
+	if x != nil {
+		return x.Sighting
+	}
+	return nil
+}
+
+// ListByStatusRequest запрос наблюдений по статусу модерации
+type ListByStatusRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Status SightingStatus         `protobuf:"varint,1,opt,name=status,proto3,enum=ufo.v1.SightingStatus" json:"status,omitempty"`
+	// page_size размер страницы, 0 - значение по умолчанию, больше 1000 - урезается до 1000
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token токен страницы из предыдущего ответа, пустой для первой страницы
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListByStatusRequest) Reset() {
+	*x = ListByStatusRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListByStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListByStatusRequest) ProtoMessage() {}
+
+func (x *ListByStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListByStatusRequest.ProtoReflect.Descriptor instead.
+func (*ListByStatusRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListByStatusRequest) GetStatus() SightingStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SightingStatus_SIGHTING_STATUS_UNSPECIFIED
+}
+
+func (x *ListByStatusRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListByStatusRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListByStatusResponse страница наблюдений с данным статусом
+type ListByStatusResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Sightings []*Sighting            `protobuf:"bytes,1,rep,name=sightings,proto3" json:"sightings,omitempty"`
+	// next_page_token токен следующей страницы, пустой если страниц больше нет
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListByStatusResponse) Reset() {
+	*x = ListByStatusResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListByStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListByStatusResponse) ProtoMessage() {}
+
+func (x *ListByStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListByStatusResponse.ProtoReflect.Descriptor instead.
+func (*ListByStatusResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListByStatusResponse) GetSightings() []*Sighting {
+	if x != nil {
+		return x.Sightings
+	}
+	return nil
+}
+
+func (x *ListByStatusResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
 }
 
-func (x *DeleteRequest) Reset() {
-	*x = DeleteRequest{}
-	mi := &file_ufo_v1_ufo_proto_msgTypes[8]
+// AddWitnessRequest запрос добавления очевидца
+type AddWitnessRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SightingUuid string                 `protobuf:"bytes,1,opt,name=sighting_uuid,json=sightingUuid,proto3" json:"sighting_uuid,omitempty"`
+	Witness      *WitnessInfo           `protobuf:"bytes,2,opt,name=witness,proto3" json:"witness,omitempty"`
+	// expected_version версия, которую видел клиент (опционально), см. UpdateRequest
+	ExpectedVersion *wrapperspb.Int64Value `protobuf:"bytes,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AddWitnessRequest) Reset() {
+	*x = AddWitnessRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteRequest) String() string {
+func (x *AddWitnessRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteRequest) ProtoMessage() {}
+func (*AddWitnessRequest) ProtoMessage() {}
 
-func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ufo_v1_ufo_proto_msgTypes[8]
+func (x *AddWitnessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -534,14 +4267,347 @@ func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
-func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use AddWitnessRequest.ProtoReflect.Descriptor instead.
+func (*AddWitnessRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *DeleteRequest) GetUuid() string {
+func (x *AddWitnessRequest) GetSightingUuid() string {
 	if x != nil {
-		return x.Uuid
+		return x.SightingUuid
+	}
+	return ""
+}
+
+func (x *AddWitnessRequest) GetWitness() *WitnessInfo {
+	if x != nil {
+		return x.Witness
+	}
+	return nil
+}
+
+func (x *AddWitnessRequest) GetExpectedVersion() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return nil
+}
+
+// AddWitnessResponse результат добавления очевидца
+type AddWitnessResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Witness *Witness               `protobuf:"bytes,1,opt,name=witness,proto3" json:"witness,omitempty"`
+	// version новая версия наблюдения
+	Version       int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddWitnessResponse) Reset() {
+	*x = AddWitnessResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddWitnessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWitnessResponse) ProtoMessage() {}
+
+func (x *AddWitnessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWitnessResponse.ProtoReflect.Descriptor instead.
+func (*AddWitnessResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *AddWitnessResponse) GetWitness() *Witness {
+	if x != nil {
+		return x.Witness
+	}
+	return nil
+}
+
+func (x *AddWitnessResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// RemoveWitnessRequest запрос удаления очевидца
+type RemoveWitnessRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SightingUuid string                 `protobuf:"bytes,1,opt,name=sighting_uuid,json=sightingUuid,proto3" json:"sighting_uuid,omitempty"`
+	WitnessId    string                 `protobuf:"bytes,2,opt,name=witness_id,json=witnessId,proto3" json:"witness_id,omitempty"`
+	// expected_version версия, которую видел клиент (опционально), см. UpdateRequest
+	ExpectedVersion *wrapperspb.Int64Value `protobuf:"bytes,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RemoveWitnessRequest) Reset() {
+	*x = RemoveWitnessRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveWitnessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveWitnessRequest) ProtoMessage() {}
+
+func (x *RemoveWitnessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveWitnessRequest.ProtoReflect.Descriptor instead.
+func (*RemoveWitnessRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *RemoveWitnessRequest) GetSightingUuid() string {
+	if x != nil {
+		return x.SightingUuid
+	}
+	return ""
+}
+
+func (x *RemoveWitnessRequest) GetWitnessId() string {
+	if x != nil {
+		return x.WitnessId
+	}
+	return ""
+}
+
+func (x *RemoveWitnessRequest) GetExpectedVersion() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return nil
+}
+
+// RemoveWitnessResponse результат удаления очевидца
+type RemoveWitnessResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// version новая версия наблюдения
+	Version       int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveWitnessResponse) Reset() {
+	*x = RemoveWitnessResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveWitnessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveWitnessResponse) ProtoMessage() {}
+
+func (x *RemoveWitnessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveWitnessResponse.ProtoReflect.Descriptor instead.
+func (*RemoveWitnessResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RemoveWitnessResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// FindSightingsByWitnessRequest запрос наблюдений очевидца. Очевидец ищется по имени
+// или контакту без учета регистра; поиск по контакту и по анонимным очевидцам
+// доступен только ролям с доступом к контактам
+type FindSightingsByWitnessRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Witness:
+	//
+	//	*FindSightingsByWitnessRequest_Name
+	//	*FindSightingsByWitnessRequest_Contact
+	Witness isFindSightingsByWitnessRequest_Witness `protobuf_oneof:"witness"`
+	// page_size размер страницы, 0 - значение по умолчанию, больше 1000 - урезается до 1000
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token токен страницы из предыдущего ответа, пустой для первой страницы
+	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSightingsByWitnessRequest) Reset() {
+	*x = FindSightingsByWitnessRequest{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSightingsByWitnessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSightingsByWitnessRequest) ProtoMessage() {}
+
+func (x *FindSightingsByWitnessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSightingsByWitnessRequest.ProtoReflect.Descriptor instead.
+func (*FindSightingsByWitnessRequest) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *FindSightingsByWitnessRequest) GetWitness() isFindSightingsByWitnessRequest_Witness {
+	if x != nil {
+		return x.Witness
+	}
+	return nil
+}
+
+func (x *FindSightingsByWitnessRequest) GetName() string {
+	if x != nil {
+		if x, ok := x.Witness.(*FindSightingsByWitnessRequest_Name); ok {
+			return x.Name
+		}
+	}
+	return ""
+}
+
+func (x *FindSightingsByWitnessRequest) GetContact() string {
+	if x != nil {
+		if x, ok := x.Witness.(*FindSightingsByWitnessRequest_Contact); ok {
+			return x.Contact
+		}
+	}
+	return ""
+}
+
+func (x *FindSightingsByWitnessRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *FindSightingsByWitnessRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type isFindSightingsByWitnessRequest_Witness interface {
+	isFindSightingsByWitnessRequest_Witness()
+}
+
+type FindSightingsByWitnessRequest_Name struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3,oneof"`
+}
+
+type FindSightingsByWitnessRequest_Contact struct {
+	Contact string `protobuf:"bytes,2,opt,name=contact,proto3,oneof"`
+}
+
+func (*FindSightingsByWitnessRequest_Name) isFindSightingsByWitnessRequest_Witness() {}
+
+func (*FindSightingsByWitnessRequest_Contact) isFindSightingsByWitnessRequest_Witness() {}
+
+// FindSightingsByWitnessResponse страница наблюдений очевидца
+type FindSightingsByWitnessResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Sightings []*Sighting            `protobuf:"bytes,1,rep,name=sightings,proto3" json:"sightings,omitempty"`
+	// next_page_token токен следующей страницы, пустой если страниц больше нет
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSightingsByWitnessResponse) Reset() {
+	*x = FindSightingsByWitnessResponse{}
+	mi := &file_ufo_v1_ufo_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSightingsByWitnessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSightingsByWitnessResponse) ProtoMessage() {}
+
+func (x *FindSightingsByWitnessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ufo_v1_ufo_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSightingsByWitnessResponse.ProtoReflect.Descriptor instead.
+func (*FindSightingsByWitnessResponse) Descriptor() ([]byte, []int) {
+	return file_ufo_v1_ufo_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *FindSightingsByWitnessResponse) GetSightings() []*Sighting {
+	if x != nil {
+		return x.Sightings
+	}
+	return nil
+}
+
+func (x *FindSightingsByWitnessResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
 	}
 	return ""
 }
@@ -550,23 +4616,33 @@ var File_ufo_v1_ufo_proto protoreflect.FileDescriptor
 
 const file_ufo_v1_ufo_proto_rawDesc = "" +
 	"\n" +
-	"\x10ufo/v1/ufo.proto\x12\x06ufo.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xb9\x02\n" +
-	"\fSightingInfo\x12;\n" +
-	"\vobserved_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"observedAt\x12\x1a\n" +
-	"\blocation\x18\x02 \x01(\tR\blocation\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x122\n" +
-	"\x05color\x18\x04 \x01(\v2\x1c.google.protobuf.StringValueR\x05color\x122\n" +
-	"\x05sound\x18\x05 \x01(\v2\x1c.google.protobuf.StringValueR\x05sound\x12F\n" +
-	"\x10duration_seconds\x18\x06 \x01(\v2\x1b.google.protobuf.Int32ValueR\x0fdurationSeconds\"\xfb\x02\n" +
-	"\x12SightingUpdateInfo\x12;\n" +
-	"\vobserved_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"observedAt\x128\n" +
-	"\blocation\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueR\blocation\x12>\n" +
-	"\vdescription\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueR\vdescription\x122\n" +
-	"\x05color\x18\x04 \x01(\v2\x1c.google.protobuf.StringValueR\x05color\x122\n" +
-	"\x05sound\x18\x05 \x01(\v2\x1c.google.protobuf.StringValueR\x05sound\x12F\n" +
-	"\x10duration_seconds\x18\x06 \x01(\v2\x1b.google.protobuf.Int32ValueR\x0fdurationSeconds\"\xf9\x01\n" +
+	"\x10ufo/v1/ufo.proto\x12\x06ufo.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x17validate/validate.proto\"\xac\x03\n" +
+	"\fSightingInfo\x12G\n" +
+	"\vobserved_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampB\n" +
+	"\xfaB\a\xb2\x01\x04\b\x018\x01R\n" +
+	"observedAt\x12&\n" +
+	"\blocation\x18\x02 \x01(\tB\n" +
+	"\xfaB\ar\x05\x10\x01\x18\x80\x02R\blocation\x12,\n" +
+	"\vdescription\x18\x03 \x01(\tB\n" +
+	"\xfaB\ar\x05\x10\x01\x18\x80 R\vdescription\x12;\n" +
+	"\x05color\x18\x04 \x01(\v2\x1c.google.protobuf.StringValueB\a\xfaB\x04r\x02\x18@R\x05color\x12;\n" +
+	"\x05sound\x18\x05 \x01(\v2\x1c.google.protobuf.StringValueB\a\xfaB\x04r\x02\x18@R\x05sound\x12O\n" +
+	"\x10duration_seconds\x18\x06 \x01(\v2\x1b.google.protobuf.Int32ValueB\a\xfaB\x04\x1a\x02 \x00R\x0fdurationSeconds\x122\n" +
+	"\vcoordinates\x18\a \x01(\v2\x10.ufo.v1.GeoPointR\vcoordinates\"\xec\x03\n" +
+	"\x12SightingUpdateInfo\x12E\n" +
+	"\vobserved_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampB\b\xfaB\x05\xb2\x01\x028\x01R\n" +
+	"observedAt\x12D\n" +
+	"\blocation\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueB\n" +
+	"\xfaB\ar\x05\x10\x01\x18\x80\x02R\blocation\x12J\n" +
+	"\vdescription\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueB\n" +
+	"\xfaB\ar\x05\x10\x01\x18\x80 R\vdescription\x12;\n" +
+	"\x05color\x18\x04 \x01(\v2\x1c.google.protobuf.StringValueB\a\xfaB\x04r\x02\x18@R\x05color\x12;\n" +
+	"\x05sound\x18\x05 \x01(\v2\x1c.google.protobuf.StringValueB\a\xfaB\x04r\x02\x18@R\x05sound\x12O\n" +
+	"\x10duration_seconds\x18\x06 \x01(\v2\x1b.google.protobuf.Int32ValueB\a\xfaB\x04\x1a\x02 \x00R\x0fdurationSeconds\x122\n" +
+	"\vcoordinates\x18\a \x01(\v2\x10.ufo.v1.GeoPointR\vcoordinates\"v\n" +
+	"\bGeoPoint\x123\n" +
+	"\blatitude\x18\x01 \x01(\x01B\x17\xfaB\x14\x12\x12\x19\x00\x00\x00\x00\x00\x80V@)\x00\x00\x00\x00\x00\x80V\xc0R\blatitude\x125\n" +
+	"\tlongitude\x18\x02 \x01(\x01B\x17\xfaB\x14\x12\x12\x19\x00\x00\x00\x00\x00\x80f@)\x00\x00\x00\x00\x00\x80f\xc0R\tlongitude\"\xd2\x04\n" +
 	"\bSighting\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12(\n" +
 	"\x04info\x18\x02 \x01(\v2\x14.ufo.v1.SightingInfoR\x04info\x129\n" +
@@ -575,28 +4651,357 @@ const file_ufo_v1_ufo_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
 	"\n" +
-	"deleted_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"9\n" +
-	"\rCreateRequest\x12(\n" +
-	"\x04info\x18\x01 \x01(\v2\x14.ufo.v1.SightingInfoR\x04info\"$\n" +
+	"deleted_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x03R\aversion\x124\n" +
+	"\vattachments\x18\a \x03(\v2\x12.ufo.v1.AttachmentR\vattachments\x12/\n" +
+	"\x13possible_duplicates\x18\b \x03(\tR\x12possibleDuplicates\x12\x1f\n" +
+	"\vmerged_into\x18\t \x01(\tR\n" +
+	"mergedInto\x12\x1f\n" +
+	"\vmerged_from\x18\n" +
+	" \x03(\tR\n" +
+	"mergedFrom\x12.\n" +
+	"\x06status\x18\v \x01(\x0e2\x16.ufo.v1.SightingStatusR\x06status\x125\n" +
+	"\freview_notes\x18\f \x03(\v2\x12.ufo.v1.ReviewNoteR\vreviewNotes\x12-\n" +
+	"\twitnesses\x18\r \x03(\v2\x0f.ufo.v1.WitnessR\twitnesses\"\xaa\x01\n" +
+	"\vWitnessInfo\x12\x1e\n" +
+	"\x04name\x18\x01 \x01(\tB\n" +
+	"\xfaB\ar\x05\x10\x01\x18\x80\x02R\x04name\x12\"\n" +
+	"\acontact\x18\x02 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x02R\acontact\x129\n" +
+	"\vcredibility\x18\x03 \x01(\x01B\x17\xfaB\x14\x12\x12\x19\x00\x00\x00\x00\x00\x00\xf0?)\x00\x00\x00\x00\x00\x00\x00\x00R\vcredibility\x12\x1c\n" +
+	"\tanonymous\x18\x04 \x01(\bR\tanonymous\"\xb0\x01\n" +
+	"\aWitness\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x04info\x18\x02 \x01(\v2\x13.ufo.v1.WitnessInfoR\x04info\x12\x19\n" +
+	"\badded_by\x18\x03 \x01(\tR\aaddedBy\x125\n" +
+	"\badded_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aaddedAt\x12\x1a\n" +
+	"\bredacted\x18\x05 \x01(\bR\bredacted\"\xe5\x01\n" +
+	"\n" +
+	"ReviewNote\x12\x1a\n" +
+	"\breviewer\x18\x01 \x01(\tR\breviewer\x127\n" +
+	"\vfrom_status\x18\x02 \x01(\x0e2\x16.ufo.v1.SightingStatusR\n" +
+	"fromStatus\x123\n" +
+	"\tto_status\x18\x03 \x01(\x0e2\x16.ufo.v1.SightingStatusR\btoStatus\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd0\x01\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x04 \x01(\x03R\tsizeBytes\x12\x16\n" +
+	"\x06sha256\x18\x05 \x01(\tR\x06sha256\x12;\n" +
+	"\vuploaded_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"uploadedAt\"v\n" +
+	"\rCreateRequest\x122\n" +
+	"\x04info\x18\x01 \x01(\v2\x14.ufo.v1.SightingInfoB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x04info\x121\n" +
+	"\x0fidempotency_key\x18\x02 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x01R\x0eidempotencyKey\"o\n" +
 	"\x0eCreateResponse\x12\x12\n" +
-	"\x04uuid\x18\x01 \x01(\tR\x04uuid\" \n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12/\n" +
+	"\x13possible_duplicates\x18\x03 \x03(\tR\x12possibleDuplicates\"S\n" +
 	"\n" +
-	"GetRequest\x12\x12\n" +
-	"\x04uuid\x18\x01 \x01(\tR\x04uuid\";\n" +
+	"GetRequest\x12\x1c\n" +
+	"\x04uuid\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\x04uuid\x12'\n" +
+	"\x0finclude_deleted\x18\x02 \x01(\bR\x0eincludeDeleted\";\n" +
 	"\vGetResponse\x12,\n" +
-	"\bsighting\x18\x01 \x01(\v2\x10.ufo.v1.SightingR\bsighting\"`\n" +
-	"\rUpdateRequest\x12\x12\n" +
-	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12;\n" +
+	"\bsighting\x18\x01 \x01(\v2\x10.ufo.v1.SightingR\bsighting\"\xf8\x01\n" +
+	"\rUpdateRequest\x12\x1c\n" +
+	"\x04uuid\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\x04uuid\x12;\n" +
 	"\vupdate_info\x18\x02 \x01(\v2\x1a.ufo.v1.SightingUpdateInfoR\n" +
-	"updateInfo\"#\n" +
-	"\rDeleteRequest\x12\x12\n" +
-	"\x04uuid\x18\x01 \x01(\tR\x04uuid2\xe7\x01\n" +
+	"updateInfo\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12O\n" +
+	"\x10expected_version\x18\x04 \x01(\v2\x1b.google.protobuf.Int64ValueB\a\xfaB\x04\"\x02 \x00R\x0fexpectedVersion\"*\n" +
+	"\x0eUpdateResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\"~\n" +
+	"\rDeleteRequest\x12\x1c\n" +
+	"\x04uuid\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\x04uuid\x12O\n" +
+	"\x10expected_version\x18\x02 \x01(\v2\x1b.google.protobuf.Int64ValueB\a\xfaB\x04\"\x02 \x00R\x0fexpectedVersion\"\xda\x03\n" +
+	"\n" +
+	"ListFilter\x12?\n" +
+	"\robserved_from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\fobservedFrom\x12;\n" +
+	"\vobserved_to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"observedTo\x125\n" +
+	"\x11location_contains\x18\x03 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x02R\x10locationContains\x122\n" +
+	"\x05color\x18\x04 \x01(\v2\x1c.google.protobuf.StringValueR\x05color\x122\n" +
+	"\x05sound\x18\x05 \x01(\v2\x1c.google.protobuf.StringValueR\x05sound\x12=\n" +
+	"\fhas_duration\x18\x06 \x01(\v2\x1a.google.protobuf.BoolValueR\vhasDuration\x12'\n" +
+	"\x0finclude_deleted\x18\a \x01(\bR\x0eincludeDeleted\x12G\n" +
+	"\bstatuses\x18\b \x03(\x0e2\x16.ufo.v1.SightingStatusB\x13\xfaB\x10\x92\x01\r\x10\x05\x18\x01\"\a\x82\x01\x04\x10\x01 \x00R\bstatuses\"~\n" +
+	"\vListRequest\x12$\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12*\n" +
+	"\x06filter\x18\x03 \x01(\v2\x12.ufo.v1.ListFilterR\x06filter\"f\n" +
+	"\fListResponse\x12.\n" +
+	"\tsightings\x18\x01 \x03(\v2\x10.ufo.v1.SightingR\tsightings\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"v\n" +
+	"\rSearchRequest\x12 \n" +
+	"\x05query\x18\x01 \x01(\tB\n" +
+	"\xfaB\ar\x05\x10\x01\x18\x80\x02R\x05query\x12$\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"R\n" +
+	"\fSearchResult\x12,\n" +
+	"\bsighting\x18\x01 \x01(\v2\x10.ufo.v1.SightingR\bsighting\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"\x87\x01\n" +
+	"\x0eSearchResponse\x12.\n" +
+	"\aresults\x18\x01 \x03(\v2\x14.ufo.v1.SearchResultR\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"u\n" +
+	"\tGeoCircle\x122\n" +
+	"\x06center\x18\x01 \x01(\v2\x10.ufo.v1.GeoPointB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x06center\x124\n" +
+	"\tradius_km\x18\x02 \x01(\x01B\x17\xfaB\x14\x12\x12\x19\x00\x00\x00\x00\x00\x8c\xd3@!\x00\x00\x00\x00\x00\x00\x00\x00R\bradiusKm\"\x86\x01\n" +
+	"\x0eGeoBoundingBox\x129\n" +
+	"\n" +
+	"south_west\x18\x01 \x01(\v2\x10.ufo.v1.GeoPointB\b\xfaB\x05\x8a\x01\x02\x10\x01R\tsouthWest\x129\n" +
+	"\n" +
+	"north_east\x18\x02 \x01(\v2\x10.ufo.v1.GeoPointB\b\xfaB\x05\x8a\x01\x02\x10\x01R\tnorthEast\"\xbe\x01\n" +
+	"\x11FindNearbyRequest\x12+\n" +
+	"\x06circle\x18\x01 \x01(\v2\x11.ufo.v1.GeoCircleH\x00R\x06circle\x12*\n" +
+	"\x03box\x18\x02 \x01(\v2\x16.ufo.v1.GeoBoundingBoxH\x00R\x03box\x12$\n" +
+	"\tpage_size\x18\x03 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageTokenB\v\n" +
+	"\x04area\x12\x03\xf8B\x01\"_\n" +
+	"\x0eNearbySighting\x12,\n" +
+	"\bsighting\x18\x01 \x01(\v2\x10.ufo.v1.SightingR\bsighting\x12\x1f\n" +
+	"\vdistance_km\x18\x02 \x01(\x01R\n" +
+	"distanceKm\"\x91\x01\n" +
+	"\x12FindNearbyResponse\x124\n" +
+	"\tsightings\x18\x01 \x03(\v2\x16.ufo.v1.NearbySightingR\tsightings\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"\xc5\x01\n" +
+	"\rSightingEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12-\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x19.ufo.v1.SightingEventTypeR\x04type\x12,\n" +
+	"\bsighting\x18\x03 \x01(\v2\x10.ufo.v1.SightingR\bsighting\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"3\n" +
+	"\fWatchRequest\x12#\n" +
+	"\rlast_sequence\x18\x01 \x01(\x04R\flastSequence\"<\n" +
+	"\rWatchResponse\x12+\n" +
+	"\x05event\x18\x01 \x01(\v2\x15.ufo.v1.SightingEventR\x05event\"'\n" +
+	"\rImportOptions\x12\x16\n" +
+	"\x06atomic\x18\x01 \x01(\bR\x06atomic\"\x82\x01\n" +
+	"\x16ImportSightingsRequest\x121\n" +
+	"\aoptions\x18\x01 \x01(\v2\x15.ufo.v1.ImportOptionsH\x00R\aoptions\x12*\n" +
+	"\x04info\x18\x02 \x01(\v2\x14.ufo.v1.SightingInfoH\x00R\x04infoB\t\n" +
+	"\apayload\"<\n" +
+	"\x10ImportedSighting\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04uuid\x18\x02 \x01(\tR\x04uuid\"A\n" +
+	"\x0fImportItemError\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x9a\x01\n" +
+	"\x17ImportSightingsResponse\x12\x1a\n" +
+	"\breceived\x18\x01 \x01(\x05R\breceived\x122\n" +
+	"\acreated\x18\x02 \x03(\v2\x18.ufo.v1.ImportedSightingR\acreated\x12/\n" +
+	"\x06errors\x18\x03 \x03(\v2\x17.ufo.v1.ImportItemErrorR\x06errors\"\xf6\x01\n" +
+	"\x16UploadAttachmentHeader\x12-\n" +
+	"\rsighting_uuid\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\fsightingUuid\x12'\n" +
+	"\tfile_name\x18\x02 \x01(\tB\n" +
+	"\xfaB\ar\x05\x10\x01\x18\xff\x01R\bfileName\x12-\n" +
+	"\fcontent_type\x18\x03 \x01(\tB\n" +
+	"\xfaB\ar\x05\x10\x01\x18\x80\x01R\vcontentType\x12&\n" +
+	"\n" +
+	"size_bytes\x18\x04 \x01(\x03B\a\xfaB\x04\"\x02 \x00R\tsizeBytes\x12-\n" +
+	"\x06sha256\x18\x05 \x01(\tB\x15\xfaB\x12r\x102\x0e^[0-9a-f]{64}$R\x06sha256\"{\n" +
+	"\x17UploadAttachmentRequest\x128\n" +
+	"\x06header\x18\x01 \x01(\v2\x1e.ufo.v1.UploadAttachmentHeaderH\x00R\x06header\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x0e\n" +
+	"\apayload\x12\x03\xf8B\x01\"h\n" +
+	"\x18UploadAttachmentResponse\x122\n" +
 	"\n" +
-	"UFOService\x127\n" +
-	"\x06Create\x12\x15.ufo.v1.CreateRequest\x1a\x16.ufo.v1.CreateResponse\x12.\n" +
-	"\x03Get\x12\x12.ufo.v1.GetRequest\x1a\x13.ufo.v1.GetResponse\x127\n" +
-	"\x06Update\x12\x15.ufo.v1.UpdateRequest\x1a\x16.google.protobuf.Empty\x127\n" +
-	"\x06Delete\x12\x15.ufo.v1.DeleteRequest\x1a\x16.google.protobuf.EmptyBBZ@github.com/yyunoshev/yyunoshev_go/week1/grpc/proto/ufo/v1;ufo_v1b\x06proto3"
+	"attachment\x18\x01 \x01(\v2\x12.ufo.v1.AttachmentR\n" +
+	"attachment\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\"y\n" +
+	"\x19DownloadAttachmentRequest\x12-\n" +
+	"\rsighting_uuid\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\fsightingUuid\x12-\n" +
+	"\rattachment_id\x18\x02 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\fattachmentId\"u\n" +
+	"\x1aDownloadAttachmentResponse\x124\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x12.ufo.v1.AttachmentH\x00R\n" +
+	"attachment\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"\x7f\n" +
+	"\x0eRestoreRequest\x12\x1c\n" +
+	"\x04uuid\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\x04uuid\x12O\n" +
+	"\x10expected_version\x18\x02 \x01(\v2\x1b.google.protobuf.Int64ValueB\a\xfaB\x04\"\x02 \x00R\x0fexpectedVersion\",\n" +
+	"\fPurgeRequest\x12\x1c\n" +
+	"\x04uuid\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\x04uuid\"\x8d\x01\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x123\n" +
+	"\told_value\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\boldValue\x123\n" +
+	"\tnew_value\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\bnewValue\"\xd6\x02\n" +
+	"\bRevision\x12#\n" +
+	"\rsighting_uuid\x18\x01 \x01(\tR\fsightingUuid\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12-\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x19.ufo.v1.SightingEventTypeR\x04type\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12-\n" +
+	"\achanges\x18\x06 \x03(\v2\x13.ufo.v1.FieldChangeR\achanges\x12(\n" +
+	"\x04info\x18\a \x01(\v2\x14.ufo.v1.SightingInfoR\x04info\x122\n" +
+	"\x15reverted_from_version\x18\b \x01(\x03R\x13revertedFromVersion\"v\n" +
+	"\x11GetHistoryRequest\x12\x1c\n" +
+	"\x04uuid\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\x04uuid\x12$\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"l\n" +
+	"\x12GetHistoryResponse\x12.\n" +
+	"\trevisions\x18\x01 \x03(\v2\x10.ufo.v1.RevisionR\trevisions\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"U\n" +
+	"\x12GetRevisionRequest\x12\x1c\n" +
+	"\x04uuid\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\x04uuid\x12!\n" +
+	"\aversion\x18\x02 \x01(\x03B\a\xfaB\x04\"\x02 \x00R\aversion\"\xa9\x01\n" +
+	"\x15RevertSightingRequest\x12\x1c\n" +
+	"\x04uuid\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\x04uuid\x12!\n" +
+	"\aversion\x18\x02 \x01(\x03B\a\xfaB\x04\"\x02 \x00R\aversion\x12O\n" +
+	"\x10expected_version\x18\x03 \x01(\v2\x1b.google.protobuf.Int64ValueB\a\xfaB\x04\"\x02 \x00R\x0fexpectedVersion\"2\n" +
+	"\x16RevertSightingResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\"j\n" +
+	"\x0fGetStatsRequest\x12*\n" +
+	"\x06filter\x18\x01 \x01(\v2\x12.ufo.v1.ListFilterR\x06filter\x12+\n" +
+	"\vgroup_limit\x18\x02 \x01(\x05B\n" +
+	"\xfaB\a\x1a\x05\x18\xe8\a(\x00R\n" +
+	"groupLimit\"U\n" +
+	"\vPeriodCount\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"8\n" +
+	"\n" +
+	"GroupCount\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\x8e\x02\n" +
+	"\rDurationStats\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x1f\n" +
+	"\vmin_seconds\x18\x02 \x01(\x05R\n" +
+	"minSeconds\x12\x1f\n" +
+	"\vmax_seconds\x18\x03 \x01(\x05R\n" +
+	"maxSeconds\x12!\n" +
+	"\fmean_seconds\x18\x04 \x01(\x01R\vmeanSeconds\x12\x1f\n" +
+	"\vp50_seconds\x18\x05 \x01(\x05R\n" +
+	"p50Seconds\x12\x1f\n" +
+	"\vp90_seconds\x18\x06 \x01(\x05R\n" +
+	"p90Seconds\x12\x1f\n" +
+	"\vp95_seconds\x18\a \x01(\x05R\n" +
+	"p95Seconds\x12\x1f\n" +
+	"\vp99_seconds\x18\b \x01(\x05R\n" +
+	"p99Seconds\"\xf8\x02\n" +
+	"\x10GetStatsResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12*\n" +
+	"\x06by_day\x18\x02 \x03(\v2\x13.ufo.v1.PeriodCountR\x05byDay\x12,\n" +
+	"\aby_week\x18\x03 \x03(\v2\x13.ufo.v1.PeriodCountR\x06byWeek\x12.\n" +
+	"\bby_month\x18\x04 \x03(\v2\x13.ufo.v1.PeriodCountR\abyMonth\x12-\n" +
+	"\bby_color\x18\x05 \x03(\v2\x12.ufo.v1.GroupCountR\abyColor\x12-\n" +
+	"\bby_sound\x18\x06 \x03(\v2\x12.ufo.v1.GroupCountR\abySound\x123\n" +
+	"\vby_location\x18\a \x03(\v2\x12.ufo.v1.GroupCountR\n" +
+	"byLocation\x121\n" +
+	"\bduration\x18\b \x01(\v2\x15.ufo.v1.DurationStatsR\bduration\"k\n" +
+	"\x15FindDuplicatesRequest\x12\x1c\n" +
+	"\x04uuid\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\x04uuid\x124\n" +
+	"\tmin_score\x18\x02 \x01(\x01B\x17\xfaB\x14\x12\x12\x19\x00\x00\x00\x00\x00\x00\xf0?)\x00\x00\x00\x00\x00\x00\x00\x00R\bminScore\"T\n" +
+	"\x0eDuplicateMatch\x12,\n" +
+	"\bsighting\x18\x01 \x01(\v2\x10.ufo.v1.SightingR\bsighting\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"J\n" +
+	"\x16FindDuplicatesResponse\x120\n" +
+	"\amatches\x18\x01 \x03(\v2\x16.ufo.v1.DuplicateMatchR\amatches\"\xd7\x01\n" +
+	"\x15MergeSightingsRequest\x12/\n" +
+	"\x0ecanonical_uuid\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\rcanonicalUuid\x12<\n" +
+	"\x0fduplicate_uuids\x18\x02 \x03(\tB\x13\xfaB\x10\x92\x01\r\b\x01\x10d\x18\x01\"\x05r\x03\xb0\x01\x01R\x0eduplicateUuids\x12O\n" +
+	"\x10expected_version\x18\x03 \x01(\v2\x1b.google.protobuf.Int64ValueB\a\xfaB\x04\"\x02 \x00R\x0fexpectedVersion\"F\n" +
+	"\x16MergeSightingsResponse\x12,\n" +
+	"\bsighting\x18\x01 \x01(\v2\x10.ufo.v1.SightingR\bsighting\"\xe2\x01\n" +
+	"\x17TransitionStatusRequest\x12\x1c\n" +
+	"\x04uuid\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\x04uuid\x12:\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x16.ufo.v1.SightingStatusB\n" +
+	"\xfaB\a\x82\x01\x04\x10\x01 \x00R\x06status\x12\x1c\n" +
+	"\x04note\x18\x03 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x10R\x04note\x12O\n" +
+	"\x10expected_version\x18\x04 \x01(\v2\x1b.google.protobuf.Int64ValueB\a\xfaB\x04\"\x02 \x00R\x0fexpectedVersion\"H\n" +
+	"\x18TransitionStatusResponse\x12,\n" +
+	"\bsighting\x18\x01 \x01(\v2\x10.ufo.v1.SightingR\bsighting\"\x96\x01\n" +
+	"\x13ListByStatusRequest\x12:\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x16.ufo.v1.SightingStatusB\n" +
+	"\xfaB\a\x82\x01\x04\x10\x01 \x00R\x06status\x12$\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"n\n" +
+	"\x14ListByStatusResponse\x12.\n" +
+	"\tsightings\x18\x01 \x03(\v2\x10.ufo.v1.SightingR\tsightings\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xcc\x01\n" +
+	"\x11AddWitnessRequest\x12-\n" +
+	"\rsighting_uuid\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\fsightingUuid\x127\n" +
+	"\awitness\x18\x02 \x01(\v2\x13.ufo.v1.WitnessInfoB\b\xfaB\x05\x8a\x01\x02\x10\x01R\awitness\x12O\n" +
+	"\x10expected_version\x18\x03 \x01(\v2\x1b.google.protobuf.Int64ValueB\a\xfaB\x04\"\x02 \x00R\x0fexpectedVersion\"Y\n" +
+	"\x12AddWitnessResponse\x12)\n" +
+	"\awitness\x18\x01 \x01(\v2\x0f.ufo.v1.WitnessR\awitness\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\"\xbf\x01\n" +
+	"\x14RemoveWitnessRequest\x12-\n" +
+	"\rsighting_uuid\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\fsightingUuid\x12'\n" +
+	"\n" +
+	"witness_id\x18\x02 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\twitnessId\x12O\n" +
+	"\x10expected_version\x18\x03 \x01(\v2\x1b.google.protobuf.Int64ValueB\a\xfaB\x04\"\x02 \x00R\x0fexpectedVersion\"1\n" +
+	"\x15RemoveWitnessResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\"\xbe\x01\n" +
+	"\x1dFindSightingsByWitnessRequest\x12 \n" +
+	"\x04name\x18\x01 \x01(\tB\n" +
+	"\xfaB\ar\x05\x10\x01\x18\x80\x02H\x00R\x04name\x12&\n" +
+	"\acontact\x18\x02 \x01(\tB\n" +
+	"\xfaB\ar\x05\x10\x01\x18\x80\x02H\x00R\acontact\x12$\n" +
+	"\tpage_size\x18\x03 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageTokenB\x0e\n" +
+	"\awitness\x12\x03\xf8B\x01\"x\n" +
+	"\x1eFindSightingsByWitnessResponse\x12.\n" +
+	"\tsightings\x18\x01 \x03(\v2\x10.ufo.v1.SightingR\tsightings\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*\xc8\x01\n" +
+	"\x0eSightingStatus\x12\x1f\n" +
+	"\x1bSIGHTING_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19SIGHTING_STATUS_SUBMITTED\x10\x01\x12 \n" +
+	"\x1cSIGHTING_STATUS_UNDER_REVIEW\x10\x02\x12\x1c\n" +
+	"\x18SIGHTING_STATUS_VERIFIED\x10\x03\x12\x1c\n" +
+	"\x18SIGHTING_STATUS_REJECTED\x10\x04\x12\x18\n" +
+	"\x14SIGHTING_STATUS_HOAX\x10\x05*\xdd\x01\n" +
+	"\x11SightingEventType\x12#\n" +
+	"\x1fSIGHTING_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bSIGHTING_EVENT_TYPE_CREATED\x10\x01\x12\x1f\n" +
+	"\x1bSIGHTING_EVENT_TYPE_UPDATED\x10\x02\x12\x1f\n" +
+	"\x1bSIGHTING_EVENT_TYPE_DELETED\x10\x03\x12 \n" +
+	"\x1cSIGHTING_EVENT_TYPE_RESTORED\x10\x04\x12\x1e\n" +
+	"\x1aSIGHTING_EVENT_TYPE_PURGED\x10\x052\xaf\x14\n" +
+	"\n" +
+	"UFOService\x12U\n" +
+	"\x06Create\x12\x15.ufo.v1.CreateRequest\x1a\x16.ufo.v1.CreateResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/api/v1/sightings\x12P\n" +
+	"\x03Get\x12\x12.ufo.v1.GetRequest\x1a\x13.ufo.v1.GetResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/sightings/{uuid}\x12f\n" +
+	"\x06Update\x12\x15.ufo.v1.UpdateRequest\x1a\x16.ufo.v1.UpdateResponse\"-\x82\xd3\xe4\x93\x02':\vupdate_info2\x18/api/v1/sightings/{uuid}\x12Y\n" +
+	"\x06Delete\x12\x15.ufo.v1.DeleteRequest\x1a\x16.google.protobuf.Empty\" \x82\xd3\xe4\x93\x02\x1a*\x18/api/v1/sightings/{uuid}\x12L\n" +
+	"\x04List\x12\x13.ufo.v1.ListRequest\x1a\x14.ufo.v1.ListResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/api/v1/sightings\x12Y\n" +
+	"\x06Search\x12\x15.ufo.v1.SearchRequest\x1a\x16.ufo.v1.SearchResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/sightings:search\x12e\n" +
+	"\n" +
+	"FindNearby\x12\x19.ufo.v1.FindNearbyRequest\x1a\x1a.ufo.v1.FindNearbyResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/sightings:nearby\x12\x81\x01\n" +
+	"\n" +
+	"AddWitness\x12\x19.ufo.v1.AddWitnessRequest\x1a\x1a.ufo.v1.AddWitnessResponse\"<\x82\xd3\xe4\x93\x026:\awitness\"+/api/v1/sightings/{sighting_uuid}/witnesses\x12\x8e\x01\n" +
+	"\rRemoveWitness\x12\x1c.ufo.v1.RemoveWitnessRequest\x1a\x1d.ufo.v1.RemoveWitnessResponse\"@\x82\xd3\xe4\x93\x02:*8/api/v1/sightings/{sighting_uuid}/witnesses/{witness_id}\x12\x8c\x01\n" +
+	"\x16FindSightingsByWitness\x12%.ufo.v1.FindSightingsByWitnessRequest\x1a&.ufo.v1.FindSightingsByWitnessResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/api/v1/sightings:byWitness\x12\x85\x01\n" +
+	"\x10TransitionStatus\x12\x1f.ufo.v1.TransitionStatusRequest\x1a .ufo.v1.TransitionStatusResponse\".\x82\xd3\xe4\x93\x02(:\x01*\"#/api/v1/sightings/{uuid}:transition\x12m\n" +
+	"\fListByStatus\x12\x1b.ufo.v1.ListByStatusRequest\x1a\x1c.ufo.v1.ListByStatusResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/api/v1/sightings:byStatus\x12^\n" +
+	"\bGetStats\x12\x17.ufo.v1.GetStatsRequest\x1a\x18.ufo.v1.GetStatsResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/sightings:stats\x12W\n" +
+	"\x05Watch\x12\x14.ufo.v1.WatchRequest\x1a\x15.ufo.v1.WatchResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/sightings:watch0\x01\x12T\n" +
+	"\x0fImportSightings\x12\x1e.ufo.v1.ImportSightingsRequest\x1a\x1f.ufo.v1.ImportSightingsResponse(\x01\x12W\n" +
+	"\x10UploadAttachment\x12\x1f.ufo.v1.UploadAttachmentRequest\x1a .ufo.v1.UploadAttachmentResponse(\x01\x12]\n" +
+	"\x12DownloadAttachment\x12!.ufo.v1.DownloadAttachmentRequest\x1a\".ufo.v1.DownloadAttachmentResponse0\x01\x12f\n" +
+	"\aRestore\x12\x16.ufo.v1.RestoreRequest\x1a\x16.google.protobuf.Empty\"+\x82\xd3\xe4\x93\x02%:\x01*\" /api/v1/sightings/{uuid}:restore\x12|\n" +
+	"\x0eFindDuplicates\x12\x1d.ufo.v1.FindDuplicatesRequest\x1a\x1e.ufo.v1.FindDuplicatesResponse\"+\x82\xd3\xe4\x93\x02%\x12#/api/v1/sightings/{uuid}/duplicates\x12\x84\x01\n" +
+	"\x0eMergeSightings\x12\x1d.ufo.v1.MergeSightingsRequest\x1a\x1e.ufo.v1.MergeSightingsResponse\"3\x82\xd3\xe4\x93\x02-:\x01*\"(/api/v1/sightings/{canonical_uuid}:merge\x12o\n" +
+	"\n" +
+	"GetHistory\x12\x19.ufo.v1.GetHistoryRequest\x1a\x1a.ufo.v1.GetHistoryResponse\"*\x82\xd3\xe4\x93\x02$\x12\"/api/v1/sightings/{uuid}/revisions\x12q\n" +
+	"\vGetRevision\x12\x1a.ufo.v1.GetRevisionRequest\x1a\x10.ufo.v1.Revision\"4\x82\xd3\xe4\x93\x02.\x12,/api/v1/sightings/{uuid}/revisions/{version}\x12\x8f\x01\n" +
+	"\x0eRevertSighting\x12\x1d.ufo.v1.RevertSightingRequest\x1a\x1e.ufo.v1.RevertSightingResponse\">\x82\xd3\xe4\x93\x028:\x01*\"3/api/v1/sightings/{uuid}/revisions/{version}:revert\x12`\n" +
+	"\x05Purge\x12\x14.ufo.v1.PurgeRequest\x1a\x16.google.protobuf.Empty\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/api/v1/sightings/{uuid}:purgeBBZ@github.com/yyunoshev/yyunoshev_go/week1/grpc/proto/ufo/v1;ufo_v1b\x06proto3"
 
 var (
 	file_ufo_v1_ufo_proto_rawDescOnce sync.Once
@@ -610,53 +5015,234 @@ func file_ufo_v1_ufo_proto_rawDescGZIP() []byte {
 	return file_ufo_v1_ufo_proto_rawDescData
 }
 
-var file_ufo_v1_ufo_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_ufo_v1_ufo_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_ufo_v1_ufo_proto_msgTypes = make([]protoimpl.MessageInfo, 68)
 var file_ufo_v1_ufo_proto_goTypes = []any{
-	(*SightingInfo)(nil),           // 0: ufo.v1.SightingInfo
-	(*SightingUpdateInfo)(nil),     // 1: ufo.v1.SightingUpdateInfo
-	(*Sighting)(nil),               // 2: ufo.v1.Sighting
-	(*CreateRequest)(nil),          // 3: ufo.v1.CreateRequest
-	(*CreateResponse)(nil),         // 4: ufo.v1.CreateResponse
-	(*GetRequest)(nil),             // 5: ufo.v1.GetRequest
-	(*GetResponse)(nil),            // 6: ufo.v1.GetResponse
-	(*UpdateRequest)(nil),          // 7: ufo.v1.UpdateRequest
-	(*DeleteRequest)(nil),          // 8: ufo.v1.DeleteRequest
-	(*timestamppb.Timestamp)(nil),  // 9: google.protobuf.Timestamp
-	(*wrapperspb.StringValue)(nil), // 10: google.protobuf.StringValue
-	(*wrapperspb.Int32Value)(nil),  // 11: google.protobuf.Int32Value
-	(*emptypb.Empty)(nil),          // 12: google.protobuf.Empty
+	(SightingStatus)(0),                    // 0: ufo.v1.SightingStatus
+	(SightingEventType)(0),                 // 1: ufo.v1.SightingEventType
+	(*SightingInfo)(nil),                   // 2: ufo.v1.SightingInfo
+	(*SightingUpdateInfo)(nil),             // 3: ufo.v1.SightingUpdateInfo
+	(*GeoPoint)(nil),                       // 4: ufo.v1.GeoPoint
+	(*Sighting)(nil),                       // 5: ufo.v1.Sighting
+	(*WitnessInfo)(nil),                    // 6: ufo.v1.WitnessInfo
+	(*Witness)(nil),                        // 7: ufo.v1.Witness
+	(*ReviewNote)(nil),                     // 8: ufo.v1.ReviewNote
+	(*Attachment)(nil),                     // 9: ufo.v1.Attachment
+	(*CreateRequest)(nil),                  // 10: ufo.v1.CreateRequest
+	(*CreateResponse)(nil),                 // 11: ufo.v1.CreateResponse
+	(*GetRequest)(nil),                     // 12: ufo.v1.GetRequest
+	(*GetResponse)(nil),                    // 13: ufo.v1.GetResponse
+	(*UpdateRequest)(nil),                  // 14: ufo.v1.UpdateRequest
+	(*UpdateResponse)(nil),                 // 15: ufo.v1.UpdateResponse
+	(*DeleteRequest)(nil),                  // 16: ufo.v1.DeleteRequest
+	(*ListFilter)(nil),                     // 17: ufo.v1.ListFilter
+	(*ListRequest)(nil),                    // 18: ufo.v1.ListRequest
+	(*ListResponse)(nil),                   // 19: ufo.v1.ListResponse
+	(*SearchRequest)(nil),                  // 20: ufo.v1.SearchRequest
+	(*SearchResult)(nil),                   // 21: ufo.v1.SearchResult
+	(*SearchResponse)(nil),                 // 22: ufo.v1.SearchResponse
+	(*GeoCircle)(nil),                      // 23: ufo.v1.GeoCircle
+	(*GeoBoundingBox)(nil),                 // 24: ufo.v1.GeoBoundingBox
+	(*FindNearbyRequest)(nil),              // 25: ufo.v1.FindNearbyRequest
+	(*NearbySighting)(nil),                 // 26: ufo.v1.NearbySighting
+	(*FindNearbyResponse)(nil),             // 27: ufo.v1.FindNearbyResponse
+	(*SightingEvent)(nil),                  // 28: ufo.v1.SightingEvent
+	(*WatchRequest)(nil),                   // 29: ufo.v1.WatchRequest
+	(*WatchResponse)(nil),                  // 30: ufo.v1.WatchResponse
+	(*ImportOptions)(nil),                  // 31: ufo.v1.ImportOptions
+	(*ImportSightingsRequest)(nil),         // 32: ufo.v1.ImportSightingsRequest
+	(*ImportedSighting)(nil),               // 33: ufo.v1.ImportedSighting
+	(*ImportItemError)(nil),                // 34: ufo.v1.ImportItemError
+	(*ImportSightingsResponse)(nil),        // 35: ufo.v1.ImportSightingsResponse
+	(*UploadAttachmentHeader)(nil),         // 36: ufo.v1.UploadAttachmentHeader
+	(*UploadAttachmentRequest)(nil),        // 37: ufo.v1.UploadAttachmentRequest
+	(*UploadAttachmentResponse)(nil),       // 38: ufo.v1.UploadAttachmentResponse
+	(*DownloadAttachmentRequest)(nil),      // 39: ufo.v1.DownloadAttachmentRequest
+	(*DownloadAttachmentResponse)(nil),     // 40: ufo.v1.DownloadAttachmentResponse
+	(*RestoreRequest)(nil),                 // 41: ufo.v1.RestoreRequest
+	(*PurgeRequest)(nil),                   // 42: ufo.v1.PurgeRequest
+	(*FieldChange)(nil),                    // 43: ufo.v1.FieldChange
+	(*Revision)(nil),                       // 44: ufo.v1.Revision
+	(*GetHistoryRequest)(nil),              // 45: ufo.v1.GetHistoryRequest
+	(*GetHistoryResponse)(nil),             // 46: ufo.v1.GetHistoryResponse
+	(*GetRevisionRequest)(nil),             // 47: ufo.v1.GetRevisionRequest
+	(*RevertSightingRequest)(nil),          // 48: ufo.v1.RevertSightingRequest
+	(*RevertSightingResponse)(nil),         // 49: ufo.v1.RevertSightingResponse
+	(*GetStatsRequest)(nil),                // 50: ufo.v1.GetStatsRequest
+	(*PeriodCount)(nil),                    // 51: ufo.v1.PeriodCount
+	(*GroupCount)(nil),                     // 52: ufo.v1.GroupCount
+	(*DurationStats)(nil),                  // 53: ufo.v1.DurationStats
+	(*GetStatsResponse)(nil),               // 54: ufo.v1.GetStatsResponse
+	(*FindDuplicatesRequest)(nil),          // 55: ufo.v1.FindDuplicatesRequest
+	(*DuplicateMatch)(nil),                 // 56: ufo.v1.DuplicateMatch
+	(*FindDuplicatesResponse)(nil),         // 57: ufo.v1.FindDuplicatesResponse
+	(*MergeSightingsRequest)(nil),          // 58: ufo.v1.MergeSightingsRequest
+	(*MergeSightingsResponse)(nil),         // 59: ufo.v1.MergeSightingsResponse
+	(*TransitionStatusRequest)(nil),        // 60: ufo.v1.TransitionStatusRequest
+	(*TransitionStatusResponse)(nil),       // 61: ufo.v1.TransitionStatusResponse
+	(*ListByStatusRequest)(nil),            // 62: ufo.v1.ListByStatusRequest
+	(*ListByStatusResponse)(nil),           // 63: ufo.v1.ListByStatusResponse
+	(*AddWitnessRequest)(nil),              // 64: ufo.v1.AddWitnessRequest
+	(*AddWitnessResponse)(nil),             // 65: ufo.v1.AddWitnessResponse
+	(*RemoveWitnessRequest)(nil),           // 66: ufo.v1.RemoveWitnessRequest
+	(*RemoveWitnessResponse)(nil),          // 67: ufo.v1.RemoveWitnessResponse
+	(*FindSightingsByWitnessRequest)(nil),  // 68: ufo.v1.FindSightingsByWitnessRequest
+	(*FindSightingsByWitnessResponse)(nil), // 69: ufo.v1.FindSightingsByWitnessResponse
+	(*timestamppb.Timestamp)(nil),          // 70: google.protobuf.Timestamp
+	(*wrapperspb.StringValue)(nil),         // 71: google.protobuf.StringValue
+	(*wrapperspb.Int32Value)(nil),          // 72: google.protobuf.Int32Value
+	(*fieldmaskpb.FieldMask)(nil),          // 73: google.protobuf.FieldMask
+	(*wrapperspb.Int64Value)(nil),          // 74: google.protobuf.Int64Value
+	(*wrapperspb.BoolValue)(nil),           // 75: google.protobuf.BoolValue
+	(*structpb.Value)(nil),                 // 76: google.protobuf.Value
+	(*emptypb.Empty)(nil),                  // 77: google.protobuf.Empty
 }
 var file_ufo_v1_ufo_proto_depIdxs = []int32{
-	9,  // 0: ufo.v1.SightingInfo.observed_at:type_name -> google.protobuf.Timestamp
-	10, // 1: ufo.v1.SightingInfo.color:type_name -> google.protobuf.StringValue
-	10, // 2: ufo.v1.SightingInfo.sound:type_name -> google.protobuf.StringValue
-	11, // 3: ufo.v1.SightingInfo.duration_seconds:type_name -> google.protobuf.Int32Value
-	9,  // 4: ufo.v1.SightingUpdateInfo.observed_at:type_name -> google.protobuf.Timestamp
-	10, // 5: ufo.v1.SightingUpdateInfo.location:type_name -> google.protobuf.StringValue
-	10, // 6: ufo.v1.SightingUpdateInfo.description:type_name -> google.protobuf.StringValue
-	10, // 7: ufo.v1.SightingUpdateInfo.color:type_name -> google.protobuf.StringValue
-	10, // 8: ufo.v1.SightingUpdateInfo.sound:type_name -> google.protobuf.StringValue
-	11, // 9: ufo.v1.SightingUpdateInfo.duration_seconds:type_name -> google.protobuf.Int32Value
-	0,  // 10: ufo.v1.Sighting.info:type_name -> ufo.v1.SightingInfo
-	9,  // 11: ufo.v1.Sighting.created_at:type_name -> google.protobuf.Timestamp
-	9,  // 12: ufo.v1.Sighting.updated_at:type_name -> google.protobuf.Timestamp
-	9,  // 13: ufo.v1.Sighting.deleted_at:type_name -> google.protobuf.Timestamp
-	0,  // 14: ufo.v1.CreateRequest.info:type_name -> ufo.v1.SightingInfo
-	2,  // 15: ufo.v1.GetResponse.sighting:type_name -> ufo.v1.Sighting
-	1,  // 16: ufo.v1.UpdateRequest.update_info:type_name -> ufo.v1.SightingUpdateInfo
-	3,  // 17: ufo.v1.UFOService.Create:input_type -> ufo.v1.CreateRequest
-	5,  // 18: ufo.v1.UFOService.Get:input_type -> ufo.v1.GetRequest
-	7,  // 19: ufo.v1.UFOService.Update:input_type -> ufo.v1.UpdateRequest
-	8,  // 20: ufo.v1.UFOService.Delete:input_type -> ufo.v1.DeleteRequest
-	4,  // 21: ufo.v1.UFOService.Create:output_type -> ufo.v1.CreateResponse
-	6,  // 22: ufo.v1.UFOService.Get:output_type -> ufo.v1.GetResponse
-	12, // 23: ufo.v1.UFOService.Update:output_type -> google.protobuf.Empty
-	12, // 24: ufo.v1.UFOService.Delete:output_type -> google.protobuf.Empty
-	21, // [21:25] is the sub-list for method output_type
-	17, // [17:21] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	70,  // 0: ufo.v1.SightingInfo.observed_at:type_name -> google.protobuf.Timestamp
+	71,  // 1: ufo.v1.SightingInfo.color:type_name -> google.protobuf.StringValue
+	71,  // 2: ufo.v1.SightingInfo.sound:type_name -> google.protobuf.StringValue
+	72,  // 3: ufo.v1.SightingInfo.duration_seconds:type_name -> google.protobuf.Int32Value
+	4,   // 4: ufo.v1.SightingInfo.coordinates:type_name -> ufo.v1.GeoPoint
+	70,  // 5: ufo.v1.SightingUpdateInfo.observed_at:type_name -> google.protobuf.Timestamp
+	71,  // 6: ufo.v1.SightingUpdateInfo.location:type_name -> google.protobuf.StringValue
+	71,  // 7: ufo.v1.SightingUpdateInfo.description:type_name -> google.protobuf.StringValue
+	71,  // 8: ufo.v1.SightingUpdateInfo.color:type_name -> google.protobuf.StringValue
+	71,  // 9: ufo.v1.SightingUpdateInfo.sound:type_name -> google.protobuf.StringValue
+	72,  // 10: ufo.v1.SightingUpdateInfo.duration_seconds:type_name -> google.protobuf.Int32Value
+	4,   // 11: ufo.v1.SightingUpdateInfo.coordinates:type_name -> ufo.v1.GeoPoint
+	2,   // 12: ufo.v1.Sighting.info:type_name -> ufo.v1.SightingInfo
+	70,  // 13: ufo.v1.Sighting.created_at:type_name -> google.protobuf.Timestamp
+	70,  // 14: ufo.v1.Sighting.updated_at:type_name -> google.protobuf.Timestamp
+	70,  // 15: ufo.v1.Sighting.deleted_at:type_name -> google.protobuf.Timestamp
+	9,   // 16: ufo.v1.Sighting.attachments:type_name -> ufo.v1.Attachment
+	0,   // 17: ufo.v1.Sighting.status:type_name -> ufo.v1.SightingStatus
+	8,   // 18: ufo.v1.Sighting.review_notes:type_name -> ufo.v1.ReviewNote
+	7,   // 19: ufo.v1.Sighting.witnesses:type_name -> ufo.v1.Witness
+	6,   // 20: ufo.v1.Witness.info:type_name -> ufo.v1.WitnessInfo
+	70,  // 21: ufo.v1.Witness.added_at:type_name -> google.protobuf.Timestamp
+	0,   // 22: ufo.v1.ReviewNote.from_status:type_name -> ufo.v1.SightingStatus
+	0,   // 23: ufo.v1.ReviewNote.to_status:type_name -> ufo.v1.SightingStatus
+	70,  // 24: ufo.v1.ReviewNote.created_at:type_name -> google.protobuf.Timestamp
+	70,  // 25: ufo.v1.Attachment.uploaded_at:type_name -> google.protobuf.Timestamp
+	2,   // 26: ufo.v1.CreateRequest.info:type_name -> ufo.v1.SightingInfo
+	5,   // 27: ufo.v1.GetResponse.sighting:type_name -> ufo.v1.Sighting
+	3,   // 28: ufo.v1.UpdateRequest.update_info:type_name -> ufo.v1.SightingUpdateInfo
+	73,  // 29: ufo.v1.UpdateRequest.update_mask:type_name -> google.protobuf.FieldMask
+	74,  // 30: ufo.v1.UpdateRequest.expected_version:type_name -> google.protobuf.Int64Value
+	74,  // 31: ufo.v1.DeleteRequest.expected_version:type_name -> google.protobuf.Int64Value
+	70,  // 32: ufo.v1.ListFilter.observed_from:type_name -> google.protobuf.Timestamp
+	70,  // 33: ufo.v1.ListFilter.observed_to:type_name -> google.protobuf.Timestamp
+	71,  // 34: ufo.v1.ListFilter.color:type_name -> google.protobuf.StringValue
+	71,  // 35: ufo.v1.ListFilter.sound:type_name -> google.protobuf.StringValue
+	75,  // 36: ufo.v1.ListFilter.has_duration:type_name -> google.protobuf.BoolValue
+	0,   // 37: ufo.v1.ListFilter.statuses:type_name -> ufo.v1.SightingStatus
+	17,  // 38: ufo.v1.ListRequest.filter:type_name -> ufo.v1.ListFilter
+	5,   // 39: ufo.v1.ListResponse.sightings:type_name -> ufo.v1.Sighting
+	5,   // 40: ufo.v1.SearchResult.sighting:type_name -> ufo.v1.Sighting
+	21,  // 41: ufo.v1.SearchResponse.results:type_name -> ufo.v1.SearchResult
+	4,   // 42: ufo.v1.GeoCircle.center:type_name -> ufo.v1.GeoPoint
+	4,   // 43: ufo.v1.GeoBoundingBox.south_west:type_name -> ufo.v1.GeoPoint
+	4,   // 44: ufo.v1.GeoBoundingBox.north_east:type_name -> ufo.v1.GeoPoint
+	23,  // 45: ufo.v1.FindNearbyRequest.circle:type_name -> ufo.v1.GeoCircle
+	24,  // 46: ufo.v1.FindNearbyRequest.box:type_name -> ufo.v1.GeoBoundingBox
+	5,   // 47: ufo.v1.NearbySighting.sighting:type_name -> ufo.v1.Sighting
+	26,  // 48: ufo.v1.FindNearbyResponse.sightings:type_name -> ufo.v1.NearbySighting
+	1,   // 49: ufo.v1.SightingEvent.type:type_name -> ufo.v1.SightingEventType
+	5,   // 50: ufo.v1.SightingEvent.sighting:type_name -> ufo.v1.Sighting
+	70,  // 51: ufo.v1.SightingEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	28,  // 52: ufo.v1.WatchResponse.event:type_name -> ufo.v1.SightingEvent
+	31,  // 53: ufo.v1.ImportSightingsRequest.options:type_name -> ufo.v1.ImportOptions
+	2,   // 54: ufo.v1.ImportSightingsRequest.info:type_name -> ufo.v1.SightingInfo
+	33,  // 55: ufo.v1.ImportSightingsResponse.created:type_name -> ufo.v1.ImportedSighting
+	34,  // 56: ufo.v1.ImportSightingsResponse.errors:type_name -> ufo.v1.ImportItemError
+	36,  // 57: ufo.v1.UploadAttachmentRequest.header:type_name -> ufo.v1.UploadAttachmentHeader
+	9,   // 58: ufo.v1.UploadAttachmentResponse.attachment:type_name -> ufo.v1.Attachment
+	9,   // 59: ufo.v1.DownloadAttachmentResponse.attachment:type_name -> ufo.v1.Attachment
+	74,  // 60: ufo.v1.RestoreRequest.expected_version:type_name -> google.protobuf.Int64Value
+	76,  // 61: ufo.v1.FieldChange.old_value:type_name -> google.protobuf.Value
+	76,  // 62: ufo.v1.FieldChange.new_value:type_name -> google.protobuf.Value
+	1,   // 63: ufo.v1.Revision.type:type_name -> ufo.v1.SightingEventType
+	70,  // 64: ufo.v1.Revision.created_at:type_name -> google.protobuf.Timestamp
+	43,  // 65: ufo.v1.Revision.changes:type_name -> ufo.v1.FieldChange
+	2,   // 66: ufo.v1.Revision.info:type_name -> ufo.v1.SightingInfo
+	44,  // 67: ufo.v1.GetHistoryResponse.revisions:type_name -> ufo.v1.Revision
+	74,  // 68: ufo.v1.RevertSightingRequest.expected_version:type_name -> google.protobuf.Int64Value
+	17,  // 69: ufo.v1.GetStatsRequest.filter:type_name -> ufo.v1.ListFilter
+	70,  // 70: ufo.v1.PeriodCount.start:type_name -> google.protobuf.Timestamp
+	51,  // 71: ufo.v1.GetStatsResponse.by_day:type_name -> ufo.v1.PeriodCount
+	51,  // 72: ufo.v1.GetStatsResponse.by_week:type_name -> ufo.v1.PeriodCount
+	51,  // 73: ufo.v1.GetStatsResponse.by_month:type_name -> ufo.v1.PeriodCount
+	52,  // 74: ufo.v1.GetStatsResponse.by_color:type_name -> ufo.v1.GroupCount
+	52,  // 75: ufo.v1.GetStatsResponse.by_sound:type_name -> ufo.v1.GroupCount
+	52,  // 76: ufo.v1.GetStatsResponse.by_location:type_name -> ufo.v1.GroupCount
+	53,  // 77: ufo.v1.GetStatsResponse.duration:type_name -> ufo.v1.DurationStats
+	5,   // 78: ufo.v1.DuplicateMatch.sighting:type_name -> ufo.v1.Sighting
+	56,  // 79: ufo.v1.FindDuplicatesResponse.matches:type_name -> ufo.v1.DuplicateMatch
+	74,  // 80: ufo.v1.MergeSightingsRequest.expected_version:type_name -> google.protobuf.Int64Value
+	5,   // 81: ufo.v1.MergeSightingsResponse.sighting:type_name -> ufo.v1.Sighting
+	0,   // 82: ufo.v1.TransitionStatusRequest.status:type_name -> ufo.v1.SightingStatus
+	74,  // 83: ufo.v1.TransitionStatusRequest.expected_version:type_name -> google.protobuf.Int64Value
+	5,   // 84: ufo.v1.TransitionStatusResponse.sighting:type_name -> ufo.v1.Sighting
+	0,   // 85: ufo.v1.ListByStatusRequest.status:type_name -> ufo.v1.SightingStatus
+	5,   // 86: ufo.v1.ListByStatusResponse.sightings:type_name -> ufo.v1.Sighting
+	6,   // 87: ufo.v1.AddWitnessRequest.witness:type_name -> ufo.v1.WitnessInfo
+	74,  // 88: ufo.v1.AddWitnessRequest.expected_version:type_name -> google.protobuf.Int64Value
+	7,   // 89: ufo.v1.AddWitnessResponse.witness:type_name -> ufo.v1.Witness
+	74,  // 90: ufo.v1.RemoveWitnessRequest.expected_version:type_name -> google.protobuf.Int64Value
+	5,   // 91: ufo.v1.FindSightingsByWitnessResponse.sightings:type_name -> ufo.v1.Sighting
+	10,  // 92: ufo.v1.UFOService.Create:input_type -> ufo.v1.CreateRequest
+	12,  // 93: ufo.v1.UFOService.Get:input_type -> ufo.v1.GetRequest
+	14,  // 94: ufo.v1.UFOService.Update:input_type -> ufo.v1.UpdateRequest
+	16,  // 95: ufo.v1.UFOService.Delete:input_type -> ufo.v1.DeleteRequest
+	18,  // 96: ufo.v1.UFOService.List:input_type -> ufo.v1.ListRequest
+	20,  // 97: ufo.v1.UFOService.Search:input_type -> ufo.v1.SearchRequest
+	25,  // 98: ufo.v1.UFOService.FindNearby:input_type -> ufo.v1.FindNearbyRequest
+	64,  // 99: ufo.v1.UFOService.AddWitness:input_type -> ufo.v1.AddWitnessRequest
+	66,  // 100: ufo.v1.UFOService.RemoveWitness:input_type -> ufo.v1.RemoveWitnessRequest
+	68,  // 101: ufo.v1.UFOService.FindSightingsByWitness:input_type -> ufo.v1.FindSightingsByWitnessRequest
+	60,  // 102: ufo.v1.UFOService.TransitionStatus:input_type -> ufo.v1.TransitionStatusRequest
+	62,  // 103: ufo.v1.UFOService.ListByStatus:input_type -> ufo.v1.ListByStatusRequest
+	50,  // 104: ufo.v1.UFOService.GetStats:input_type -> ufo.v1.GetStatsRequest
+	29,  // 105: ufo.v1.UFOService.Watch:input_type -> ufo.v1.WatchRequest
+	32,  // 106: ufo.v1.UFOService.ImportSightings:input_type -> ufo.v1.ImportSightingsRequest
+	37,  // 107: ufo.v1.UFOService.UploadAttachment:input_type -> ufo.v1.UploadAttachmentRequest
+	39,  // 108: ufo.v1.UFOService.DownloadAttachment:input_type -> ufo.v1.DownloadAttachmentRequest
+	41,  // 109: ufo.v1.UFOService.Restore:input_type -> ufo.v1.RestoreRequest
+	55,  // 110: ufo.v1.UFOService.FindDuplicates:input_type -> ufo.v1.FindDuplicatesRequest
+	58,  // 111: ufo.v1.UFOService.MergeSightings:input_type -> ufo.v1.MergeSightingsRequest
+	45,  // 112: ufo.v1.UFOService.GetHistory:input_type -> ufo.v1.GetHistoryRequest
+	47,  // 113: ufo.v1.UFOService.GetRevision:input_type -> ufo.v1.GetRevisionRequest
+	48,  // 114: ufo.v1.UFOService.RevertSighting:input_type -> ufo.v1.RevertSightingRequest
+	42,  // 115: ufo.v1.UFOService.Purge:input_type -> ufo.v1.PurgeRequest
+	11,  // 116: ufo.v1.UFOService.Create:output_type -> ufo.v1.CreateResponse
+	13,  // 117: ufo.v1.UFOService.Get:output_type -> ufo.v1.GetResponse
+	15,  // 118: ufo.v1.UFOService.Update:output_type -> ufo.v1.UpdateResponse
+	77,  // 119: ufo.v1.UFOService.Delete:output_type -> google.protobuf.Empty
+	19,  // 120: ufo.v1.UFOService.List:output_type -> ufo.v1.ListResponse
+	22,  // 121: ufo.v1.UFOService.Search:output_type -> ufo.v1.SearchResponse
+	27,  // 122: ufo.v1.UFOService.FindNearby:output_type -> ufo.v1.FindNearbyResponse
+	65,  // 123: ufo.v1.UFOService.AddWitness:output_type -> ufo.v1.AddWitnessResponse
+	67,  // 124: ufo.v1.UFOService.RemoveWitness:output_type -> ufo.v1.RemoveWitnessResponse
+	69,  // 125: ufo.v1.UFOService.FindSightingsByWitness:output_type -> ufo.v1.FindSightingsByWitnessResponse
+	61,  // 126: ufo.v1.UFOService.TransitionStatus:output_type -> ufo.v1.TransitionStatusResponse
+	63,  // 127: ufo.v1.UFOService.ListByStatus:output_type -> ufo.v1.ListByStatusResponse
+	54,  // 128: ufo.v1.UFOService.GetStats:output_type -> ufo.v1.GetStatsResponse
+	30,  // 129: ufo.v1.UFOService.Watch:output_type -> ufo.v1.WatchResponse
+	35,  // 130: ufo.v1.UFOService.ImportSightings:output_type -> ufo.v1.ImportSightingsResponse
+	38,  // 131: ufo.v1.UFOService.UploadAttachment:output_type -> ufo.v1.UploadAttachmentResponse
+	40,  // 132: ufo.v1.UFOService.DownloadAttachment:output_type -> ufo.v1.DownloadAttachmentResponse
+	77,  // 133: ufo.v1.UFOService.Restore:output_type -> google.protobuf.Empty
+	57,  // 134: ufo.v1.UFOService.FindDuplicates:output_type -> ufo.v1.FindDuplicatesResponse
+	59,  // 135: ufo.v1.UFOService.MergeSightings:output_type -> ufo.v1.MergeSightingsResponse
+	46,  // 136: ufo.v1.UFOService.GetHistory:output_type -> ufo.v1.GetHistoryResponse
+	44,  // 137: ufo.v1.UFOService.GetRevision:output_type -> ufo.v1.Revision
+	49,  // 138: ufo.v1.UFOService.RevertSighting:output_type -> ufo.v1.RevertSightingResponse
+	77,  // 139: ufo.v1.UFOService.Purge:output_type -> google.protobuf.Empty
+	116, // [116:140] is the sub-list for method output_type
+	92,  // [92:116] is the sub-list for method input_type
+	92,  // [92:92] is the sub-list for extension type_name
+	92,  // [92:92] is the sub-list for extension extendee
+	0,   // [0:92] is the sub-list for field type_name
 }
 
 func init() { file_ufo_v1_ufo_proto_init() }
@@ -664,18 +5250,39 @@ func file_ufo_v1_ufo_proto_init() {
 	if File_ufo_v1_ufo_proto != nil {
 		return
 	}
+	file_ufo_v1_ufo_proto_msgTypes[23].OneofWrappers = []any{
+		(*FindNearbyRequest_Circle)(nil),
+		(*FindNearbyRequest_Box)(nil),
+	}
+	file_ufo_v1_ufo_proto_msgTypes[30].OneofWrappers = []any{
+		(*ImportSightingsRequest_Options)(nil),
+		(*ImportSightingsRequest_Info)(nil),
+	}
+	file_ufo_v1_ufo_proto_msgTypes[35].OneofWrappers = []any{
+		(*UploadAttachmentRequest_Header)(nil),
+		(*UploadAttachmentRequest_Chunk)(nil),
+	}
+	file_ufo_v1_ufo_proto_msgTypes[38].OneofWrappers = []any{
+		(*DownloadAttachmentResponse_Attachment)(nil),
+		(*DownloadAttachmentResponse_Chunk)(nil),
+	}
+	file_ufo_v1_ufo_proto_msgTypes[66].OneofWrappers = []any{
+		(*FindSightingsByWitnessRequest_Name)(nil),
+		(*FindSightingsByWitnessRequest_Contact)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ufo_v1_ufo_proto_rawDesc), len(file_ufo_v1_ufo_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   9,
+			NumEnums:      2,
+			NumMessages:   68,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_ufo_v1_ufo_proto_goTypes,
 		DependencyIndexes: file_ufo_v1_ufo_proto_depIdxs,
+		EnumInfos:         file_ufo_v1_ufo_proto_enumTypes,
 		MessageInfos:      file_ufo_v1_ufo_proto_msgTypes,
 	}.Build()
 	File_ufo_v1_ufo_proto = out.File
@@ -20,10 +20,30 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UFOService_Create_FullMethodName = "/ufo.v1.UFOService/Create"
-	UFOService_Get_FullMethodName    = "/ufo.v1.UFOService/Get"
-	UFOService_Update_FullMethodName = "/ufo.v1.UFOService/Update"
-	UFOService_Delete_FullMethodName = "/ufo.v1.UFOService/Delete"
+	UFOService_Create_FullMethodName                 = "/ufo.v1.UFOService/Create"
+	UFOService_Get_FullMethodName                    = "/ufo.v1.UFOService/Get"
+	UFOService_Update_FullMethodName                 = "/ufo.v1.UFOService/Update"
+	UFOService_Delete_FullMethodName                 = "/ufo.v1.UFOService/Delete"
+	UFOService_List_FullMethodName                   = "/ufo.v1.UFOService/List"
+	UFOService_Search_FullMethodName                 = "/ufo.v1.UFOService/Search"
+	UFOService_FindNearby_FullMethodName             = "/ufo.v1.UFOService/FindNearby"
+	UFOService_AddWitness_FullMethodName             = "/ufo.v1.UFOService/AddWitness"
+	UFOService_RemoveWitness_FullMethodName          = "/ufo.v1.UFOService/RemoveWitness"
+	UFOService_FindSightingsByWitness_FullMethodName = "/ufo.v1.UFOService/FindSightingsByWitness"
+	UFOService_TransitionStatus_FullMethodName       = "/ufo.v1.UFOService/TransitionStatus"
+	UFOService_ListByStatus_FullMethodName           = "/ufo.v1.UFOService/ListByStatus"
+	UFOService_GetStats_FullMethodName               = "/ufo.v1.UFOService/GetStats"
+	UFOService_Watch_FullMethodName                  = "/ufo.v1.UFOService/Watch"
+	UFOService_ImportSightings_FullMethodName        = "/ufo.v1.UFOService/ImportSightings"
+	UFOService_UploadAttachment_FullMethodName       = "/ufo.v1.UFOService/UploadAttachment"
+	UFOService_DownloadAttachment_FullMethodName     = "/ufo.v1.UFOService/DownloadAttachment"
+	UFOService_Restore_FullMethodName                = "/ufo.v1.UFOService/Restore"
+	UFOService_FindDuplicates_FullMethodName         = "/ufo.v1.UFOService/FindDuplicates"
+	UFOService_MergeSightings_FullMethodName         = "/ufo.v1.UFOService/MergeSightings"
+	UFOService_GetHistory_FullMethodName             = "/ufo.v1.UFOService/GetHistory"
+	UFOService_GetRevision_FullMethodName            = "/ufo.v1.UFOService/GetRevision"
+	UFOService_RevertSighting_FullMethodName         = "/ufo.v1.UFOService/RevertSighting"
+	UFOService_Purge_FullMethodName                  = "/ufo.v1.UFOService/Purge"
 )
 
 // UFOServiceClient is the client API for UFOService service.
@@ -32,8 +52,55 @@ const (
 type UFOServiceClient interface {
 	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
-	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Update в REST принимает в теле только update_info: маска строится из переданных
+	// JSON-полей, а поле со значением null сбрасывается
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// List возвращает наблюдения постранично с фильтрацией
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Search ищет наблюдения по словам из описания и места, самые релевантные первыми
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// FindNearby ищет наблюдения с координатами в круге или прямоугольнике, ближайшие первыми
+	FindNearby(ctx context.Context, in *FindNearbyRequest, opts ...grpc.CallOption) (*FindNearbyResponse, error)
+	// AddWitness добавляет очевидца к наблюдению
+	AddWitness(ctx context.Context, in *AddWitnessRequest, opts ...grpc.CallOption) (*AddWitnessResponse, error)
+	// RemoveWitness убирает очевидца из наблюдения
+	RemoveWitness(ctx context.Context, in *RemoveWitnessRequest, opts ...grpc.CallOption) (*RemoveWitnessResponse, error)
+	// FindSightingsByWitness возвращает неудаленные наблюдения одного очевидца, упорядоченные как в List
+	FindSightingsByWitness(ctx context.Context, in *FindSightingsByWitnessRequest, opts ...grpc.CallOption) (*FindSightingsByWitnessResponse, error)
+	// TransitionStatus переводит наблюдение на следующий этап модерации
+	TransitionStatus(ctx context.Context, in *TransitionStatusRequest, opts ...grpc.CallOption) (*TransitionStatusResponse, error)
+	// ListByStatus возвращает неудаленные наблюдения с данным статусом модерации, упорядоченные как в List
+	ListByStatus(ctx context.Context, in *ListByStatusRequest, opts ...grpc.CallOption) (*ListByStatusResponse, error)
+	// GetStats считает сводную статистику по наблюдениям, подходящим под фильтр
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	// Watch транслирует события изменения наблюдений по мере их появления.
+	// Через REST события приходят как JSON, по объекту на строку
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchResponse], error)
+	// ImportSightings массово создает наблюдения из клиентского стрима.
+	// Без atomic ошибка элемента, в том числе исчерпанная квота, попадает в errors
+	// ответа, а загрузка продолжается со следующего элемента
+	ImportSightings(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportSightingsRequest, ImportSightingsResponse], error)
+	// UploadAttachment загружает фото или видео к наблюдению клиентским стримом:
+	// первым сообщением заголовок, затем содержимое частями. Через REST недоступен
+	UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentRequest, UploadAttachmentResponse], error)
+	// DownloadAttachment отдает вложение серверным стримом: сначала метаданные, затем содержимое частями
+	DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadAttachmentResponse], error)
+	// Restore восстанавливает удаленное наблюдение
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// FindDuplicates ищет неудаленные наблюдения, похожие на данное
+	FindDuplicates(ctx context.Context, in *FindDuplicatesRequest, opts ...grpc.CallOption) (*FindDuplicatesResponse, error)
+	// MergeSightings объединяет повторные сообщения об одном событии в основное наблюдение
+	MergeSightings(ctx context.Context, in *MergeSightingsRequest, opts ...grpc.CallOption) (*MergeSightingsResponse, error)
+	// GetHistory возвращает ревизии наблюдения по возрастанию версии, в том числе удаленного
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// GetRevision возвращает одну ревизию наблюдения
+	GetRevision(ctx context.Context, in *GetRevisionRequest, opts ...grpc.CallOption) (*Revision, error)
+	// RevertSighting возвращает информацию о наблюдении к состоянию ревизии,
+	// записывая возврат новой ревизией
+	RevertSighting(ctx context.Context, in *RevertSightingRequest, opts ...grpc.CallOption) (*RevertSightingResponse, error)
+	// Purge безвозвратно удаляет ранее удаленное наблюдение
+	Purge(ctx context.Context, in *PurgeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type uFOServiceClient struct {
@@ -64,9 +131,9 @@ func (c *uFOServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc
 	return out, nil
 }
 
-func (c *uFOServiceClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *uFOServiceClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(emptypb.Empty)
+	out := new(UpdateResponse)
 	err := c.cc.Invoke(ctx, UFOService_Update_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -84,14 +151,285 @@ func (c *uFOServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts .
 	return out, nil
 }
 
+func (c *uFOServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, UFOService_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uFOServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, UFOService_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uFOServiceClient) FindNearby(ctx context.Context, in *FindNearbyRequest, opts ...grpc.CallOption) (*FindNearbyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindNearbyResponse)
+	err := c.cc.Invoke(ctx, UFOService_FindNearby_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uFOServiceClient) AddWitness(ctx context.Context, in *AddWitnessRequest, opts ...grpc.CallOption) (*AddWitnessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddWitnessResponse)
+	err := c.cc.Invoke(ctx, UFOService_AddWitness_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uFOServiceClient) RemoveWitness(ctx context.Context, in *RemoveWitnessRequest, opts ...grpc.CallOption) (*RemoveWitnessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveWitnessResponse)
+	err := c.cc.Invoke(ctx, UFOService_RemoveWitness_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uFOServiceClient) FindSightingsByWitness(ctx context.Context, in *FindSightingsByWitnessRequest, opts ...grpc.CallOption) (*FindSightingsByWitnessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindSightingsByWitnessResponse)
+	err := c.cc.Invoke(ctx, UFOService_FindSightingsByWitness_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uFOServiceClient) TransitionStatus(ctx context.Context, in *TransitionStatusRequest, opts ...grpc.CallOption) (*TransitionStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransitionStatusResponse)
+	err := c.cc.Invoke(ctx, UFOService_TransitionStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uFOServiceClient) ListByStatus(ctx context.Context, in *ListByStatusRequest, opts ...grpc.CallOption) (*ListByStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListByStatusResponse)
+	err := c.cc.Invoke(ctx, UFOService_ListByStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uFOServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, UFOService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uFOServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UFOService_ServiceDesc.Streams[0], UFOService_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, WatchResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UFOService_WatchClient = grpc.ServerStreamingClient[WatchResponse]
+
+func (c *uFOServiceClient) ImportSightings(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportSightingsRequest, ImportSightingsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UFOService_ServiceDesc.Streams[1], UFOService_ImportSightings_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportSightingsRequest, ImportSightingsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UFOService_ImportSightingsClient = grpc.ClientStreamingClient[ImportSightingsRequest, ImportSightingsResponse]
+
+func (c *uFOServiceClient) UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentRequest, UploadAttachmentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UFOService_ServiceDesc.Streams[2], UFOService_UploadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadAttachmentRequest, UploadAttachmentResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UFOService_UploadAttachmentClient = grpc.ClientStreamingClient[UploadAttachmentRequest, UploadAttachmentResponse]
+
+func (c *uFOServiceClient) DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadAttachmentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UFOService_ServiceDesc.Streams[3], UFOService_DownloadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadAttachmentRequest, DownloadAttachmentResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UFOService_DownloadAttachmentClient = grpc.ServerStreamingClient[DownloadAttachmentResponse]
+
+func (c *uFOServiceClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UFOService_Restore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uFOServiceClient) FindDuplicates(ctx context.Context, in *FindDuplicatesRequest, opts ...grpc.CallOption) (*FindDuplicatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindDuplicatesResponse)
+	err := c.cc.Invoke(ctx, UFOService_FindDuplicates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uFOServiceClient) MergeSightings(ctx context.Context, in *MergeSightingsRequest, opts ...grpc.CallOption) (*MergeSightingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeSightingsResponse)
+	err := c.cc.Invoke(ctx, UFOService_MergeSightings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uFOServiceClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, UFOService_GetHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uFOServiceClient) GetRevision(ctx context.Context, in *GetRevisionRequest, opts ...grpc.CallOption) (*Revision, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Revision)
+	err := c.cc.Invoke(ctx, UFOService_GetRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uFOServiceClient) RevertSighting(ctx context.Context, in *RevertSightingRequest, opts ...grpc.CallOption) (*RevertSightingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevertSightingResponse)
+	err := c.cc.Invoke(ctx, UFOService_RevertSighting_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uFOServiceClient) Purge(ctx context.Context, in *PurgeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UFOService_Purge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UFOServiceServer is the server API for UFOService service.
 // All implementations must embed UnimplementedUFOServiceServer
 // for forward compatibility.
 type UFOServiceServer interface {
 	Create(context.Context, *CreateRequest) (*CreateResponse, error)
 	Get(context.Context, *GetRequest) (*GetResponse, error)
-	Update(context.Context, *UpdateRequest) (*emptypb.Empty, error)
+	// Update в REST принимает в теле только update_info: маска строится из переданных
+	// JSON-полей, а поле со значением null сбрасывается
+	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
 	Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	// List возвращает наблюдения постранично с фильтрацией
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	// Search ищет наблюдения по словам из описания и места, самые релевантные первыми
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// FindNearby ищет наблюдения с координатами в круге или прямоугольнике, ближайшие первыми
+	FindNearby(context.Context, *FindNearbyRequest) (*FindNearbyResponse, error)
+	// AddWitness добавляет очевидца к наблюдению
+	AddWitness(context.Context, *AddWitnessRequest) (*AddWitnessResponse, error)
+	// RemoveWitness убирает очевидца из наблюдения
+	RemoveWitness(context.Context, *RemoveWitnessRequest) (*RemoveWitnessResponse, error)
+	// FindSightingsByWitness возвращает неудаленные наблюдения одного очевидца, упорядоченные как в List
+	FindSightingsByWitness(context.Context, *FindSightingsByWitnessRequest) (*FindSightingsByWitnessResponse, error)
+	// TransitionStatus переводит наблюдение на следующий этап модерации
+	TransitionStatus(context.Context, *TransitionStatusRequest) (*TransitionStatusResponse, error)
+	// ListByStatus возвращает неудаленные наблюдения с данным статусом модерации, упорядоченные как в List
+	ListByStatus(context.Context, *ListByStatusRequest) (*ListByStatusResponse, error)
+	// GetStats считает сводную статистику по наблюдениям, подходящим под фильтр
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	// Watch транслирует события изменения наблюдений по мере их появления.
+	// Через REST события приходят как JSON, по объекту на строку
+	Watch(*WatchRequest, grpc.ServerStreamingServer[WatchResponse]) error
+	// ImportSightings массово создает наблюдения из клиентского стрима.
+	// Без atomic ошибка элемента, в том числе исчерпанная квота, попадает в errors
+	// ответа, а загрузка продолжается со следующего элемента
+	ImportSightings(grpc.ClientStreamingServer[ImportSightingsRequest, ImportSightingsResponse]) error
+	// UploadAttachment загружает фото или видео к наблюдению клиентским стримом:
+	// первым сообщением заголовок, затем содержимое частями. Через REST недоступен
+	UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentRequest, UploadAttachmentResponse]) error
+	// DownloadAttachment отдает вложение серверным стримом: сначала метаданные, затем содержимое частями
+	DownloadAttachment(*DownloadAttachmentRequest, grpc.ServerStreamingServer[DownloadAttachmentResponse]) error
+	// Restore восстанавливает удаленное наблюдение
+	Restore(context.Context, *RestoreRequest) (*emptypb.Empty, error)
+	// FindDuplicates ищет неудаленные наблюдения, похожие на данное
+	FindDuplicates(context.Context, *FindDuplicatesRequest) (*FindDuplicatesResponse, error)
+	// MergeSightings объединяет повторные сообщения об одном событии в основное наблюдение
+	MergeSightings(context.Context, *MergeSightingsRequest) (*MergeSightingsResponse, error)
+	// GetHistory возвращает ревизии наблюдения по возрастанию версии, в том числе удаленного
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// GetRevision возвращает одну ревизию наблюдения
+	GetRevision(context.Context, *GetRevisionRequest) (*Revision, error)
+	// RevertSighting возвращает информацию о наблюдении к состоянию ревизии,
+	// записывая возврат новой ревизией
+	RevertSighting(context.Context, *RevertSightingRequest) (*RevertSightingResponse, error)
+	// Purge безвозвратно удаляет ранее удаленное наблюдение
+	Purge(context.Context, *PurgeRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedUFOServiceServer()
 }
 
@@ -108,12 +446,72 @@ func (UnimplementedUFOServiceServer) Create(context.Context, *CreateRequest) (*C
 func (UnimplementedUFOServiceServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
-func (UnimplementedUFOServiceServer) Update(context.Context, *UpdateRequest) (*emptypb.Empty, error) {
+func (UnimplementedUFOServiceServer) Update(context.Context, *UpdateRequest) (*UpdateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
 }
 func (UnimplementedUFOServiceServer) Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedUFOServiceServer) List(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedUFOServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedUFOServiceServer) FindNearby(context.Context, *FindNearbyRequest) (*FindNearbyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindNearby not implemented")
+}
+func (UnimplementedUFOServiceServer) AddWitness(context.Context, *AddWitnessRequest) (*AddWitnessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWitness not implemented")
+}
+func (UnimplementedUFOServiceServer) RemoveWitness(context.Context, *RemoveWitnessRequest) (*RemoveWitnessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWitness not implemented")
+}
+func (UnimplementedUFOServiceServer) FindSightingsByWitness(context.Context, *FindSightingsByWitnessRequest) (*FindSightingsByWitnessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindSightingsByWitness not implemented")
+}
+func (UnimplementedUFOServiceServer) TransitionStatus(context.Context, *TransitionStatusRequest) (*TransitionStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransitionStatus not implemented")
+}
+func (UnimplementedUFOServiceServer) ListByStatus(context.Context, *ListByStatusRequest) (*ListByStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListByStatus not implemented")
+}
+func (UnimplementedUFOServiceServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedUFOServiceServer) Watch(*WatchRequest, grpc.ServerStreamingServer[WatchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedUFOServiceServer) ImportSightings(grpc.ClientStreamingServer[ImportSightingsRequest, ImportSightingsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportSightings not implemented")
+}
+func (UnimplementedUFOServiceServer) UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentRequest, UploadAttachmentResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (UnimplementedUFOServiceServer) DownloadAttachment(*DownloadAttachmentRequest, grpc.ServerStreamingServer[DownloadAttachmentResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadAttachment not implemented")
+}
+func (UnimplementedUFOServiceServer) Restore(context.Context, *RestoreRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedUFOServiceServer) FindDuplicates(context.Context, *FindDuplicatesRequest) (*FindDuplicatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindDuplicates not implemented")
+}
+func (UnimplementedUFOServiceServer) MergeSightings(context.Context, *MergeSightingsRequest) (*MergeSightingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeSightings not implemented")
+}
+func (UnimplementedUFOServiceServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedUFOServiceServer) GetRevision(context.Context, *GetRevisionRequest) (*Revision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevision not implemented")
+}
+func (UnimplementedUFOServiceServer) RevertSighting(context.Context, *RevertSightingRequest) (*RevertSightingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertSighting not implemented")
+}
+func (UnimplementedUFOServiceServer) Purge(context.Context, *PurgeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Purge not implemented")
+}
 func (UnimplementedUFOServiceServer) mustEmbedUnimplementedUFOServiceServer() {}
 func (UnimplementedUFOServiceServer) testEmbeddedByValue()                    {}
 
@@ -207,6 +605,330 @@ func _UFOService_Delete_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UFOService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UFOServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UFOService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UFOServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UFOService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UFOServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UFOService_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UFOServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UFOService_FindNearby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindNearbyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UFOServiceServer).FindNearby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UFOService_FindNearby_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UFOServiceServer).FindNearby(ctx, req.(*FindNearbyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UFOService_AddWitness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWitnessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UFOServiceServer).AddWitness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UFOService_AddWitness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UFOServiceServer).AddWitness(ctx, req.(*AddWitnessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UFOService_RemoveWitness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveWitnessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UFOServiceServer).RemoveWitness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UFOService_RemoveWitness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UFOServiceServer).RemoveWitness(ctx, req.(*RemoveWitnessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UFOService_FindSightingsByWitness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSightingsByWitnessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UFOServiceServer).FindSightingsByWitness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UFOService_FindSightingsByWitness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UFOServiceServer).FindSightingsByWitness(ctx, req.(*FindSightingsByWitnessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UFOService_TransitionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransitionStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UFOServiceServer).TransitionStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UFOService_TransitionStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UFOServiceServer).TransitionStatus(ctx, req.(*TransitionStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UFOService_ListByStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListByStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UFOServiceServer).ListByStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UFOService_ListByStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UFOServiceServer).ListByStatus(ctx, req.(*ListByStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UFOService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UFOServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UFOService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UFOServiceServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UFOService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UFOServiceServer).Watch(m, &grpc.GenericServerStream[WatchRequest, WatchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UFOService_WatchServer = grpc.ServerStreamingServer[WatchResponse]
+
+func _UFOService_ImportSightings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UFOServiceServer).ImportSightings(&grpc.GenericServerStream[ImportSightingsRequest, ImportSightingsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UFOService_ImportSightingsServer = grpc.ClientStreamingServer[ImportSightingsRequest, ImportSightingsResponse]
+
+func _UFOService_UploadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UFOServiceServer).UploadAttachment(&grpc.GenericServerStream[UploadAttachmentRequest, UploadAttachmentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UFOService_UploadAttachmentServer = grpc.ClientStreamingServer[UploadAttachmentRequest, UploadAttachmentResponse]
+
+func _UFOService_DownloadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadAttachmentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UFOServiceServer).DownloadAttachment(m, &grpc.GenericServerStream[DownloadAttachmentRequest, DownloadAttachmentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UFOService_DownloadAttachmentServer = grpc.ServerStreamingServer[DownloadAttachmentResponse]
+
+func _UFOService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UFOServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UFOService_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UFOServiceServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UFOService_FindDuplicates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindDuplicatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UFOServiceServer).FindDuplicates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UFOService_FindDuplicates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UFOServiceServer).FindDuplicates(ctx, req.(*FindDuplicatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UFOService_MergeSightings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeSightingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UFOServiceServer).MergeSightings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UFOService_MergeSightings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UFOServiceServer).MergeSightings(ctx, req.(*MergeSightingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UFOService_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UFOServiceServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UFOService_GetHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UFOServiceServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UFOService_GetRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UFOServiceServer).GetRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UFOService_GetRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UFOServiceServer).GetRevision(ctx, req.(*GetRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UFOService_RevertSighting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertSightingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UFOServiceServer).RevertSighting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UFOService_RevertSighting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UFOServiceServer).RevertSighting(ctx, req.(*RevertSightingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UFOService_Purge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UFOServiceServer).Purge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UFOService_Purge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UFOServiceServer).Purge(ctx, req.(*PurgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UFOService_ServiceDesc is the grpc.ServiceDesc for UFOService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -230,7 +952,92 @@ var UFOService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _UFOService_Delete_Handler,
 		},
+		{
+			MethodName: "List",
+			Handler:    _UFOService_List_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _UFOService_Search_Handler,
+		},
+		{
+			MethodName: "FindNearby",
+			Handler:    _UFOService_FindNearby_Handler,
+		},
+		{
+			MethodName: "AddWitness",
+			Handler:    _UFOService_AddWitness_Handler,
+		},
+		{
+			MethodName: "RemoveWitness",
+			Handler:    _UFOService_RemoveWitness_Handler,
+		},
+		{
+			MethodName: "FindSightingsByWitness",
+			Handler:    _UFOService_FindSightingsByWitness_Handler,
+		},
+		{
+			MethodName: "TransitionStatus",
+			Handler:    _UFOService_TransitionStatus_Handler,
+		},
+		{
+			MethodName: "ListByStatus",
+			Handler:    _UFOService_ListByStatus_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _UFOService_GetStats_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _UFOService_Restore_Handler,
+		},
+		{
+			MethodName: "FindDuplicates",
+			Handler:    _UFOService_FindDuplicates_Handler,
+		},
+		{
+			MethodName: "MergeSightings",
+			Handler:    _UFOService_MergeSightings_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _UFOService_GetHistory_Handler,
+		},
+		{
+			MethodName: "GetRevision",
+			Handler:    _UFOService_GetRevision_Handler,
+		},
+		{
+			MethodName: "RevertSighting",
+			Handler:    _UFOService_RevertSighting_Handler,
+		},
+		{
+			MethodName: "Purge",
+			Handler:    _UFOService_Purge_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _UFOService_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportSightings",
+			Handler:       _UFOService_ImportSightings_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "UploadAttachment",
+			Handler:       _UFOService_UploadAttachment_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadAttachment",
+			Handler:       _UFOService_DownloadAttachment_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "ufo/v1/ufo.proto",
 }